@@ -44,6 +44,29 @@ const (
 	featurePubWithoutTruncate
 	featureFunction
 	featureServer
+	featureIdentityColumn
+	featureMaterializedView
+	featureRefreshMaterializedViewConcurrently
+	featureViewSecurityInvoker
+	featureRestrictivePolicy
+	featureSequenceDataType
+	featureEnumRenameValue
+	featureEnumAddValueInTransaction
+	featureTriggerTransitionTables
+	featureTriggerExecuteFunction
+	featureEventTrigger
+	featureForeignDataWrapperHandler
+	featureForeignTable
+	featureForeignTablePartition
+	featureImportForeignSchema
+	featureAlterSystem
+	featureGrantRoleInheritOption
+	featureGrantRoleSetOption
+	featureGrantRoleGrantedBy
+	featureGrantOnType
+	featureGrantOnParameter
+	featureDefaultPrivilegesOnLargeObjects
+	featureEventTriggerLogin
 )
 
 var (
@@ -114,6 +137,75 @@ var (
 		// CREATE SERVER support
 		featureServer: semver.MustParseRange(">=10.0.0"),
 
+		// GENERATED AS IDENTITY columns
+		featureIdentityColumn: semver.MustParseRange(">=10.0.0"),
+
+		// CREATE MATERIALIZED VIEW support
+		featureMaterializedView: semver.MustParseRange(">=9.3.0"),
+
+		// REFRESH MATERIALIZED VIEW CONCURRENTLY support
+		featureRefreshMaterializedViewConcurrently: semver.MustParseRange(">=9.4.0"),
+
+		// security_invoker option for views
+		featureViewSecurityInvoker: semver.MustParseRange(">=15.0.0"),
+
+		// AS RESTRICTIVE for policies
+		featureRestrictivePolicy: semver.MustParseRange(">=10.0.0"),
+
+		// CREATE SEQUENCE AS data_type and pg_sequence catalog
+		featureSequenceDataType: semver.MustParseRange(">=10.0.0"),
+
+		// ALTER TYPE ... RENAME VALUE for enums
+		featureEnumRenameValue: semver.MustParseRange(">=10.0.0"),
+
+		// ALTER TYPE ... ADD VALUE inside a transaction block
+		featureEnumAddValueInTransaction: semver.MustParseRange(">=12.0.0"),
+
+		// REFERENCING OLD/NEW TABLE (transition tables) for triggers
+		featureTriggerTransitionTables: semver.MustParseRange(">=10.0.0"),
+
+		// EXECUTE FUNCTION syntax for triggers (EXECUTE PROCEDURE before)
+		featureTriggerExecuteFunction: semver.MustParseRange(">=11.0.0"),
+
+		// CREATE EVENT TRIGGER support
+		featureEventTrigger: semver.MustParseRange(">=9.3.0"),
+
+		// HANDLER for foreign data wrappers
+		featureForeignDataWrapperHandler: semver.MustParseRange(">=9.1.0"),
+
+		// CREATE FOREIGN TABLE support
+		featureForeignTable: semver.MustParseRange(">=9.1.0"),
+
+		// CREATE FOREIGN TABLE ... PARTITION OF support
+		featureForeignTablePartition: semver.MustParseRange(">=10.0.0"),
+
+		// IMPORT FOREIGN SCHEMA support
+		featureImportForeignSchema: semver.MustParseRange(">=9.5.0"),
+
+		// ALTER SYSTEM support with pg_file_settings and pg_settings.pending_restart
+		featureAlterSystem: semver.MustParseRange(">=9.5.0"),
+
+		// GRANT role ... WITH INHERIT TRUE|FALSE
+		featureGrantRoleInheritOption: semver.MustParseRange(">=16.0.0"),
+
+		// GRANT role ... WITH SET TRUE|FALSE
+		featureGrantRoleSetOption: semver.MustParseRange(">=16.0.0"),
+
+		// GRANT/REVOKE role ... GRANTED BY, with one membership per grantor
+		featureGrantRoleGrantedBy: semver.MustParseRange(">=16.0.0"),
+
+		// GRANT USAGE ON TYPE / DOMAIN
+		featureGrantOnType: semver.MustParseRange(">=9.2.0"),
+
+		// GRANT SET, ALTER SYSTEM ON PARAMETER
+		featureGrantOnParameter: semver.MustParseRange(">=15.0.0"),
+
+		// ALTER DEFAULT PRIVILEGES ... ON LARGE OBJECTS
+		featureDefaultPrivilegesOnLargeObjects: semver.MustParseRange(">=18.0.0"),
+
+		// CREATE EVENT TRIGGER ... ON login
+		featureEventTriggerLogin: semver.MustParseRange(">=17.0.0"),
+
 		featureDatabaseOwnerRole: semver.MustParseRange(">=15.0.0"),
 	}
 )
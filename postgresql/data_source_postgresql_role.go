@@ -0,0 +1,202 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const getRoleQuery = `
+SELECT
+  rolsuper,
+  rolinherit,
+  rolcreaterole,
+  rolcreatedb,
+  rolcanlogin,
+  rolreplication,
+  rolbypassrls,
+  rolconnlimit,
+  COALESCE(rolvaliduntil::TEXT, 'infinity'),
+  rolconfig,
+  ARRAY(
+    SELECT pg_get_userbyid(roleid) FROM pg_catalog.pg_auth_members WHERE member = pg_roles.oid ORDER BY 1
+  ),
+  ARRAY(
+    SELECT pg_get_userbyid(member) FROM pg_catalog.pg_auth_members WHERE roleid = pg_roles.oid ORDER BY 1
+  )
+FROM pg_catalog.pg_roles
+WHERE rolname = $1
+`
+
+func dataSourcePostgreSQLRole() *schema.Resource {
+	return &schema.Resource{
+		Read: PGResourceFunc(dataSourcePostgreSQLRoleRead),
+		Schema: map[string]*schema.Schema{
+			roleNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the role",
+			},
+			roleSuperuserAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the role is a superuser",
+			},
+			roleInheritAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the role inherits the privileges of the roles it is a member of",
+			},
+			roleCreateRoleAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the role can create, alter and drop other roles",
+			},
+			roleCreateDBAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the role can create databases",
+			},
+			roleLoginAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the role can log in",
+			},
+			roleReplicationAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the role is a replication role",
+			},
+			roleBypassRLSAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the role bypasses every row-level security (RLS) policy",
+			},
+			roleConnLimitAttr: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "How many concurrent connections can be made with this role, -1 means no limit",
+			},
+			roleValidUntilAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time after which the role's password is no longer valid",
+			},
+			roleSearchPathAttr: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The search path of the role",
+			},
+			roleStatementTimeoutAttr: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The statement_timeout of the role in milliseconds",
+			},
+			roleIdleInTransactionSessionTimeoutAttr: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The idle_in_transaction_session_timeout of the role in milliseconds",
+			},
+			roleAssumeRoleAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The role switched to at login",
+			},
+			roleParametersAttr: {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The other configuration parameters of the role",
+			},
+			roleRolesAttr: {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "The roles this role is a direct member of",
+			},
+			"members": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "The direct members of this role",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLRoleRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureRLS) {
+		return fmt.Errorf(
+			"postgresql_role data source is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	var roleSuperuser, roleInherit, roleCreateRole, roleCreateDB, roleCanLogin, roleReplication, roleBypassRLS bool
+	var roleConnLimit int
+	var roleValidUntil string
+	var roleConfig, roleRoles, roleMembers pq.ByteaArray
+
+	roleName := d.Get(roleNameAttr).(string)
+
+	err := db.QueryRow(getRoleQuery, roleName).Scan(
+		&roleSuperuser,
+		&roleInherit,
+		&roleCreateRole,
+		&roleCreateDB,
+		&roleCanLogin,
+		&roleReplication,
+		&roleBypassRLS,
+		&roleConnLimit,
+		&roleValidUntil,
+		&roleConfig,
+		&roleRoles,
+		&roleMembers,
+	)
+	switch {
+	case err == sql.ErrNoRows:
+		return fmt.Errorf("role %s not found", roleName)
+	case err != nil:
+		return fmt.Errorf("error reading role %s: %w", roleName, err)
+	}
+
+	statementTimeout, err := readStatementTimeout(roleConfig)
+	if err != nil {
+		return err
+	}
+
+	idleInTransactionSessionTimeout, err := readIdleInTransactionSessionTimeout(roleConfig)
+	if err != nil {
+		return err
+	}
+
+	parameters, err := readRoleParameters(db, roleConfig, nil)
+	if err != nil {
+		return err
+	}
+
+	d.Set(roleSuperuserAttr, roleSuperuser)
+	d.Set(roleInheritAttr, roleInherit)
+	d.Set(roleCreateRoleAttr, roleCreateRole)
+	d.Set(roleCreateDBAttr, roleCreateDB)
+	d.Set(roleLoginAttr, roleCanLogin)
+	d.Set(roleReplicationAttr, roleReplication)
+	d.Set(roleBypassRLSAttr, roleBypassRLS)
+	d.Set(roleConnLimitAttr, roleConnLimit)
+	d.Set(roleValidUntilAttr, roleValidUntil)
+	d.Set(roleSearchPathAttr, readSearchPath(roleConfig))
+	d.Set(roleStatementTimeoutAttr, statementTimeout)
+	d.Set(roleIdleInTransactionSessionTimeoutAttr, idleInTransactionSessionTimeout)
+	d.Set(roleAssumeRoleAttr, readAssumeRole(roleConfig))
+	d.Set(roleParametersAttr, parameters)
+	d.Set(roleRolesAttr, pgArrayToSet(roleRoles))
+	d.Set("members", pgArrayToSet(roleMembers))
+	d.SetId(roleName)
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package postgresql
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceRole(t *testing.T) {
+	skipIfNotAcc(t)
+
+	config := `
+resource "postgresql_role" "group" {
+  name = "ds_group"
+}
+
+resource "postgresql_role" "member" {
+  name              = "ds_member"
+  login             = true
+  connection_limit  = 5
+  search_path       = ["foo", "bar"]
+  statement_timeout = 30000
+  roles             = [postgresql_role.group.name]
+  parameters = {
+    lock_timeout = "5s"
+  }
+}
+
+data "postgresql_role" "member" {
+  name = postgresql_role.member.name
+}
+
+data "postgresql_role" "group" {
+  name       = postgresql_role.group.name
+  depends_on = [postgresql_role.member]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureRLS)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_role.member", "login", "true"),
+					resource.TestCheckResourceAttr("data.postgresql_role.member", "superuser", "false"),
+					resource.TestCheckResourceAttr("data.postgresql_role.member", "connection_limit", "5"),
+					resource.TestCheckResourceAttr("data.postgresql_role.member", "valid_until", "infinity"),
+					resource.TestCheckResourceAttr("data.postgresql_role.member", "search_path.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_role.member", "search_path.0", "foo"),
+					resource.TestCheckResourceAttr("data.postgresql_role.member", "statement_timeout", "30000"),
+					resource.TestCheckResourceAttr("data.postgresql_role.member", "parameters.%", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_role.member", "parameters.lock_timeout", "5s"),
+					resource.TestCheckResourceAttr("data.postgresql_role.member", "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.postgresql_role.member", "roles.*", "ds_group"),
+					resource.TestCheckResourceAttr("data.postgresql_role.group", "login", "false"),
+					resource.TestCheckResourceAttr("data.postgresql_role.group", "members.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.postgresql_role.group", "members.*", "ds_member"),
+				),
+			},
+			{
+				Config: `
+data "postgresql_role" "missing" {
+  name = "ds_missing_role"
+}
+`,
+				ExpectError: regexp.MustCompile("role ds_missing_role not found"),
+			},
+		},
+	})
+}
@@ -0,0 +1,177 @@
+package postgresql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+var roleQueries = map[string]string{
+	"query_include_system_roles": `
+	SELECT rolname
+	FROM pg_catalog.pg_roles
+	`,
+	"query_exclude_system_roles": `
+	SELECT rolname
+	FROM pg_catalog.pg_roles
+	WHERE rolname NOT LIKE 'pg\_%'
+	`,
+}
+
+const (
+	rolePatternMatchingTarget = "rolname"
+	roleLoginKeyword          = "rolcanlogin"
+	roleSuperuserKeyword      = "rolsuper"
+)
+
+func dataSourcePostgreSQLRoles() *schema.Resource {
+	return &schema.Resource{
+		Read: PGResourceFunc(dataSourcePostgreSQLRolesRead),
+		Schema: map[string]*schema.Schema{
+			"include_system_roles": {
+				Type:        schema.TypeBool,
+				Default:     false,
+				Optional:    true,
+				Description: "Determines whether to include the predefined roles (pg_ prefix)",
+			},
+			"login": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return the roles which can (true) or cannot (false) log in",
+			},
+			"superuser": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return the roles which are (true) or are not (false) superusers",
+			},
+			"member_of": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Only return the roles which are direct members of any of these roles",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"roles": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "The list of PostgreSQL roles retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLRolesRead(db *DBConnection, d *schema.ResourceData) error {
+	var query string
+	var queryConcatKeyword string
+	if d.Get("include_system_roles").(bool) {
+		query = roleQueries["query_include_system_roles"]
+		queryConcatKeyword = queryConcatKeywordWhere
+	} else {
+		query = roleQueries["query_exclude_system_roles"]
+		queryConcatKeyword = queryConcatKeywordAnd
+	}
+
+	query, args := applyRoleDataSourceQueryFilters(query, queryConcatKeyword, d)
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	roles := []string{}
+	for rows.Next() {
+		var role string
+
+		if err = rows.Scan(&role); err != nil {
+			return fmt.Errorf("could not scan role name: %w", err)
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("could not read roles: %w", err)
+	}
+
+	d.Set("roles", stringSliceToSet(roles))
+	d.SetId(generateDataSourceRolesID(d))
+
+	return nil
+}
+
+func generateDataSourceRolesID(d *schema.ResourceData) string {
+	return strings.Join([]string{
+		strconv.FormatBool(d.Get("include_system_roles").(bool)),
+		optionalBoolString(d, "login"),
+		optionalBoolString(d, "superuser"),
+		generatePatternArrayString(d.Get("member_of").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
+
+// optionalBoolString returns the value of an optional boolean filter, or an empty string if it's not set.
+func optionalBoolString(d *schema.ResourceData, key string) string {
+	if v, ok := d.GetOkExists(key); ok { //nolint:staticcheck
+		return strconv.FormatBool(v.(bool))
+	}
+	return ""
+}
+
+// applyRoleDataSourceQueryFilters returns the query with the filters of the data source and the arguments to bind.
+func applyRoleDataSourceQueryFilters(query string, queryConcatKeyword string, d *schema.ResourceData) (string, []interface{}) {
+	filters := []string{}
+	args := []interface{}{}
+	for _, f := range []struct{ keyword, key string }{
+		{roleLoginKeyword, "login"},
+		{roleSuperuserKeyword, "superuser"},
+	} {
+		if v := optionalBoolString(d, f.key); v != "" {
+			filters = append(filters, fmt.Sprintf("%s = %s", f.keyword, v))
+		}
+	}
+	if memberOf := d.Get("member_of").([]interface{}); len(memberOf) > 0 {
+		args = append(args, pq.Array(memberOf))
+		filters = append(filters, fmt.Sprintf(`EXISTS (
+		SELECT 1
+		FROM pg_catalog.pg_auth_members m
+		JOIN pg_catalog.pg_roles g ON g.oid = m.roleid
+		WHERE m.member = pg_roles.oid AND g.rolname = ANY($%d)
+	)`, len(args)))
+	}
+	filters = append(filters, applyPatternMatchingToQuery(rolePatternMatchingTarget, d)...)
+
+	return finalizeQueryWithFilters(query, queryConcatKeyword, filters), args
+}
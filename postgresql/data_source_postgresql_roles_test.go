@@ -0,0 +1,100 @@
+package postgresql
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestApplyRoleDataSourceQueryFilters(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourcePostgreSQLRoles().Schema, map[string]interface{}{
+		"login":             true,
+		"member_of":         []interface{}{"readonly"},
+		"like_any_patterns": []interface{}{"app_%"},
+	})
+
+	expected := `SELECT rolname FROM pg_catalog.pg_roles WHERE rolcanlogin = true AND EXISTS (
+		SELECT 1
+		FROM pg_catalog.pg_auth_members m
+		JOIN pg_catalog.pg_roles g ON g.oid = m.roleid
+		WHERE m.member = pg_roles.oid AND g.rolname = ANY($1)
+	) AND rolname LIKE ANY (array['app_%'])`
+
+	out, args := applyRoleDataSourceQueryFilters("SELECT rolname FROM pg_catalog.pg_roles", queryConcatKeywordWhere, d)
+	if out != expected {
+		t.Fatalf("Error matching output and expected: %#v vs %#v", out, expected)
+	}
+	if len(args) != 1 {
+		t.Fatalf("Expected member_of to be bound as the only argument, got %#v", args)
+	}
+	if memberOf, err := args[0].(driver.Valuer).Value(); err != nil || memberOf != `{"readonly"}` {
+		t.Fatalf("Error matching member_of argument and expected: %#v vs %#v (%v)", memberOf, `{"readonly"}`, err)
+	}
+}
+
+func TestAccPostgresqlDataSourceRoles(t *testing.T) {
+	skipIfNotAcc(t)
+
+	config := `
+resource "postgresql_role" "readonly" {
+  name = "ds_readonly"
+}
+
+resource "postgresql_role" "app_reader" {
+  name  = "ds_app_reader"
+  login = true
+  roles = [postgresql_role.readonly.name]
+}
+
+resource "postgresql_role" "app_writer" {
+  name  = "ds_app_writer"
+  login = true
+}
+
+data "postgresql_roles" "like_app" {
+  like_any_patterns = ["ds_app_%"]
+  depends_on        = [postgresql_role.app_reader, postgresql_role.app_writer]
+}
+
+data "postgresql_roles" "nologin" {
+  regex_pattern = "^ds_"
+  login         = false
+  depends_on    = [postgresql_role.app_reader, postgresql_role.app_writer]
+}
+
+data "postgresql_roles" "member_of" {
+  member_of  = [postgresql_role.readonly.name]
+  depends_on = [postgresql_role.app_reader]
+}
+
+data "postgresql_roles" "system" {
+  include_system_roles = true
+  like_any_patterns    = ["pg_read_all_data", "pg_monitor"]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_roles.like_app", "roles.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.postgresql_roles.like_app", "roles.*", "ds_app_reader"),
+					resource.TestCheckTypeSetElemAttr("data.postgresql_roles.like_app", "roles.*", "ds_app_writer"),
+					resource.TestCheckResourceAttr("data.postgresql_roles.nologin", "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.postgresql_roles.nologin", "roles.*", "ds_readonly"),
+					resource.TestCheckResourceAttr("data.postgresql_roles.member_of", "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.postgresql_roles.member_of", "roles.*", "ds_app_reader"),
+					resource.TestCheckTypeSetElemAttr("data.postgresql_roles.system", "roles.*", "pg_monitor"),
+				),
+			},
+		},
+	})
+}
@@ -9,8 +9,10 @@ func dataSourcePostgrePassword() *schema.Resource {
 		Read: dataSourcePostgrePasswordRead,
 		Schema: map[string]*schema.Schema{
 			"password": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The password used by the provider. It is stored in the state as plain-text",
 			},
 		},
 	}
@@ -0,0 +1,448 @@
+package postgresql
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Precedence of the SQL operators, from the loosest to the tightest binding,
+// as documented in https://www.postgresql.org/docs/current/sql-syntax-lexical.html#SQL-PRECEDENCE
+const (
+	exprPrecDelimiter = iota
+	exprPrecOr
+	exprPrecAnd
+	exprPrecNot
+	exprPrecIs
+	exprPrecComparison
+	exprPrecLike
+	exprPrecOther
+	exprPrecAdd
+	exprPrecMul
+	exprPrecExp
+	exprPrecUnary
+	exprPrecAtom    = 100
+	exprPrecNoGroup = 1000
+)
+
+const exprOperatorChars = "+-*/<>=~!@#%^&|`?"
+
+var (
+	exprKeywordPrecedence = map[string]int{
+		"or":      exprPrecOr,
+		"and":     exprPrecAnd,
+		"is":      exprPrecIs,
+		"isnull":  exprPrecIs,
+		"notnull": exprPrecIs,
+		"like":    exprPrecLike,
+		"ilike":   exprPrecLike,
+		"similar": exprPrecLike,
+		"between": exprPrecLike,
+		"in":      exprPrecLike,
+	}
+
+	exprOperatorPrecedence = map[string]int{
+		"<":  exprPrecComparison,
+		">":  exprPrecComparison,
+		"=":  exprPrecComparison,
+		"<=": exprPrecComparison,
+		">=": exprPrecComparison,
+		"<>": exprPrecComparison,
+		"!=": exprPrecComparison,
+		"+":  exprPrecAdd,
+		"-":  exprPrecAdd,
+		"*":  exprPrecMul,
+		"/":  exprPrecMul,
+		"%":  exprPrecMul,
+		"^":  exprPrecExp,
+	}
+
+	// Keywords delimiting complete expressions, like the start or the end of the expression.
+	exprDelimiterKeywords = map[string]bool{
+		"case": true,
+		"when": true,
+		"then": true,
+		"else": true,
+		"end":  true,
+	}
+
+	// Words which can follow the first word of a type name in a cast (e.g.: ::character varying).
+	exprTypeContinuations = map[string]bool{
+		"varying":   true,
+		"precision": true,
+		"with":      true,
+		"without":   true,
+		"time":      true,
+		"zone":      true,
+	}
+
+	exprSimpleIdentifierRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_$]*$`)
+)
+
+// exprToken is a lexical token of an SQL expression.
+type exprToken struct {
+	value string
+	kind  exprTokenKind
+}
+
+type exprTokenKind int
+
+const (
+	exprTokenIdentifier exprTokenKind = iota
+	exprTokenLiteral
+	exprTokenOperator
+	exprTokenPunctuation
+)
+
+// normalizeTableExpression normalizes an SQL expression (default values, check constraints,
+// policy or trigger conditions) in order to compare what is configured with what is returned by PostgreSQL
+// when it deparses the expression.
+// The expression is split in tokens so the quoted literals are kept as is, unquoted identifiers and
+// keywords are lower cased, explicit casts added by PostgreSQL are removed (e.g.: 'foo'::text or (0)::numeric)
+// and only the parentheses which do not change the meaning of the expression
+// according to the operators precedence are removed (e.g.: ((a > 0) AND (b > 0)) => a > 0 AND b > 0).
+func normalizeTableExpression(expression string) string {
+	tokens := removeExpressionCasts(tokenizeExpression(expression))
+	for {
+		var removed bool
+		if tokens, removed = removeRedundantExpressionParentheses(tokens); !removed {
+			break
+		}
+	}
+
+	values := make([]string, len(tokens))
+	for i, token := range tokens {
+		values[i] = token.value
+	}
+	return strings.Join(values, " ")
+}
+
+// tokenizeExpression splits an SQL expression in tokens, following the lexical rules of PostgreSQL.
+func tokenizeExpression(expression string) []exprToken {
+	var tokens []exprToken
+
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(expression[i:], "--"):
+			for i < len(expression) && expression[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(expression[i:], "/*"):
+			end := strings.Index(expression[i+2:], "*/")
+			if end < 0 {
+				i = len(expression)
+			} else {
+				i += end + 4
+			}
+		case c == '\'':
+			end := scanQuoted(expression, i, '\'', false)
+			tokens = append(tokens, exprToken{value: expression[i:end], kind: exprTokenLiteral})
+			i = end
+		case (c == 'e' || c == 'E') && i+1 < len(expression) && expression[i+1] == '\'':
+			end := scanQuoted(expression, i+1, '\'', true)
+			tokens = append(tokens, exprToken{value: "E" + expression[i+1:end], kind: exprTokenLiteral})
+			i = end
+		case c == '"':
+			end := scanQuoted(expression, i, '"', false)
+			tokens = append(tokens, exprToken{value: normalizeQuotedIdentifier(expression[i:end]), kind: exprTokenIdentifier})
+			i = end
+		case c == '$' && dollarQuoteTag(expression[i:]) != "":
+			tag := dollarQuoteTag(expression[i:])
+			end := strings.Index(expression[i+len(tag):], tag)
+			if end < 0 {
+				end = len(expression)
+			} else {
+				end += i + 2*len(tag)
+			}
+			tokens = append(tokens, exprToken{value: expression[i:end], kind: exprTokenLiteral})
+			i = end
+		case isIdentifierStart(c):
+			end := i + 1
+			for end < len(expression) && (isIdentifierStart(expression[end]) || isDigit(expression[end]) || expression[end] == '$') {
+				end++
+			}
+			tokens = append(tokens, exprToken{value: strings.ToLower(expression[i:end]), kind: exprTokenIdentifier})
+			i = end
+		case isDigit(c) || (c == '.' && i+1 < len(expression) && isDigit(expression[i+1])):
+			end := i + 1
+			for end < len(expression) && (isDigit(expression[end]) || expression[end] == '.' ||
+				expression[end] == 'e' || expression[end] == 'E' ||
+				((expression[end] == '+' || expression[end] == '-') && (expression[end-1] == 'e' || expression[end-1] == 'E'))) {
+				end++
+			}
+			tokens = append(tokens, exprToken{value: strings.ToLower(expression[i:end]), kind: exprTokenLiteral})
+			i = end
+		case c == ':' && i+1 < len(expression) && expression[i+1] == ':':
+			tokens = append(tokens, exprToken{value: "::", kind: exprTokenPunctuation})
+			i += 2
+		case strings.IndexByte(exprOperatorChars, c) >= 0:
+			end := i + 1
+			for end < len(expression) && strings.IndexByte(exprOperatorChars, expression[end]) >= 0 &&
+				!strings.HasPrefix(expression[end:], "--") && !strings.HasPrefix(expression[end:], "/*") {
+				end++
+			}
+			// As in PostgreSQL, a multi-characters operator cannot end with + or -
+			// unless it contains one of the characters ~ ! @ # % ^ & | ` ?
+			operator := expression[i:end]
+			for len(operator) > 1 && strings.ContainsAny(operator[len(operator)-1:], "+-") && !strings.ContainsAny(operator, "~!@#%^&|`?") {
+				operator = operator[:len(operator)-1]
+			}
+			tokens = append(tokens, exprToken{value: operator, kind: exprTokenOperator})
+			i += len(operator)
+		default:
+			tokens = append(tokens, exprToken{value: string(c), kind: exprTokenPunctuation})
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// scanQuoted returns the position following the quoted string starting at start.
+// The quote character is escaped by doubling it or, in escape strings, with a backslash.
+func scanQuoted(expression string, start int, quote byte, backslashEscapes bool) int {
+	for i := start + 1; i < len(expression); i++ {
+		switch {
+		case backslashEscapes && expression[i] == '\\':
+			i++
+		case expression[i] == quote:
+			if i+1 < len(expression) && expression[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(expression)
+}
+
+// dollarQuoteTag returns the opening tag (e.g.: $$ or $body$) if the expression starts with a dollar-quoted string.
+func dollarQuoteTag(expression string) string {
+	for i := 1; i < len(expression); i++ {
+		switch {
+		case expression[i] == '$':
+			return expression[:i+1]
+		case !isIdentifierStart(expression[i]) && !(i > 1 && isDigit(expression[i])):
+			return ""
+		}
+	}
+	return ""
+}
+
+// normalizeQuotedIdentifier removes the quotes of an identifier when they are not needed,
+// as PostgreSQL only quotes identifiers which would be changed by the case folding.
+func normalizeQuotedIdentifier(identifier string) string {
+	unquoted := strings.ReplaceAll(strings.Trim(identifier, `"`), `""`, `"`)
+	if exprSimpleIdentifierRegexp.MatchString(unquoted) {
+		return unquoted
+	}
+	return identifier
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// removeExpressionCasts removes the explicit casts (::type) from the tokens,
+// including the type modifiers and array dimensions (e.g.: ::character varying(255)[]).
+func removeExpressionCasts(tokens []exprToken) []exprToken {
+	result := make([]exprToken, 0, len(tokens))
+
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].value != "::" {
+			result = append(result, tokens[i])
+			continue
+		}
+
+		i++
+		// Type name, possibly schema-qualified or made of several words.
+		for i < len(tokens) && tokens[i].kind == exprTokenIdentifier {
+			if i+2 < len(tokens) && tokens[i+1].value == "." && tokens[i+2].kind == exprTokenIdentifier {
+				i += 2
+				continue
+			}
+			if i+1 < len(tokens) && exprTypeContinuations[tokens[i+1].value] {
+				i++
+				continue
+			}
+			break
+		}
+		// Type modifiers
+		if i+1 < len(tokens) && tokens[i+1].value == "(" {
+			i = matchingExpressionParenthesis(tokens, i+1, "(", ")")
+		}
+		// Array dimensions
+		for i+1 < len(tokens) && tokens[i+1].value == "[" {
+			i = matchingExpressionParenthesis(tokens, i+1, "[", "]")
+		}
+	}
+
+	return result
+}
+
+// matchingExpressionParenthesis returns the index of the token closing the one at index start.
+func matchingExpressionParenthesis(tokens []exprToken, start int, open, closing string) int {
+	depth := 0
+	for i := start; i < len(tokens); i++ {
+		switch tokens[i].value {
+		case open:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+// removeRedundantExpressionParentheses removes the first pair of grouping parentheses
+// which can be removed without changing how the expression is parsed, i.e. if the operators
+// inside the parentheses bind tighter than the operators around them.
+func removeRedundantExpressionParentheses(tokens []exprToken) ([]exprToken, bool) {
+	for start, token := range tokens {
+		if token.value != "(" {
+			continue
+		}
+
+		leftPrec, grouping := expressionLeftPrecedence(tokens, start)
+		if !grouping {
+			continue
+		}
+
+		end := matchingExpressionParenthesis(tokens, start, "(", ")")
+		if tokens[end].value != ")" {
+			continue
+		}
+		rightPrec := expressionRightPrecedence(tokens, end)
+		innerPrec := expressionPrecedence(tokens[start+1 : end])
+
+		// Operators are left-associative: a - (b - c) is not the same as a - b - c.
+		if innerPrec > leftPrec && innerPrec >= rightPrec {
+			result := make([]exprToken, 0, len(tokens)-2)
+			result = append(result, tokens[:start]...)
+			result = append(result, tokens[start+1:end]...)
+			result = append(result, tokens[end+1:]...)
+			return result, true
+		}
+	}
+
+	return tokens, false
+}
+
+// isExpressionOperand returns true if the token at index i is in operand position,
+// i.e. it starts an expression or follows an operator.
+func isExpressionOperand(tokens []exprToken, i int) bool {
+	if i == 0 {
+		return true
+	}
+	previous := tokens[i-1]
+	switch {
+	case previous.value == "(" || previous.value == "," || previous.value == "[":
+		return true
+	case previous.kind == exprTokenOperator:
+		return true
+	case previous.kind == exprTokenIdentifier:
+		_, keyword := exprKeywordPrecedence[previous.value]
+		return keyword || previous.value == "not" || exprDelimiterKeywords[previous.value]
+	}
+	return false
+}
+
+// tokenPrecedence returns the precedence of the token at index i if it's an operator.
+func tokenPrecedence(tokens []exprToken, i int) (int, bool) {
+	token := tokens[i]
+	switch token.kind {
+	case exprTokenOperator:
+		if isExpressionOperand(tokens, i) {
+			return exprPrecUnary, true
+		}
+		if prec, ok := exprOperatorPrecedence[token.value]; ok {
+			return prec, true
+		}
+		return exprPrecOther, true
+	case exprTokenIdentifier:
+		if token.value == "not" {
+			if i > 0 && tokens[i-1].value == "is" {
+				return exprPrecIs, true
+			}
+			if isExpressionOperand(tokens, i) {
+				return exprPrecNot, true
+			}
+			// NOT LIKE, NOT IN, NOT BETWEEN...
+			return exprPrecLike, true
+		}
+		if prec, ok := exprKeywordPrecedence[token.value]; ok {
+			return prec, true
+		}
+	}
+	return 0, false
+}
+
+// expressionLeftPrecedence returns the precedence of the operator preceding the parenthesis
+// at index start and false if the parenthesis is not a grouping one (e.g.: function call or IN list).
+func expressionLeftPrecedence(tokens []exprToken, start int) (int, bool) {
+	if start == 0 {
+		return exprPrecDelimiter, true
+	}
+	previous := tokens[start-1]
+	switch {
+	case previous.value == "(" || previous.value == "," || previous.value == "[":
+		return exprPrecDelimiter, true
+	case exprDelimiterKeywords[previous.value]:
+		return exprPrecDelimiter, true
+	case previous.value == "in":
+		return 0, false
+	}
+	return tokenPrecedence(tokens, start-1)
+}
+
+// expressionRightPrecedence returns the precedence of the operator following the parenthesis at index end.
+func expressionRightPrecedence(tokens []exprToken, end int) int {
+	if end+1 >= len(tokens) {
+		return exprPrecDelimiter
+	}
+	next := tokens[end+1]
+	switch {
+	case next.value == ")" || next.value == "," || next.value == "]":
+		return exprPrecDelimiter
+	case exprDelimiterKeywords[next.value]:
+		return exprPrecDelimiter
+	}
+	if prec, ok := tokenPrecedence(tokens, end+1); ok {
+		return prec
+	}
+	// e.g.: field selection or subscript, (a).b or (a)[1]
+	return exprPrecNoGroup
+}
+
+// expressionPrecedence returns the precedence of the loosest binding operator of an expression,
+// ignoring what is nested in parentheses, brackets and CASE expressions.
+func expressionPrecedence(tokens []exprToken) int {
+	prec := exprPrecAtom
+	depth := 0
+	for i, token := range tokens {
+		switch token.value {
+		case "(", "[", "case":
+			depth++
+			continue
+		case ")", "]", "end":
+			depth--
+			continue
+		}
+		if depth > 0 {
+			continue
+		}
+		if p, ok := tokenPrecedence(tokens, i); ok && p < prec {
+			prec = p
+		}
+	}
+	return prec
+}
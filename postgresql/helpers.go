@@ -56,6 +56,61 @@ func PGResourceContextFunc(fn func(context.Context, *DBConnection, *schema.Resou
 	}
 }
 
+// PGResourceDiffFunc wraps a CustomizeDiff function which needs a connection to the database,
+// e.g.: to validate the plan against the catalog.
+func PGResourceDiffFunc(fn func(context.Context, *DBConnection, *schema.ResourceDiff) error) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		client := meta.(*Client)
+
+		db, err := client.Connect()
+		if err != nil {
+			return err
+		}
+
+		return fn(ctx, db, d)
+	}
+}
+
+// PGResourceImportFunc wraps an import function parsing the ID of a resource
+// which needs a connection to check the imported object or read some of its attributes.
+func PGResourceImportFunc(fn func(*DBConnection, *schema.ResourceData) error) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		client := meta.(*Client)
+
+		db, err := client.Connect()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := fn(db, d); err != nil {
+			return nil, err
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// splitImportID splits the ID given to terraform import on "/" and checks its number of parts.
+// Optional trailing parts are returned as empty strings.
+func splitImportID(id string, minParts, maxParts int, format string) ([]string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) < minParts || len(parts) > maxParts {
+		return nil, fmt.Errorf("invalid import ID %q, expected format: %s", id, format)
+	}
+	for len(parts) < maxParts {
+		parts = append(parts, "")
+	}
+	return parts, nil
+}
+
+// splitImportList splits a comma separated list of an import ID.
+func splitImportList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
 // QueryAble is a DB connection (sql.DB/Tx)
 type QueryAble interface {
 	Exec(query string, args ...interface{}) (sql.Result, error)
@@ -145,23 +200,60 @@ func revokeRoleMembership(db QueryAble, role, member string) (bool, error) {
 // (i.e.: the admin configure in the provider) and revoke them as soon as the
 // callback func has finished.
 func withRolesGranted(txn *sql.Tx, roles []string, fn func() error) error {
-	// No roles asked, execute the function directly
-	if len(roles) == 0 {
-		return fn()
+	restore, err := grantRolesTemporarily(txn, roles)
+	if err != nil {
+		return err
 	}
 
-	currentUser, err := getCurrentUser(txn)
-	if err != nil {
+	// Execute the wrapped function
+	if err := fn(); err != nil {
 		return err
 	}
 
-	superuser, err := isSuperuser(txn, currentUser)
+	return restore()
+}
+
+// withRolesGrantedOutsideTransaction is like withRolesGranted for statements which cannot be
+// executed inside a transaction block (e.g.: ALTER TYPE ... ADD VALUE before Postgres 12).
+// Each grant is committed on its own, so the roles are revoked even if the callback func fails.
+func withRolesGrantedOutsideTransaction(db QueryAble, roles []string, fn func() error) error {
+	restore, err := grantRolesTemporarily(db, roles)
 	if err != nil {
 		return err
 	}
+
+	if err := fn(); err != nil {
+		if restoreErr := restore(); restoreErr != nil {
+			log.Printf("[WARN] could not revoke the roles temporarily granted: %v", restoreErr)
+		}
+		return err
+	}
+
+	return restore()
+}
+
+// grantRolesTemporarily grants, if needed, the roles specified to connected user
+// and returns the func revoking them.
+func grantRolesTemporarily(db QueryAble, roles []string) (func() error, error) {
+	noop := func() error { return nil }
+
+	// No roles asked, nothing to grant
+	if len(roles) == 0 {
+		return noop, nil
+	}
+
+	currentUser, err := getCurrentUser(db)
+	if err != nil {
+		return nil, err
+	}
+
+	superuser, err := isSuperuser(db, currentUser)
+	if err != nil {
+		return nil, err
+	}
 	if superuser {
 		log.Printf("withRolesGranted: current user %s is superuser, no need to grant roles", currentUser)
-		return fn()
+		return noop, nil
 	}
 
 	var grantedRoles []string
@@ -170,9 +262,9 @@ func withRolesGranted(txn *sql.Tx, roles []string, fn func() error) error {
 	for _, role := range roles {
 		// We need to check if the role we want to grant is a superuser
 		// in this case Postgres disallows to grant it to a current user which is not superuser.
-		superuser, err := isSuperuser(txn, role)
+		superuser, err := isSuperuser(db, role)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if superuser {
 			log.Printf("withRolesGranted: WARN role %s could not be granted to current user (%s) as it's a superuser", role, currentUser)
@@ -195,52 +287,49 @@ func withRolesGranted(txn *sql.Tx, roles []string, fn func() error) error {
 		//  - GRANT postgres TO foo
 
 		// Check the opposite relation and revoke currentUser from role if needed
-		revoked, err := revokeRoleMembership(txn, currentUser, role)
+		revoked, err := revokeRoleMembership(db, currentUser, role)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if revoked {
 			revokedRoles = append(revokedRoles, role)
 		}
 
 		// Grant the role to currentUser if needed
-		roleGranted, err := grantRoleMembership(txn, role, currentUser)
+		roleGranted, err := grantRoleMembership(db, role, currentUser)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if roleGranted {
 			grantedRoles = append(grantedRoles, role)
 		}
 	}
 
-	// Execute the wrapped function
-	if err := fn(); err != nil {
-		return err
-	}
-
-	// Revoke the temporary granted roles.
-	for _, role := range grantedRoles {
-		if _, err := revokeRoleMembership(txn, role, currentUser); err != nil {
-			return err
+	return func() error {
+		// Revoke the temporary granted roles.
+		for _, role := range grantedRoles {
+			if _, err := revokeRoleMembership(db, role, currentUser); err != nil {
+				return err
+			}
 		}
-	}
 
-	// Grant back the temporary revoked role.
-	for _, role := range revokedRoles {
-		// check if the role has not been deleted by the wrapped function
-		exists, err := roleExists(txn, role)
-		if err != nil {
-			return err
-		}
-		if !exists {
-			continue
-		}
-		if _, err := grantRoleMembership(txn, currentUser, role); err != nil {
-			return err
+		// Grant back the temporary revoked role.
+		for _, role := range revokedRoles {
+			// check if the role has not been deleted by the wrapped function
+			exists, err := roleExists(db, role)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				continue
+			}
+			if _, err := grantRoleMembership(db, currentUser, role); err != nil {
+				return err
+			}
 		}
-	}
 
-	return nil
+		return nil
+	}, nil
 }
 
 func sliceContainsStr(haystack []string, needle string) bool {
@@ -263,6 +352,11 @@ var allowedPrivileges = map[string][]string{
 	"procedure":            {"ALL", "EXECUTE"},
 	"routine":              {"ALL", "EXECUTE"},
 	"type":                 {"ALL", "USAGE"},
+	"domain":               {"ALL", "USAGE"},
+	"language":             {"ALL", "USAGE"},
+	"tablespace":           {"ALL", "CREATE"},
+	"large_object":         {"ALL", "SELECT", "UPDATE"},
+	"parameter":            {"ALL", "SET", "ALTER SYSTEM"},
 	"foreign_data_wrapper": {"ALL", "USAGE"},
 	"foreign_server":       {"ALL", "USAGE"},
 	"column":               {"ALL", "SELECT", "INSERT", "UPDATE", "REFERENCES"},
@@ -349,6 +443,78 @@ func setToPgIdentSimpleList(idents *schema.Set) string {
 	return strings.Join(quotedIdents, ",")
 }
 
+// setToPgParameterList quotes the parts of each (possibly qualified) parameter name, e.g.: "pg_stat_statements"."track".
+func setToPgParameterList(idents *schema.Set) string {
+	quotedIdents := make([]string, idents.Len())
+	for i, ident := range idents.List() {
+		parts := strings.Split(ident.(string), ".")
+		for j := range parts {
+			parts[j] = pq.QuoteIdentifier(parts[j])
+		}
+		quotedIdents[i] = strings.Join(parts, ".")
+	}
+	return strings.Join(quotedIdents, ",")
+}
+
+// quoteIdentifierList quotes each identifier and joins them with commas.
+func quoteIdentifierList(idents []string) string {
+	quotedIdents := make([]string, len(idents))
+	for i, ident := range idents {
+		quotedIdents[i] = pq.QuoteIdentifier(ident)
+	}
+	return strings.Join(quotedIdents, ", ")
+}
+
+func interfaceSliceToStrings(slice []interface{}) []string {
+	s := make([]string, 0, len(slice))
+	for _, v := range slice {
+		if v == nil {
+			continue
+		}
+		s = append(s, v.(string))
+	}
+	return s
+}
+
+// isAttrConfigured returns true if the attribute is set in the configuration,
+// even to its zero value (unlike GetOk).
+func isAttrConfigured(d *schema.ResourceData, attr string) bool {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		_, ok := d.GetOk(attr)
+		return ok
+	}
+	return !rawConfig.GetAttr(attr).IsNull()
+}
+
+// sortByName reorders a list of blocks read from the database (maps with a "name" key)
+// to follow the order of the blocks in the configuration.
+// Blocks unknown in the configuration are appended at the end in their original order.
+func sortByName(items []interface{}, configured []interface{}) []interface{} {
+	byName := make(map[string]interface{}, len(items))
+	for _, item := range items {
+		byName[item.(map[string]interface{})["name"].(string)] = item
+	}
+
+	sorted := make([]interface{}, 0, len(items))
+	for _, c := range configured {
+		if c == nil {
+			continue
+		}
+		name := c.(map[string]interface{})["name"].(string)
+		if item, ok := byName[name]; ok {
+			sorted = append(sorted, item)
+			delete(byName, name)
+		}
+	}
+	for _, item := range items {
+		if _, ok := byName[item.(map[string]interface{})["name"].(string)]; ok {
+			sorted = append(sorted, item)
+		}
+	}
+	return sorted
+}
+
 // startTransaction starts a new DB transaction on the specified database.
 // If the database is specified and different from the one configured in the provider,
 // it will create a new connection pool if needed.
@@ -381,8 +547,31 @@ func dbExists(db QueryAble, dbname string) (bool, error) {
 	return true, nil
 }
 
-func roleExists(txn *sql.Tx, rolname string) (bool, error) {
-	err := txn.QueryRow("SELECT 1 FROM pg_roles WHERE rolname=$1", rolname).Scan(&rolname)
+// getConnectableDatabases returns the databases which accept connections.
+func getConnectableDatabases(db QueryAble) ([]string, error) {
+	rows, err := db.Query("SELECT datname FROM pg_catalog.pg_database WHERE datallowconn ORDER BY datname")
+	if err != nil {
+		return nil, fmt.Errorf("could not list databases: %w", err)
+	}
+	defer rows.Close()
+
+	var databases []string
+	for rows.Next() {
+		var database string
+		if err := rows.Scan(&database); err != nil {
+			return nil, fmt.Errorf("could not scan database name: %w", err)
+		}
+		databases = append(databases, database)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not list databases: %w", err)
+	}
+
+	return databases, nil
+}
+
+func roleExists(db QueryAble, rolname string) (bool, error) {
+	err := db.QueryRow("SELECT 1 FROM pg_roles WHERE rolname=$1", rolname).Scan(&rolname)
 	switch {
 	case err == sql.ErrNoRows:
 		return false, nil
@@ -507,6 +696,31 @@ func getTablesOwner(db QueryAble, schemaName string) ([]string, error) {
 	return owners, nil
 }
 
+func getTypesOwner(db QueryAble, schemaName string) ([]string, error) {
+	rows, err := db.Query(
+		`SELECT DISTINCT pg_catalog.pg_get_userbyid(t.typowner)
+		FROM pg_catalog.pg_type t
+		JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+		WHERE n.nspname = $1`,
+		schemaName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for owners of types in schema '%s': %w", schemaName, err)
+	}
+	defer rows.Close()
+
+	var owners []string
+	for rows.Next() {
+		var owner string
+		if err := rows.Scan(&owner); err != nil {
+			return nil, fmt.Errorf("could not scan types owner: %w", err)
+		}
+		owners = append(owners, owner)
+	}
+
+	return owners, nil
+}
+
 func resolveOwners(db QueryAble, owners []string) ([]string, error) {
 	resolvedOwners := []string{}
 	for _, owner := range owners {
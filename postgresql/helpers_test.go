@@ -17,3 +17,22 @@ func TestFindStringSubmatchMap(t *testing.T) {
 		},
 	)
 }
+
+func TestSplitImportID(t *testing.T) {
+	parts, err := splitImportID("a/b", 2, 3, "x/y[/z]")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", ""}, parts)
+
+	parts, err = splitImportID("a/b/c", 2, 3, "x/y[/z]")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, parts)
+
+	_, err = splitImportID("a", 2, 3, "x/y[/z]")
+	assert.Error(t, err)
+
+	_, err = splitImportID("a/b/c/d", 2, 3, "x/y[/z]")
+	assert.Error(t, err)
+
+	assert.Nil(t, splitImportList(""))
+	assert.Equal(t, []string{"a", "b"}, splitImportList("a,b"))
+}
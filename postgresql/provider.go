@@ -209,6 +209,26 @@ func Provider() *schema.Provider {
 			"postgresql_user_mapping":              resourcePostgreSQLUserMapping(),
 			"postgresql_alter_role":                resourcePostgreSQLAlterRole(),
 			"postgresql_script":                    resourcePostgreSQLScript(),
+			"postgresql_table":                     resourcePostgreSQLTable(),
+			"postgresql_view":                      resourcePostgreSQLView(),
+			"postgresql_materialized_view":         resourcePostgreSQLMaterializedView(),
+			"postgresql_policy":                    resourcePostgreSQLPolicy(),
+			"postgresql_sequence":                  resourcePostgreSQLSequence(),
+			"postgresql_enum_type":                 resourcePostgreSQLEnumType(),
+			"postgresql_composite_type":            resourcePostgreSQLCompositeType(),
+			"postgresql_domain":                    resourcePostgreSQLDomain(),
+			"postgresql_range_type":                resourcePostgreSQLRangeType(),
+			"postgresql_trigger":                   resourcePostgreSQLTrigger(),
+			"postgresql_event_trigger":             resourcePostgreSQLEventTrigger(),
+			"postgresql_tablespace":                resourcePostgreSQLTablespace(),
+			"postgresql_foreign_data_wrapper":      resourcePostgreSQLForeignDataWrapper(),
+			"postgresql_foreign_table":             resourcePostgreSQLForeignTable(),
+			"postgresql_import_foreign_schema":     resourcePostgreSQLImportForeignSchema(),
+			"postgresql_database_parameters":       resourcePostgreSQLDatabaseParameters(),
+			"postgresql_system_parameter":          resourcePostgreSQLSystemParameter(),
+			"postgresql_role_members":              resourcePostgreSQLRoleMembers(),
+			"postgresql_object_acl":                resourcePostgreSQLObjectACL(),
+			"postgresql_public_hardening":          resourcePostgreSQLPublicHardening(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -216,6 +236,8 @@ func Provider() *schema.Provider {
 			"postgresql_schemas":   dataSourcePostgreSQLDatabaseSchemas(),
 			"postgresql_tables":    dataSourcePostgreSQLDatabaseTables(),
 			"postgresql_sequences": dataSourcePostgreSQLDatabaseSequences(),
+			"postgresql_roles":     dataSourcePostgreSQLRoles(),
+			"postgresql_role":      dataSourcePostgreSQLRole(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -27,6 +27,9 @@ func resourcePostgreSQLAlterRole() *schema.Resource {
 		Create: PGResourceFunc(resourcePostgreSQLAlterRoleCreate),
 		Read:   PGResourceFunc(resourcePostgreSQLAlterRoleRead),
 		Delete: PGResourceFunc(resourcePostgreSQLAlterRoleDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: PGResourceImportFunc(resourcePostgreSQLAlterRoleImport),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"role_name": {
@@ -119,6 +122,51 @@ func resourcePostgreSQLAlterRoleDelete(db *DBConnection, d *schema.ResourceData)
 	return nil
 }
 
+const alterRoleImportIDFormat = "role_name/parameter_key"
+
+// resourcePostgreSQLAlterRoleImport parses an import ID of the form role_name/parameter_key
+// and reads the value of the parameter set on the role.
+func resourcePostgreSQLAlterRoleImport(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePrivileges) {
+		return fmt.Errorf(
+			"postgresql_alter_role resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	if err := setAlterRoleFromImportID(d); err != nil {
+		return err
+	}
+
+	importID := d.Id()
+	if err := readAlterRole(db, d); err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return fmt.Errorf("role %q not found", d.Get("role_name").(string))
+	}
+	if d.Get("parameter_value").(string) == "" {
+		return fmt.Errorf("parameter not set on role for import ID %q", importID)
+	}
+
+	return nil
+}
+
+func setAlterRoleFromImportID(d *schema.ResourceData) error {
+	parts, err := splitImportID(d.Id(), 2, 2, alterRoleImportIDFormat)
+	if err != nil {
+		return err
+	}
+	if parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid import ID %q, expected format: %s", d.Id(), alterRoleImportIDFormat)
+	}
+
+	d.Set("role_name", parts[0])
+	d.Set("parameter_key", parts[1])
+
+	return nil
+}
+
 func readAlterRole(db QueryAble, d *schema.ResourceData) error {
 	var (
 		roleName       string
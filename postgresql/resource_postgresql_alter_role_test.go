@@ -108,6 +108,12 @@ func TestAccPostgresqlAlterRole(t *testing.T) {
 					checkAlterRole(t, dsn, roleName, parameterKey, parameterValue),
 				),
 			},
+			{
+				ResourceName:      "postgresql_alter_role.alter_role",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%s", roleName, parameterKey),
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -0,0 +1,317 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	compositeAttributeAttr          = "attribute"
+	compositeAttributeNameAttr      = "name"
+	compositeAttributeTypeAttr      = "type"
+	compositeAttributeCollationAttr = "collation"
+)
+
+// pgCompositeAttribute is the model of an attribute of a composite type.
+type pgCompositeAttribute struct {
+	Name      string
+	Type      string
+	Collation string
+}
+
+func resourcePostgreSQLCompositeType() *schema.Resource {
+	s := typeCommonSchema("composite type")
+	s[compositeAttributeAttr] = &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		Description: "The attributes of the composite type",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				compositeAttributeNameAttr: {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "The name of the attribute",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				compositeAttributeTypeAttr: {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The data type of the attribute",
+
+					DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+						return normalizeColumnType(old) == normalizeColumnType(new)
+					},
+				},
+				compositeAttributeCollationAttr: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The collation of the attribute. If not specified, the default collation of the data type is used",
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLCompositeTypeCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLCompositeTypeRead),
+		Update: PGResourceFunc(resourcePostgreSQLCompositeTypeUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLCompositeTypeDelete),
+		Exists: PGResourceExistsFunc(resourcePostgreSQLCompositeTypeExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: s,
+	}
+}
+
+func resourcePostgreSQLCompositeTypeCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getTypeSchema(d)
+	typeName := d.Get(typeNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	owner := d.Get(typeOwnerAttr).(string)
+	var rolesToGrant []string
+	if owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		attributes := compositeAttributesFromResourceData(d.Get(compositeAttributeAttr).([]interface{}))
+		if _, err := txn.Exec(createCompositeTypeQuery(pgSchema, typeName, attributes)); err != nil {
+			return fmt.Errorf("could not create composite type %s: %w", typeName, err)
+		}
+
+		return setTypeOwner(txn, "TYPE", pgSchema, typeName, owner)
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateTypeID(database, pgSchema, typeName))
+
+	return resourcePostgreSQLCompositeTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLCompositeTypeExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	return typeExists(db, d, typTypeComposite)
+}
+
+func resourcePostgreSQLCompositeTypeRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLCompositeTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLCompositeTypeReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, pgSchema, typeName, err := getDBTypeName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var typeOID uint32
+	var typeOwner string
+	err = txn.QueryRow(typeQuery, pgSchema, typeName, typTypeComposite).Scan(&typeOID, &typeOwner)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL composite type (%s) not found in database %s", typeName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading composite type: %w", err)
+	}
+
+	attributes, err := readCompositeAttributes(txn, typeOID)
+	if err != nil {
+		return err
+	}
+
+	attributesList := make([]interface{}, 0, len(attributes))
+	for _, a := range attributes {
+		attributesList = append(attributesList, map[string]interface{}{
+			compositeAttributeNameAttr:      a.Name,
+			compositeAttributeTypeAttr:      a.Type,
+			compositeAttributeCollationAttr: a.Collation,
+		})
+	}
+
+	d.Set(typeNameAttr, typeName)
+	d.Set(typeSchemaAttr, pgSchema)
+	d.Set(typeDatabaseAttr, database)
+	d.Set(typeOwnerAttr, typeOwner)
+	d.Set(compositeAttributeAttr, sortByName(attributesList, d.Get(compositeAttributeAttr).([]interface{})))
+	d.SetId(generateTypeID(database, pgSchema, typeName))
+
+	return nil
+}
+
+func readCompositeAttributes(txn *sql.Tx, typeOID uint32) ([]pgCompositeAttribute, error) {
+	// The collation is only returned if it's not the default one of the attribute data type.
+	rows, err := txn.Query(`
+SELECT a.attname, pg_catalog.format_type(a.atttypid, a.atttypmod),
+       COALESCE((
+           SELECT coll.collname FROM pg_catalog.pg_collation coll
+            WHERE coll.oid = a.attcollation AND a.attcollation <> at.typcollation
+       ), '')
+  FROM pg_catalog.pg_type t
+  JOIN pg_catalog.pg_attribute a ON a.attrelid = t.typrelid
+  JOIN pg_catalog.pg_type at ON at.oid = a.atttypid
+ WHERE t.oid = $1 AND a.attnum > 0 AND NOT a.attisdropped
+ ORDER BY a.attnum
+`, typeOID)
+	if err != nil {
+		return nil, fmt.Errorf("could not read attributes of composite type: %w", err)
+	}
+	defer rows.Close()
+
+	attributes := []pgCompositeAttribute{}
+	for rows.Next() {
+		var attribute pgCompositeAttribute
+		if err := rows.Scan(&attribute.Name, &attribute.Type, &attribute.Collation); err != nil {
+			return nil, fmt.Errorf("could not scan attribute of composite type: %w", err)
+		}
+		attributes = append(attributes, attribute)
+	}
+
+	return attributes, rows.Err()
+}
+
+func resourcePostgreSQLCompositeTypeUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getTypeSchema(d)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := withRolesGranted(txn, typeOwnerRoles(d), func() error {
+		if err := setTypeName(txn, d, "TYPE", pgSchema); err != nil {
+			return err
+		}
+
+		typeName := d.Get(typeNameAttr).(string)
+
+		if d.HasChange(compositeAttributeAttr) {
+			o, n := d.GetChange(compositeAttributeAttr)
+			for _, query := range alterCompositeTypeQueries(
+				pgSchema, typeName,
+				compositeAttributesFromResourceData(o.([]interface{})),
+				compositeAttributesFromResourceData(n.([]interface{})),
+			) {
+				if _, err := txn.Exec(query); err != nil {
+					return fmt.Errorf("could not alter composite type %s: %w", typeName, err)
+				}
+			}
+		}
+
+		if d.HasChange(typeOwnerAttr) {
+			return setTypeOwner(txn, "TYPE", pgSchema, typeName, d.Get(typeOwnerAttr).(string))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourcePostgreSQLCompositeTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLCompositeTypeDelete(db *DBConnection, d *schema.ResourceData) error {
+	return dropType(db, d, "TYPE")
+}
+
+func compositeAttributeDefinition(a pgCompositeAttribute) string {
+	definition := fmt.Sprintf("%s %s", pq.QuoteIdentifier(a.Name), a.Type)
+	if a.Collation != "" {
+		definition += " COLLATE " + pq.QuoteIdentifier(a.Collation)
+	}
+	return definition
+}
+
+func createCompositeTypeQuery(pgSchema, typeName string, attributes []pgCompositeAttribute) string {
+	definitions := make([]string, 0, len(attributes))
+	for _, a := range attributes {
+		definitions = append(definitions, compositeAttributeDefinition(a))
+	}
+
+	return fmt.Sprintf("CREATE TYPE %s AS (%s)", tableIdentifier(pgSchema, typeName), strings.Join(definitions, ", "))
+}
+
+// alterCompositeTypeQueries returns the ALTER TYPE statements needed to go from the old
+// attributes to the new ones. Attributes are matched by name.
+func alterCompositeTypeQueries(pgSchema, typeName string, oldAttributes, newAttributes []pgCompositeAttribute) []string {
+	typeIdent := tableIdentifier(pgSchema, typeName)
+	queries := []string{}
+
+	oldByName := make(map[string]pgCompositeAttribute, len(oldAttributes))
+	for _, a := range oldAttributes {
+		oldByName[a.Name] = a
+	}
+	newByName := make(map[string]pgCompositeAttribute, len(newAttributes))
+	for _, a := range newAttributes {
+		newByName[a.Name] = a
+	}
+
+	for _, a := range oldAttributes {
+		if _, ok := newByName[a.Name]; !ok {
+			queries = append(queries, fmt.Sprintf("ALTER TYPE %s DROP ATTRIBUTE %s", typeIdent, pq.QuoteIdentifier(a.Name)))
+		}
+	}
+
+	for _, n := range newAttributes {
+		o, ok := oldByName[n.Name]
+		if !ok {
+			queries = append(queries, fmt.Sprintf("ALTER TYPE %s ADD ATTRIBUTE %s", typeIdent, compositeAttributeDefinition(n)))
+			continue
+		}
+
+		if normalizeColumnType(o.Type) != normalizeColumnType(n.Type) || o.Collation != n.Collation {
+			query := fmt.Sprintf("ALTER TYPE %s ALTER ATTRIBUTE %s TYPE %s", typeIdent, pq.QuoteIdentifier(n.Name), n.Type)
+			if n.Collation != "" {
+				query += " COLLATE " + pq.QuoteIdentifier(n.Collation)
+			}
+			queries = append(queries, query)
+		}
+	}
+
+	return queries
+}
+
+func compositeAttributesFromResourceData(list []interface{}) []pgCompositeAttribute {
+	attributes := []pgCompositeAttribute{}
+
+	for _, raw := range list {
+		m := raw.(map[string]interface{})
+		attributes = append(attributes, pgCompositeAttribute{
+			Name:      m[compositeAttributeNameAttr].(string),
+			Type:      m[compositeAttributeTypeAttr].(string),
+			Collation: m[compositeAttributeCollationAttr].(string),
+		})
+	}
+
+	return attributes
+}
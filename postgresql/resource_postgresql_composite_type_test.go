@@ -0,0 +1,101 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCompositeTypeQuery(t *testing.T) {
+	assert.Equal(t,
+		`CREATE TYPE "public"."address" AS ("street" text COLLATE "C", "zip" varchar(10))`,
+		createCompositeTypeQuery("public", "address", []pgCompositeAttribute{
+			{Name: "street", Type: "text", Collation: "C"},
+			{Name: "zip", Type: "varchar(10)"},
+		}),
+	)
+}
+
+func TestAlterCompositeTypeQueries(t *testing.T) {
+	queries := alterCompositeTypeQueries("public", "address",
+		[]pgCompositeAttribute{
+			{Name: "street", Type: "text"},
+			{Name: "zip", Type: "character varying(10)"},
+			{Name: "country", Type: "text"},
+		},
+		[]pgCompositeAttribute{
+			{Name: "street", Type: "text", Collation: "C"},
+			{Name: "zip", Type: "varchar(10)"},
+			{Name: "city", Type: "text"},
+		},
+	)
+
+	assert.Equal(t, []string{
+		`ALTER TYPE "public"."address" DROP ATTRIBUTE "country"`,
+		`ALTER TYPE "public"."address" ALTER ATTRIBUTE "street" TYPE text COLLATE "C"`,
+		`ALTER TYPE "public"."address" ADD ATTRIBUTE "city" text`,
+	}, queries)
+}
+
+func TestAccPostgresqlCompositeType_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+
+	config := `
+resource "postgresql_composite_type" "test" {
+  database = "%s"
+  schema   = "test_schema"
+  name     = "%s"
+  owner    = "%s"
+
+  attribute {
+    name = "street"
+    type = "text"
+  }
+
+  attribute {
+    name = "zip"
+    type = "%s"
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlTypeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, dbName, "address", roleName, "varchar(10)"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTypeExists("postgresql_composite_type.test"),
+					resource.TestCheckResourceAttr("postgresql_composite_type.test", "owner", roleName),
+					resource.TestCheckResourceAttr("postgresql_composite_type.test", "attribute.#", "2"),
+					resource.TestCheckResourceAttr("postgresql_composite_type.test", "attribute.1.type", "character varying(10)"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, dbName, "postal_address", roleName, "text"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTypeExists("postgresql_composite_type.test"),
+					resource.TestCheckResourceAttr("postgresql_composite_type.test", "id", fmt.Sprintf("%s.test_schema.postal_address", dbName)),
+					resource.TestCheckResourceAttr("postgresql_composite_type.test", "attribute.1.type", "text"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_composite_type.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"drop_cascade"},
+			},
+		},
+	})
+}
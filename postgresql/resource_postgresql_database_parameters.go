@@ -0,0 +1,318 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	dbParametersDatabaseAttr   = "database"
+	dbParametersRoleAttr       = "role"
+	dbParametersParametersAttr = "parameters"
+)
+
+// listParameters are the parameters whose value is a list. Each element has to be
+// quoted separately, otherwise the whole value is considered as a single element.
+var listParameters = map[string]bool{
+	"search_path":               true,
+	"temp_tablespaces":          true,
+	"session_preload_libraries": true,
+	"local_preload_libraries":   true,
+	"shared_preload_libraries":  true,
+}
+
+func resourcePostgreSQLDatabaseParameters() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLDatabaseParametersCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLDatabaseParametersRead),
+		Update: PGResourceFunc(resourcePostgreSQLDatabaseParametersUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLDatabaseParametersDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			dbParametersDatabaseAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The database on which the parameters are set",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			dbParametersRoleAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The role for which the parameters are set in the database. If not specified, the parameters are set for all roles",
+			},
+			dbParametersParametersAttr: {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "The configuration parameters (e.g.: statement_timeout) and their values. Parameters which are not listed are reset",
+
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					name := strings.TrimPrefix(k, dbParametersParametersAttr+".")
+					return normalizeParameterValue(name, old) == normalizeParameterValue(name, new)
+				},
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLDatabaseParametersCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := d.Get(dbParametersDatabaseAttr).(string)
+	role := d.Get(dbParametersRoleAttr).(string)
+
+	txn, err := startTransaction(db.client, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	// The parameters are managed authoritatively, the existing ones are reset first.
+	queries := []string{dbParametersResetAllQuery(database, role)}
+	queries = append(queries, dbParametersQueries(database, role, nil, d.Get(dbParametersParametersAttr).(map[string]interface{}))...)
+	for _, query := range queries {
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not set parameters of database %s: %w", database, err)
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateDatabaseParametersID(database, role))
+
+	return resourcePostgreSQLDatabaseParametersReadImpl(db, d)
+}
+
+func resourcePostgreSQLDatabaseParametersRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLDatabaseParametersReadImpl(db, d)
+}
+
+func resourcePostgreSQLDatabaseParametersReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, role := getDatabaseParametersTarget(d)
+
+	var databaseOID, roleOID uint32
+	err := db.QueryRow("SELECT oid FROM pg_catalog.pg_database WHERE datname = $1", database).Scan(&databaseOID)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL database (%s) not found", database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading database: %w", err)
+	}
+
+	if role != "" {
+		err = db.QueryRow("SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $1", role).Scan(&roleOID)
+		switch {
+		case err == sql.ErrNoRows:
+			log.Printf("[WARN] PostgreSQL role (%s) not found", role)
+			d.SetId("")
+			return nil
+		case err != nil:
+			return fmt.Errorf("Error reading role: %w", err)
+		}
+	}
+
+	var settings []string
+	err = db.QueryRow(
+		"SELECT setconfig FROM pg_catalog.pg_db_role_setting WHERE setdatabase = $1 AND setrole = $2",
+		databaseOID, roleOID,
+	).Scan(pq.Array(&settings))
+	switch {
+	case err == sql.ErrNoRows:
+		settings = nil
+	case err != nil:
+		return fmt.Errorf("Error reading parameters of database %s: %w", database, err)
+	}
+
+	d.Set(dbParametersDatabaseAttr, database)
+	d.Set(dbParametersRoleAttr, role)
+	d.Set(dbParametersParametersAttr, readParameterSettings(
+		settings, d.Get(dbParametersParametersAttr).(map[string]interface{}),
+	))
+	d.SetId(generateDatabaseParametersID(database, role))
+
+	return nil
+}
+
+func resourcePostgreSQLDatabaseParametersUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if !d.HasChange(dbParametersParametersAttr) {
+		return resourcePostgreSQLDatabaseParametersReadImpl(db, d)
+	}
+
+	database := d.Get(dbParametersDatabaseAttr).(string)
+	role := d.Get(dbParametersRoleAttr).(string)
+	oldParameters, newParameters := d.GetChange(dbParametersParametersAttr)
+
+	txn, err := startTransaction(db.client, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	for _, query := range dbParametersQueries(
+		database, role, oldParameters.(map[string]interface{}), newParameters.(map[string]interface{}),
+	) {
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not set parameters of database %s: %w", database, err)
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourcePostgreSQLDatabaseParametersReadImpl(db, d)
+}
+
+func resourcePostgreSQLDatabaseParametersDelete(db *DBConnection, d *schema.ResourceData) error {
+	database, role := getDatabaseParametersTarget(d)
+
+	if _, err := db.Exec(dbParametersResetAllQuery(database, role)); err != nil {
+		return fmt.Errorf("could not reset parameters of database %s: %w", database, err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// dbParametersAlterPrefix returns the beginning of the ALTER statement setting parameters
+// for a database or for a role in a database.
+func dbParametersAlterPrefix(database, role string) string {
+	if database == "" {
+		return fmt.Sprintf("ALTER ROLE %s", pq.QuoteIdentifier(role))
+	}
+	if role == "" {
+		return fmt.Sprintf("ALTER DATABASE %s", pq.QuoteIdentifier(database))
+	}
+	return fmt.Sprintf("ALTER ROLE %s IN DATABASE %s", pq.QuoteIdentifier(role), pq.QuoteIdentifier(database))
+}
+
+func dbParametersResetAllQuery(database, role string) string {
+	return dbParametersAlterPrefix(database, role) + " RESET ALL"
+}
+
+// dbParametersQueries returns the statements setting the new or changed parameters
+// and resetting the removed ones.
+func dbParametersQueries(database, role string, oldParameters, newParameters map[string]interface{}) []string {
+	prefix := dbParametersAlterPrefix(database, role)
+	queries := []string{}
+
+	for _, name := range sortedOptionKeys(oldParameters) {
+		if _, ok := newParameters[name]; !ok {
+			queries = append(queries, fmt.Sprintf("%s RESET %s", prefix, quoteParameterName(name)))
+		}
+	}
+
+	for _, name := range sortedOptionKeys(newParameters) {
+		value := newParameters[name].(string)
+		if oldValue, ok := oldParameters[name]; ok && normalizeParameterValue(name, oldValue.(string)) == normalizeParameterValue(name, value) {
+			continue
+		}
+		queries = append(queries, fmt.Sprintf("%s SET %s TO %s", prefix, quoteParameterName(name), parameterValueSQL(name, value)))
+	}
+
+	return queries
+}
+
+// quoteParameterName quotes the name of a parameter which can be
+// a custom parameter with a prefix (e.g.: pg_stat_statements.track).
+func quoteParameterName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = pq.QuoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// parameterValueSQL returns the SQL representation of a parameter value.
+// Each element of a list parameter is quoted separately (e.g.: "$user", public => '$user', 'public').
+func parameterValueSQL(name, value string) string {
+	if !listParameters[name] {
+		return pq.QuoteLiteral(value)
+	}
+
+	elements := splitListParameter(value)
+	if len(elements) == 0 {
+		return "''"
+	}
+	for i, element := range elements {
+		elements[i] = pq.QuoteLiteral(element)
+	}
+	return strings.Join(elements, ", ")
+}
+
+// normalizeParameterValue normalizes a parameter value in order to compare what is configured
+// with what is stored by PostgreSQL, e.g.: search_path is stored as "$user", public
+func normalizeParameterValue(name, value string) string {
+	if !listParameters[name] {
+		return value
+	}
+	return strings.Join(splitListParameter(value), ", ")
+}
+
+func splitListParameter(value string) []string {
+	elements := []string{}
+	for _, element := range strings.Split(value, ",") {
+		element = strings.TrimSpace(element)
+		if len(element) >= 2 && strings.HasPrefix(element, `"`) && strings.HasSuffix(element, `"`) {
+			element = element[1 : len(element)-1]
+		}
+		if element != "" {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
+// readParameterSettings parses the settings stored by PostgreSQL (e.g.: {work_mem=64MB})
+// and keeps the configured values if they are equivalent.
+func readParameterSettings(settings []string, configured map[string]interface{}) map[string]interface{} {
+	parameters := make(map[string]interface{}, len(settings))
+	for name, value := range parseRelOptions(settings) {
+		if c, ok := configured[name]; ok && normalizeParameterValue(name, c.(string)) == normalizeParameterValue(name, value) {
+			value = c.(string)
+		}
+		parameters[name] = value
+	}
+	return parameters
+}
+
+func generateDatabaseParametersID(database, role string) string {
+	if role == "" {
+		return database
+	}
+	return strings.Join([]string{database, role}, ".")
+}
+
+// getDatabaseParametersTarget returns the database and the role of the parameters. If we are importing this resource,
+// they will be parsed from the resource ID otherwise they will be simply get from the state.
+func getDatabaseParametersTarget(d *schema.ResourceData) (string, string) {
+	database := d.Get(dbParametersDatabaseAttr).(string)
+	role := d.Get(dbParametersRoleAttr).(string)
+
+	// When importing, we have to parse the ID to find database and role names.
+	if database == "" {
+		parsed := strings.SplitN(d.Id(), ".", 2)
+		database = parsed[0]
+		if len(parsed) == 2 {
+			role = parsed[1]
+		}
+	}
+
+	return database, role
+}
@@ -0,0 +1,181 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBParametersQueries(t *testing.T) {
+	oldParameters := map[string]interface{}{
+		"work_mem":          "4MB",
+		"statement_timeout": "30s",
+		"search_path":       `"$user", public`,
+	}
+	newParameters := map[string]interface{}{
+		"work_mem":                      "64MB",
+		"search_path":                   "$user,public",
+		"pg_stat_statements.track":      "all",
+		"default_transaction_isolation": "repeatable read",
+	}
+
+	assert.Equal(t,
+		[]string{
+			`ALTER DATABASE "app" RESET "statement_timeout"`,
+			`ALTER DATABASE "app" SET "default_transaction_isolation" TO 'repeatable read'`,
+			`ALTER DATABASE "app" SET "pg_stat_statements"."track" TO 'all'`,
+			`ALTER DATABASE "app" SET "work_mem" TO '64MB'`,
+		},
+		dbParametersQueries("app", "", oldParameters, newParameters),
+	)
+
+	assert.Equal(t,
+		[]string{
+			`ALTER ROLE "reader" IN DATABASE "app" SET "search_path" TO 'reporting', 'public'`,
+		},
+		dbParametersQueries("app", "reader", nil, map[string]interface{}{"search_path": "reporting, public"}),
+	)
+
+	assert.Equal(t, `ALTER ROLE "reader" IN DATABASE "app" RESET ALL`, dbParametersResetAllQuery("app", "reader"))
+
+	assert.Equal(t,
+		[]string{`ALTER ROLE "reader" RESET "work_mem"`},
+		dbParametersQueries("", "reader", map[string]interface{}{"work_mem": "4MB"}, nil),
+	)
+}
+
+func TestReadParameterSettings(t *testing.T) {
+	assert.Equal(t,
+		map[string]interface{}{
+			"search_path": "$user, public",
+			"work_mem":    "64MB",
+		},
+		readParameterSettings(
+			[]string{`search_path="$user", public`, "work_mem=64MB"},
+			map[string]interface{}{"search_path": "$user, public", "work_mem": "32MB"},
+		),
+	)
+}
+
+func TestAccPostgresqlDatabaseParameters_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+
+	config := `
+resource "postgresql_database_parameters" "database" {
+  database   = "%s"
+  parameters = %s
+}
+
+resource "postgresql_database_parameters" "role" {
+  database = "%s"
+  role     = "%s"
+  parameters = {
+    search_path = "$user, public"
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlDatabaseParametersDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, dbName, `{
+    statement_timeout = "30s"
+    work_mem          = "64MB"
+  }`, dbName, roleName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_database_parameters.database", "parameters.%", "2"),
+					resource.TestCheckResourceAttr("postgresql_database_parameters.database", "parameters.statement_timeout", "30s"),
+					resource.TestCheckResourceAttr("postgresql_database_parameters.database", "parameters.work_mem", "64MB"),
+					resource.TestCheckResourceAttr("postgresql_database_parameters.role", "parameters.search_path", "$user, public"),
+					testAccCheckDatabaseParameters(dbName, "", []string{"statement_timeout=30s", "work_mem=64MB"}),
+					testAccCheckDatabaseParameters(dbName, roleName, []string{`search_path="$user", public`}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, dbName, `{
+    work_mem = "128MB"
+  }`, dbName, roleName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_database_parameters.database", "parameters.%", "1"),
+					resource.TestCheckResourceAttr("postgresql_database_parameters.database", "parameters.work_mem", "128MB"),
+					testAccCheckDatabaseParameters(dbName, "", []string{"work_mem=128MB"}),
+				),
+			},
+			{
+				ResourceName:      "postgresql_database_parameters.role",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDatabaseParameters(database, role string, expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		settings, err := getDatabaseParameters(database, role)
+		if err != nil {
+			return err
+		}
+
+		if !assert.ObjectsAreEqual(expected, settings) {
+			return fmt.Errorf("expected parameters %v, got %v", expected, settings)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPostgresqlDatabaseParametersDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_database_parameters" {
+			continue
+		}
+
+		settings, err := getDatabaseParameters(rs.Primary.Attributes["database"], rs.Primary.Attributes["role"])
+		if err != nil {
+			return err
+		}
+
+		if len(settings) > 0 {
+			return fmt.Errorf("Database parameters still exist after destroy: %v", settings)
+		}
+	}
+
+	return nil
+}
+
+func getDatabaseParameters(database, role string) ([]string, error) {
+	client := testAccProvider.Meta().(*Client)
+	db, err := client.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	var settings []string
+	if err := db.QueryRow(`
+SELECT COALESCE((
+    SELECT s.setconfig FROM pg_catalog.pg_db_role_setting s
+      JOIN pg_catalog.pg_database d ON d.oid = s.setdatabase
+     WHERE d.datname = $1
+       AND s.setrole = COALESCE((SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $2), 0)
+), '{}')
+`, database, role).Scan(pq.Array(&settings)); err != nil {
+		return nil, fmt.Errorf("could not read parameters of database %s: %w", database, err)
+	}
+
+	return settings, nil
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -19,6 +20,9 @@ func resourcePostgreSQLDefaultPrivileges() *schema.Resource {
 		Update: PGResourceFunc(resourcePostgreSQLDefaultPrivilegesCreate),
 		Read:   PGResourceFunc(resourcePostgreSQLDefaultPrivilegesRead),
 		Delete: PGResourceFunc(resourcePostgreSQLDefaultPrivilegesDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: PGResourceImportFunc(resourcePostgreSQLDefaultPrivilegesImport),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"role": {
@@ -34,16 +38,34 @@ func resourcePostgreSQLDefaultPrivileges() *schema.Resource {
 				Description: "The database to grant default privileges for this role",
 			},
 			"owner": {
-				Type:        schema.TypeString,
-				Required:    true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"owner", "owners"},
+				Description:  "Target role for which to alter default privileges.",
+			},
+			"owners": {
+				Type:        schema.TypeSet,
+				Optional:    true,
 				ForceNew:    true,
-				Description: "Target role for which to alter default privileges.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Target roles for which to alter default privileges.",
 			},
 			"schema": {
-				Type:        schema.TypeString,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"schemas"},
+				Description:   "The database schema to set default privileges for this role",
+			},
+			"schemas": {
+				Type:        schema.TypeSet,
 				Optional:    true,
 				ForceNew:    true,
-				Description: "The database schema to set default privileges for this role",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "The database schemas to set default privileges for this role",
 			},
 			"object_type": {
 				Type:     schema.TypeString,
@@ -55,8 +77,9 @@ func resourcePostgreSQLDefaultPrivileges() *schema.Resource {
 					"function",
 					"type",
 					"schema",
+					"large_object",
 				}, false),
-				Description: "The PostgreSQL object type to set the default privileges on (one of: table, sequence, function, type, schema)",
+				Description: "The PostgreSQL object type to set the default privileges on (one of: table, sequence, function, type, schema, large_object)",
 			},
 			"privileges": {
 				Type:        schema.TypeSet,
@@ -86,6 +109,12 @@ func resourcePostgreSQLDefaultPrivilegesRead(db *DBConnection, d *schema.Resourc
 			db.version,
 		)
 	}
+	if objectType == "large_object" && !db.featureSupported(featureDefaultPrivilegesOnLargeObjects) {
+		return fmt.Errorf(
+			"changing default privileges for large objects is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
 
 	exists, err := checkRoleDBSchemaExists(db.client, d)
 	if err != nil {
@@ -102,6 +131,17 @@ func resourcePostgreSQLDefaultPrivilegesRead(db *DBConnection, d *schema.Resourc
 	}
 	defer deferredRollback(txn)
 
+	// checkRoleDBSchemaExists only checks the schema attribute.
+	missing, err := getMissingDefaultPrivilegesSchemas(txn, d)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		log.Printf("[WARN] PostgreSQL schemas %s of default privileges %s not found", strings.Join(missing, ", "), d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	return readRoleDefaultPrivileges(txn, d)
 }
 
@@ -118,6 +158,20 @@ func resourcePostgreSQLDefaultPrivilegesCreate(db *DBConnection, d *schema.Resou
 		}
 		return fmt.Errorf("cannot specify `schema` when `object_type` is `schema`")
 	}
+	if objectType == "large_object" {
+		if !db.featureSupported(featureDefaultPrivilegesOnLargeObjects) {
+			return fmt.Errorf(
+				"changing default privileges for large objects is not supported for this Postgres version (%s)",
+				db.version,
+			)
+		}
+		if pgSchema != "" {
+			return fmt.Errorf("cannot specify `schema` when `object_type` is `large_object`")
+		}
+	}
+	if d.Get("schemas").(*schema.Set).Len() > 0 && (objectType == "schema" || objectType == "large_object") {
+		return fmt.Errorf("cannot specify `schemas` when `object_type` is `%s`", objectType)
+	}
 
 	if d.Get("with_grant_option").(bool) && strings.ToLower(d.Get("role").(string)) == "public" {
 		return fmt.Errorf("with_grant_option cannot be true for role 'public'")
@@ -128,7 +182,7 @@ func resourcePostgreSQLDefaultPrivilegesCreate(db *DBConnection, d *schema.Resou
 	}
 
 	database := d.Get("database").(string)
-	owner := d.Get("owner").(string)
+	owners := getDefaultPrivilegesOwners(d)
 
 	txn, err := startTransaction(db.client, database)
 	if err != nil {
@@ -136,12 +190,22 @@ func resourcePostgreSQLDefaultPrivilegesCreate(db *DBConnection, d *schema.Resou
 	}
 	defer deferredRollback(txn)
 
-	if err := pgLockRole(txn, owner); err != nil {
+	missing, err := getMissingDefaultPrivilegesSchemas(txn, d)
+	if err != nil {
 		return err
 	}
+	if len(missing) > 0 {
+		return fmt.Errorf("schemas %s do not exist in database %s", strings.Join(missing, ", "), database)
+	}
+
+	for _, owner := range owners {
+		if err := pgLockRole(txn, owner); err != nil {
+			return err
+		}
+	}
 
 	// Needed in order to set the owner of the db if the connection user is not a superuser
-	if err := withRolesGranted(txn, []string{owner}, func() error {
+	if err := withRolesGranted(txn, owners, func() error {
 
 		// Revoke all privileges before granting otherwise reducing privileges will not work.
 		// We just have to revoke them in the same transaction so role will not lost his privileges
@@ -174,7 +238,7 @@ func resourcePostgreSQLDefaultPrivilegesCreate(db *DBConnection, d *schema.Resou
 }
 
 func resourcePostgreSQLDefaultPrivilegesDelete(db *DBConnection, d *schema.ResourceData) error {
-	owner := d.Get("owner").(string)
+	owners := getDefaultPrivilegesOwners(d)
 	pgSchema := d.Get("schema").(string)
 	objectType := d.Get("object_type").(string)
 
@@ -191,12 +255,14 @@ func resourcePostgreSQLDefaultPrivilegesDelete(db *DBConnection, d *schema.Resou
 	}
 	defer deferredRollback(txn)
 
-	if err := pgLockRole(txn, owner); err != nil {
-		return err
+	for _, owner := range owners {
+		if err := pgLockRole(txn, owner); err != nil {
+			return err
+		}
 	}
 
 	// Needed in order to set the owner of the db if the connection user is not a superuser
-	if err := withRolesGranted(txn, []string{owner}, func() error {
+	if err := withRolesGranted(txn, owners, func() error {
 		return revokeRoleDefaultPrivileges(txn, d)
 	}); err != nil {
 		return err
@@ -211,13 +277,12 @@ func resourcePostgreSQLDefaultPrivilegesDelete(db *DBConnection, d *schema.Resou
 
 func readRoleDefaultPrivileges(txn *sql.Tx, d *schema.ResourceData) error {
 	role := d.Get("role").(string)
-	owner := d.Get("owner").(string)
-	pgSchema := d.Get("schema").(string)
-	objectType := d.Get("object_type").(string)
-	privilegesInput := d.Get("privileges").(*schema.Set).List()
+	privilegesInput := d.Get("privileges").(*schema.Set)
 
-	if err := pgLockRole(txn, owner); err != nil {
-		return err
+	for _, owner := range getDefaultPrivilegesOwners(d) {
+		if err := pgLockRole(txn, owner); err != nil {
+			return err
+		}
 	}
 
 	roleOID, err := getRoleOID(txn, role)
@@ -225,6 +290,43 @@ func readRoleDefaultPrivileges(txn *sql.Tx, d *schema.ResourceData) error {
 		return err
 	}
 
+	// Every owner and schema should have the same default privileges:
+	// if one of them doesn't, we return its privileges to force an update.
+	privilegesSet := privilegesInput
+	found, drifted := false, false
+	for _, target := range getDefaultPrivilegesTargets(d) {
+		privileges, err := getRoleDefaultPrivileges(txn, d, roleOID, target.owner, target.schema)
+		if err != nil {
+			return err
+		}
+		if len(privileges) == 0 {
+			log.Printf("[DEBUG] no default privileges for role %s, owner %s in schema %s", role, target.owner, target.schema)
+		} else {
+			found = true
+		}
+
+		if set := pgArrayToSet(privileges); !drifted && !set.Equal(privilegesInput) {
+			privilegesSet, drifted = set, true
+		}
+	}
+
+	// We consider no privileges as "not exists" unless no privileges were provided as input
+	if !found && privilegesInput.Len() != 0 {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("privileges", privilegesSet)
+	d.SetId(generateDefaultPrivilegesID(d))
+
+	return nil
+}
+
+// getRoleDefaultPrivileges returns the default privileges granted to a role
+// on the objects created by the owner in the schema, or in any schema if the schema is empty.
+func getRoleDefaultPrivileges(txn *sql.Tx, d *schema.ResourceData, roleOID uint32, owner, pgSchema string) (pq.ByteaArray, error) {
+	objectType := d.Get("object_type").(string)
+
 	var query string
 	var queryArgs []interface{}
 
@@ -255,28 +357,14 @@ func readRoleDefaultPrivileges(txn *sql.Tx, d *schema.ResourceData) error {
 	if err := txn.QueryRow(
 		query, queryArgs...,
 	).Scan(&privileges); err != nil {
-		return fmt.Errorf("could not read default privileges: %w", err)
-	}
-
-	// We consider no privileges as "not exists" unless no privileges were provided as input
-	if len(privileges) == 0 {
-		log.Printf("[DEBUG] no default privileges for role %s in schema %s", role, pgSchema)
-		if len(privilegesInput) != 0 {
-			d.SetId("")
-			return nil
-		}
+		return nil, fmt.Errorf("could not read default privileges: %w", err)
 	}
 
-	privilegesSet := pgArrayToSet(privileges)
-	d.Set("privileges", privilegesSet)
-	d.SetId(generateDefaultPrivilegesID(d))
-
-	return nil
+	return privileges, nil
 }
 
 func grantRoleDefaultPrivileges(txn *sql.Tx, d *schema.ResourceData) error {
 	role := d.Get("role").(string)
-	pgSchema := d.Get("schema").(string)
 
 	privileges := []string{}
 	for _, priv := range d.Get("privileges").(*schema.Set).List() {
@@ -284,71 +372,190 @@ func grantRoleDefaultPrivileges(txn *sql.Tx, d *schema.ResourceData) error {
 	}
 
 	if len(privileges) == 0 {
-		log.Printf("[DEBUG] no default privileges to grant for role %s, owner %s in database: %s,", d.Get("role").(string), d.Get("owner").(string), d.Get("database").(string))
+		log.Printf("[DEBUG] no default privileges to grant for role %s, owners %v in database: %s,", d.Get("role").(string), getDefaultPrivilegesOwners(d), d.Get("database").(string))
 		return nil
 	}
 
-	var inSchema string
+	for _, target := range getDefaultPrivilegesTargets(d) {
+		var inSchema string
 
-	// If a schema is specified we need to build the part of the query string to action this
-	if pgSchema != "" {
-		inSchema = fmt.Sprintf("IN SCHEMA %s", pq.QuoteIdentifier(pgSchema))
-	}
+		// If a schema is specified we need to build the part of the query string to action this
+		if target.schema != "" {
+			inSchema = fmt.Sprintf("IN SCHEMA %s", pq.QuoteIdentifier(target.schema))
+		}
 
-	query := fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s %s GRANT %s ON %sS TO %s",
-		pq.QuoteIdentifier(d.Get("owner").(string)),
-		inSchema,
-		strings.Join(privileges, ","),
-		strings.ToUpper(d.Get("object_type").(string)),
-		pq.QuoteIdentifier(role),
-	)
+		query := fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s %s GRANT %s ON %s TO %s",
+			pq.QuoteIdentifier(target.owner),
+			inSchema,
+			strings.Join(privileges, ","),
+			defaultPrivilegesObjectType(d),
+			pq.QuoteIdentifier(role),
+		)
 
-	if d.Get("with_grant_option").(bool) {
-		query = query + " WITH GRANT OPTION"
-	}
+		if d.Get("with_grant_option").(bool) {
+			query = query + " WITH GRANT OPTION"
+		}
 
-	_, err := txn.Exec(
-		query,
-	)
-	if err != nil {
-		return fmt.Errorf("could not alter default privileges: %w", err)
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not alter default privileges: %w", err)
+		}
 	}
 
 	return nil
 }
 
 func revokeRoleDefaultPrivileges(txn *sql.Tx, d *schema.ResourceData) error {
-	pgSchema := d.Get("schema").(string)
+	for _, target := range getDefaultPrivilegesTargets(d) {
+		var inSchema string
 
-	var inSchema string
+		// If a schema is specified we need to build the part of the query string to action this
+		if target.schema != "" {
+			inSchema = fmt.Sprintf("IN SCHEMA %s", pq.QuoteIdentifier(target.schema))
+		}
+		query := fmt.Sprintf(
+			"ALTER DEFAULT PRIVILEGES FOR ROLE %s %s REVOKE ALL ON %s FROM %s",
+			pq.QuoteIdentifier(target.owner),
+			inSchema,
+			defaultPrivilegesObjectType(d),
+			pq.QuoteIdentifier(d.Get("role").(string)),
+		)
 
-	// If a schema is specified we need to build the part of the query string to action this
-	if pgSchema != "" {
-		inSchema = fmt.Sprintf("IN SCHEMA %s", pq.QuoteIdentifier(pgSchema))
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not revoke default privileges: %w", err)
+		}
 	}
-	query := fmt.Sprintf(
-		"ALTER DEFAULT PRIVILEGES FOR ROLE %s %s REVOKE ALL ON %sS FROM %s",
-		pq.QuoteIdentifier(d.Get("owner").(string)),
-		inSchema,
-		strings.ToUpper(d.Get("object_type").(string)),
-		pq.QuoteIdentifier(d.Get("role").(string)),
-	)
+	return nil
+}
+
+// defaultPrivilegesObjectType returns the plural object type used by ALTER DEFAULT PRIVILEGES, e.g.: TABLES.
+func defaultPrivilegesObjectType(d *schema.ResourceData) string {
+	return strings.ReplaceAll(strings.ToUpper(d.Get("object_type").(string)), "_", " ") + "S"
+}
 
-	if _, err := txn.Exec(query); err != nil {
-		return fmt.Errorf("could not revoke default privileges: %w", err)
+// defaultPrivilegesTarget is an owner and a schema (empty for all the schemas) the default privileges are altered for.
+type defaultPrivilegesTarget struct {
+	owner  string
+	schema string
+}
+
+func getDefaultPrivilegesOwners(d *schema.ResourceData) []string {
+	if owner := d.Get("owner").(string); owner != "" {
+		return []string{owner}
 	}
-	return nil
+
+	owners := interfaceSliceToStrings(d.Get("owners").(*schema.Set).List())
+	sort.Strings(owners)
+	return owners
+}
+
+func getDefaultPrivilegesSchemas(d *schema.ResourceData) []string {
+	schemas := interfaceSliceToStrings(d.Get("schemas").(*schema.Set).List())
+	if len(schemas) == 0 {
+		return []string{d.Get("schema").(string)}
+	}
+
+	sort.Strings(schemas)
+	return schemas
+}
+
+// getMissingDefaultPrivilegesSchemas returns the schemas of the default privileges which don't exist in the database.
+func getMissingDefaultPrivilegesSchemas(txn *sql.Tx, d *schema.ResourceData) ([]string, error) {
+	missing := []string{}
+	for _, pgSchema := range getDefaultPrivilegesSchemas(d) {
+		if pgSchema == "" {
+			continue
+		}
+		exists, err := schemaExists(txn, pgSchema)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			missing = append(missing, pgSchema)
+		}
+	}
+	return missing, nil
+}
+
+// getDefaultPrivilegesTargets returns the cross product of the owners and the schemas.
+func getDefaultPrivilegesTargets(d *schema.ResourceData) []defaultPrivilegesTarget {
+	targets := []defaultPrivilegesTarget{}
+	for _, owner := range getDefaultPrivilegesOwners(d) {
+		for _, pgSchema := range getDefaultPrivilegesSchemas(d) {
+			targets = append(targets, defaultPrivilegesTarget{owner: owner, schema: pgSchema})
+		}
+	}
+	return targets
 }
 
 func generateDefaultPrivilegesID(d *schema.ResourceData) string {
-	pgSchema := d.Get("schema").(string)
+	pgSchema := strings.Join(getDefaultPrivilegesSchemas(d), ",")
 	if pgSchema == "" {
 		pgSchema = "noschema"
 	}
 
 	return strings.Join([]string{
 		d.Get("role").(string), d.Get("database").(string), pgSchema,
-		d.Get("owner").(string), d.Get("object_type").(string),
+		strings.Join(getDefaultPrivilegesOwners(d), ","), d.Get("object_type").(string),
 	}, "_")
 
 }
+
+const defaultPrivilegesImportIDFormat = "role/database/schema/owner/object_type"
+
+// resourcePostgreSQLDefaultPrivilegesImport parses an import ID of the form role/database/schema/owner/object_type,
+// schema being empty for default privileges on all the schemas. The privileges are then read from the catalogs.
+func resourcePostgreSQLDefaultPrivilegesImport(db *DBConnection, d *schema.ResourceData) error {
+	if err := setDefaultPrivilegesFromImportID(d); err != nil {
+		return err
+	}
+
+	// with_grant_option forces a new resource so it needs to be read when importing.
+	txn, err := startTransaction(db.client, d.Get("database").(string))
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var withGrantOption bool
+	if err := txn.QueryRow(`
+SELECT COALESCE(bool_or(a.is_grantable), false)
+  FROM pg_catalog.pg_default_acl da
+ CROSS JOIN LATERAL pg_catalog.aclexplode(da.defaclacl) a
+ WHERE da.defaclobjtype = $1
+   AND da.defaclnamespace = COALESCE((SELECT oid FROM pg_catalog.pg_namespace WHERE nspname = $2), 0)
+   AND pg_catalog.pg_get_userbyid(a.grantor) = $3
+   AND a.grantee = COALESCE((SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $4), 0)
+`,
+		objectTypes[d.Get("object_type").(string)], d.Get("schema").(string),
+		d.Get("owner").(string), d.Get("role").(string),
+	).Scan(&withGrantOption); err != nil {
+		return fmt.Errorf("could not read default privileges: %w", err)
+	}
+	d.Set("with_grant_option", withGrantOption)
+
+	return nil
+}
+
+func setDefaultPrivilegesFromImportID(d *schema.ResourceData) error {
+	parts, err := splitImportID(d.Id(), 5, 5, defaultPrivilegesImportIDFormat)
+	if err != nil {
+		return err
+	}
+	role, database, pgSchema, owner, objectType := parts[0], parts[1], parts[2], parts[3], parts[4]
+
+	if role == "" || database == "" || owner == "" {
+		return fmt.Errorf("invalid import ID %q, expected format: %s", d.Id(), defaultPrivilegesImportIDFormat)
+	}
+	if _, ok := objectTypes[objectType]; !ok {
+		return fmt.Errorf("invalid object type %q in import ID %q", objectType, d.Id())
+	}
+
+	d.Set("role", role)
+	d.Set("database", database)
+	d.Set("schema", pgSchema)
+	d.Set("owner", owner)
+	d.Set("object_type", objectType)
+	d.SetId(generateDefaultPrivilegesID(d))
+
+	return nil
+}
@@ -2,12 +2,44 @@ package postgresql
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
+func TestGetDefaultPrivilegesTargets(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLDefaultPrivileges().Schema, map[string]interface{}{
+		"role":        "foo",
+		"database":    "bar",
+		"owners":      []interface{}{"owner2", "owner1"},
+		"schemas":     []interface{}{"s2", "s1"},
+		"object_type": "table",
+	})
+
+	assert.Equal(t, []defaultPrivilegesTarget{
+		{owner: "owner1", schema: "s1"},
+		{owner: "owner1", schema: "s2"},
+		{owner: "owner2", schema: "s1"},
+		{owner: "owner2", schema: "s2"},
+	}, getDefaultPrivilegesTargets(d))
+	assert.Equal(t, "foo_bar_s1,s2_owner1,owner2_table", generateDefaultPrivilegesID(d))
+
+	d = schema.TestResourceDataRaw(t, resourcePostgreSQLDefaultPrivileges().Schema, map[string]interface{}{
+		"role":        "foo",
+		"database":    "bar",
+		"owner":       "owner1",
+		"object_type": "large_object",
+	})
+
+	assert.Equal(t, []defaultPrivilegesTarget{{owner: "owner1", schema: ""}}, getDefaultPrivilegesTargets(d))
+	assert.Equal(t, "foo_bar_noschema_owner1_large_object", generateDefaultPrivilegesID(d))
+	assert.Equal(t, "LARGE OBJECTS", defaultPrivilegesObjectType(d))
+}
+
 func TestAccPostgresqlDefaultPrivileges(t *testing.T) {
 	skipIfNotAcc(t)
 
@@ -83,6 +115,12 @@ resource "postgresql_default_privileges" "test_ro" {
 							resource.TestCheckResourceAttr("postgresql_default_privileges.test_ro", "privileges.0", "SELECT"),
 						),
 					},
+					{
+						ResourceName:      "postgresql_default_privileges.test_ro",
+						ImportState:       true,
+						ImportStateId:     fmt.Sprintf("%s/%s/test_schema/%s/table", role, dbName, config.Username),
+						ImportStateVerify: true,
+					},
 					{
 						Config: fmt.Sprintf(tfConfig, `["SELECT", "UPDATE"]`),
 						Check: resource.ComposeTestCheckFunc(
@@ -350,3 +388,78 @@ resource "postgresql_default_privileges" "test_ro" {
 		})
 	}
 }
+
+func TestAccPostgresqlDefaultPrivileges_OwnersAndSchemas(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	config := getTestConfig(t)
+	dbName, roleName := getTestDBNames(dbSuffix)
+
+	createTestSchemas(t, dbSuffix, []string{"test_schema2"}, "")
+	dbExecute(t, config.connStr(dbName), fmt.Sprintf("GRANT USAGE ON SCHEMA test_schema2 TO %s", roleName))
+
+	// We set PGUSER as one of the owners as he will create the test tables
+	tfConfig := fmt.Sprintf(`
+resource "postgresql_role" "owner" {
+	name = "test_default_privileges_owner"
+}
+
+resource "postgresql_default_privileges" "test_ro" {
+	database    = "%s"
+	owners      = ["%s", postgresql_role.owner.name]
+	role        = "%s"
+	schemas     = ["test_schema", "test_schema2"]
+	object_type = "table"
+	privileges  = ["SELECT"]
+}
+`, dbName, config.Username, roleName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tfConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"postgresql_default_privileges.test_ro", "id",
+						fmt.Sprintf("%s_%s_test_schema,test_schema2_%s,test_default_privileges_owner_table", roleName, dbName, config.Username),
+					),
+					resource.TestCheckResourceAttr("postgresql_default_privileges.test_ro", "privileges.#", "1"),
+					func(*terraform.State) error {
+						tables := []string{"test_schema.test_table", "test_schema2.test_table"}
+						// To test default privileges, we need to create tables
+						// after having apply the state.
+						dropFunc := createTestTables(t, dbSuffix, tables, "")
+						defer dropFunc()
+
+						return testCheckTablesPrivileges(t, dbName, roleName, tables, []string{"SELECT"})
+					},
+					func(*terraform.State) error {
+						dbExecute(t, config.connStr(dbName), "GRANT CREATE, USAGE ON SCHEMA test_schema, test_schema2 TO test_default_privileges_owner")
+
+						tables := []string{"test_schema.test_table_owner", "test_schema2.test_table_owner"}
+						dropFunc := createTestTables(t, dbSuffix, tables, "test_default_privileges_owner")
+						defer dropFunc()
+
+						return testCheckTablesPrivileges(t, dbName, roleName, tables, []string{"SELECT"})
+					},
+				),
+			},
+			{
+				// A dropped schema removes the resource from the state, and creating it again names the schema.
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), "DROP SCHEMA test_schema2 CASCADE")
+				},
+				Config:      tfConfig,
+				ExpectError: regexp.MustCompile("schemas test_schema2 do not exist"),
+			},
+		},
+	})
+}
@@ -0,0 +1,366 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	domainDataTypeAttr        = "data_type"
+	domainCollationAttr       = "collation"
+	domainDefaultAttr         = "default"
+	domainNotNullAttr         = "not_null"
+	domainCheckAttr           = "check"
+	domainCheckNameAttr       = "name"
+	domainCheckExpressionAttr = "expression"
+)
+
+// pgDomainCheck is the model of a check constraint of a domain.
+type pgDomainCheck struct {
+	Name       string
+	Expression string
+}
+
+func resourcePostgreSQLDomain() *schema.Resource {
+	s := typeCommonSchema("domain")
+	s[domainDataTypeAttr] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "The underlying data type of the domain",
+
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			return normalizeColumnType(old) == normalizeColumnType(new)
+		},
+	}
+	s[domainCollationAttr] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "The collation of the domain. If not specified, the collation of the underlying data type is used",
+	}
+	s[domainDefaultAttr] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The default expression of the domain",
+
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			return normalizeTableExpression(old) == normalizeTableExpression(new)
+		},
+	}
+	s[domainNotNullAttr] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "If true, values of the domain cannot be null",
+	}
+	s[domainCheckAttr] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "The check constraints of the domain",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				domainCheckNameAttr: {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "The name of the constraint",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				domainCheckExpressionAttr: {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The boolean expression checked by the constraint. The value being tested is referenced with the keyword VALUE",
+
+					DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+						return normalizeTableExpression(old) == normalizeTableExpression(new)
+					},
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLDomainCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLDomainRead),
+		Update: PGResourceFunc(resourcePostgreSQLDomainUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLDomainDelete),
+		Exists: PGResourceExistsFunc(resourcePostgreSQLDomainExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: s,
+	}
+}
+
+func resourcePostgreSQLDomainCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getTypeSchema(d)
+	domainName := d.Get(typeNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	owner := d.Get(typeOwnerAttr).(string)
+	var rolesToGrant []string
+	if owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		if _, err := txn.Exec(createDomainQuery(d, pgSchema, domainName)); err != nil {
+			return fmt.Errorf("could not create domain %s: %w", domainName, err)
+		}
+
+		return setTypeOwner(txn, "DOMAIN", pgSchema, domainName, owner)
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateTypeID(database, pgSchema, domainName))
+
+	return resourcePostgreSQLDomainReadImpl(db, d)
+}
+
+func resourcePostgreSQLDomainExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	return typeExists(db, d, typTypeDomain)
+}
+
+func resourcePostgreSQLDomainRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLDomainReadImpl(db, d)
+}
+
+func resourcePostgreSQLDomainReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, pgSchema, domainName, err := getDBTypeName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var domainOID uint32
+	var domainOwner, dataType, collation, defaultValue string
+	var notNull bool
+	// The collation is only returned if it's not the default one of the underlying data type.
+	err = txn.QueryRow(`
+SELECT t.oid, pg_catalog.pg_get_userbyid(t.typowner),
+       pg_catalog.format_type(t.typbasetype, t.typtypmod),
+       COALESCE((
+           SELECT coll.collname FROM pg_catalog.pg_collation coll
+            WHERE coll.oid = t.typcollation AND t.typcollation <> bt.typcollation
+       ), ''),
+       COALESCE(t.typdefault, ''), t.typnotnull
+  FROM pg_catalog.pg_type t
+  JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+  JOIN pg_catalog.pg_type bt ON bt.oid = t.typbasetype
+ WHERE n.nspname = $1 AND t.typname = $2 AND t.typtype = 'd'
+`, pgSchema, domainName).Scan(&domainOID, &domainOwner, &dataType, &collation, &defaultValue, &notNull)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL domain (%s) not found in database %s", domainName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading domain: %w", err)
+	}
+
+	checks, err := readDomainChecks(txn, domainOID)
+	if err != nil {
+		return err
+	}
+
+	checksList := make([]interface{}, 0, len(checks))
+	for _, c := range checks {
+		checksList = append(checksList, map[string]interface{}{
+			domainCheckNameAttr:       c.Name,
+			domainCheckExpressionAttr: c.Expression,
+		})
+	}
+
+	d.Set(typeNameAttr, domainName)
+	d.Set(typeSchemaAttr, pgSchema)
+	d.Set(typeDatabaseAttr, database)
+	d.Set(typeOwnerAttr, domainOwner)
+	d.Set(domainDataTypeAttr, dataType)
+	d.Set(domainCollationAttr, collation)
+	d.Set(domainDefaultAttr, defaultValue)
+	d.Set(domainNotNullAttr, notNull)
+	d.Set(domainCheckAttr, sortByName(checksList, d.Get(domainCheckAttr).([]interface{})))
+	d.SetId(generateTypeID(database, pgSchema, domainName))
+
+	return nil
+}
+
+func readDomainChecks(txn *sql.Tx, domainOID uint32) ([]pgDomainCheck, error) {
+	rows, err := txn.Query(`
+SELECT conname, pg_catalog.pg_get_constraintdef(oid)
+  FROM pg_catalog.pg_constraint
+ WHERE contypid = $1 AND contype = 'c'
+ ORDER BY conname
+`, domainOID)
+	if err != nil {
+		return nil, fmt.Errorf("could not read constraints of domain: %w", err)
+	}
+	defer rows.Close()
+
+	checks := []pgDomainCheck{}
+	for rows.Next() {
+		var check pgDomainCheck
+		var definition string
+		if err := rows.Scan(&check.Name, &definition); err != nil {
+			return nil, fmt.Errorf("could not scan constraint of domain: %w", err)
+		}
+		check.Expression = parseCheckConstraintDef(definition)
+		checks = append(checks, check)
+	}
+
+	return checks, rows.Err()
+}
+
+func resourcePostgreSQLDomainUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getTypeSchema(d)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := withRolesGranted(txn, typeOwnerRoles(d), func() error {
+		if err := setTypeName(txn, d, "DOMAIN", pgSchema); err != nil {
+			return err
+		}
+
+		domainName := d.Get(typeNameAttr).(string)
+
+		for _, query := range alterDomainQueries(d, pgSchema, domainName) {
+			if _, err := txn.Exec(query); err != nil {
+				return fmt.Errorf("could not alter domain %s: %w", domainName, err)
+			}
+		}
+
+		if d.HasChange(typeOwnerAttr) {
+			return setTypeOwner(txn, "DOMAIN", pgSchema, domainName, d.Get(typeOwnerAttr).(string))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourcePostgreSQLDomainReadImpl(db, d)
+}
+
+func resourcePostgreSQLDomainDelete(db *DBConnection, d *schema.ResourceData) error {
+	return dropType(db, d, "DOMAIN")
+}
+
+func createDomainQuery(d *schema.ResourceData, pgSchema, domainName string) string {
+	b := bytes.NewBufferString("CREATE DOMAIN ")
+	fmt.Fprint(b, tableIdentifier(pgSchema, domainName), " AS ", d.Get(domainDataTypeAttr).(string))
+
+	if v, ok := d.GetOk(domainCollationAttr); ok {
+		fmt.Fprint(b, " COLLATE ", pq.QuoteIdentifier(v.(string)))
+	}
+	if v, ok := d.GetOk(domainDefaultAttr); ok {
+		fmt.Fprint(b, " DEFAULT ", v.(string))
+	}
+	if d.Get(domainNotNullAttr).(bool) {
+		b.WriteString(" NOT NULL")
+	}
+	for _, c := range domainChecksFromResourceData(d.Get(domainCheckAttr).([]interface{})) {
+		fmt.Fprint(b, " ", domainCheckDefinition(c))
+	}
+
+	return b.String()
+}
+
+func domainCheckDefinition(c pgDomainCheck) string {
+	return fmt.Sprintf("CONSTRAINT %s CHECK (%s)", pq.QuoteIdentifier(c.Name), c.Expression)
+}
+
+// alterDomainQueries returns the ALTER DOMAIN statements for the changed default, not null
+// and check constraints. A modified constraint is dropped then added again.
+func alterDomainQueries(d *schema.ResourceData, pgSchema, domainName string) []string {
+	domain := fmt.Sprintf("ALTER DOMAIN %s", tableIdentifier(pgSchema, domainName))
+	queries := []string{}
+
+	if d.HasChange(domainDefaultAttr) {
+		if v := d.Get(domainDefaultAttr).(string); v != "" {
+			queries = append(queries, fmt.Sprintf("%s SET DEFAULT %s", domain, v))
+		} else {
+			queries = append(queries, domain+" DROP DEFAULT")
+		}
+	}
+
+	if d.HasChange(domainNotNullAttr) {
+		if d.Get(domainNotNullAttr).(bool) {
+			queries = append(queries, domain+" SET NOT NULL")
+		} else {
+			queries = append(queries, domain+" DROP NOT NULL")
+		}
+	}
+
+	if d.HasChange(domainCheckAttr) {
+		o, n := d.GetChange(domainCheckAttr)
+		oldChecks := domainChecksFromResourceData(o.([]interface{}))
+		newChecks := domainChecksFromResourceData(n.([]interface{}))
+
+		oldByName := make(map[string]pgDomainCheck, len(oldChecks))
+		for _, c := range oldChecks {
+			oldByName[c.Name] = c
+		}
+		newByName := make(map[string]pgDomainCheck, len(newChecks))
+		for _, c := range newChecks {
+			newByName[c.Name] = c
+		}
+
+		for _, c := range oldChecks {
+			if nc, ok := newByName[c.Name]; !ok || normalizeTableExpression(nc.Expression) != normalizeTableExpression(c.Expression) {
+				queries = append(queries, fmt.Sprintf("%s DROP CONSTRAINT %s", domain, pq.QuoteIdentifier(c.Name)))
+			}
+		}
+		for _, c := range newChecks {
+			if oc, ok := oldByName[c.Name]; !ok || normalizeTableExpression(oc.Expression) != normalizeTableExpression(c.Expression) {
+				queries = append(queries, fmt.Sprintf("%s ADD %s", domain, domainCheckDefinition(c)))
+			}
+		}
+	}
+
+	return queries
+}
+
+func domainChecksFromResourceData(list []interface{}) []pgDomainCheck {
+	checks := []pgDomainCheck{}
+
+	for _, raw := range list {
+		m := raw.(map[string]interface{})
+		checks = append(checks, pgDomainCheck{
+			Name:       m[domainCheckNameAttr].(string),
+			Expression: m[domainCheckExpressionAttr].(string),
+		})
+	}
+
+	return checks
+}
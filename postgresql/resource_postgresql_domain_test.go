@@ -0,0 +1,88 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDomainQuery(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLDomain().Schema, map[string]interface{}{
+		"name":      "us_postal_code",
+		"data_type": "text",
+		"collation": "C",
+		"default":   "'00000'",
+		"not_null":  true,
+		"check": []interface{}{
+			map[string]interface{}{"name": "valid_code", "expression": `VALUE ~ '^\d{5}$'`},
+		},
+	})
+
+	assert.Equal(t,
+		`CREATE DOMAIN "public"."us_postal_code" AS text COLLATE "C" DEFAULT '00000' NOT NULL CONSTRAINT "valid_code" CHECK (VALUE ~ '^\d{5}$')`,
+		createDomainQuery(d, "public", "us_postal_code"),
+	)
+}
+
+func TestAccPostgresqlDomain_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+
+	config := `
+resource "postgresql_domain" "test" {
+  database  = "%s"
+  schema    = "test_schema"
+  name      = "positive_amount"
+  owner     = "%s"
+  data_type = "numeric(10,2)"
+  default   = "%s"
+  not_null  = %t
+
+  check {
+    name       = "positive"
+    expression = "%s"
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlTypeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, dbName, roleName, "1", true, "VALUE > 0"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTypeExists("postgresql_domain.test"),
+					resource.TestCheckResourceAttr("postgresql_domain.test", "owner", roleName),
+					resource.TestCheckResourceAttr("postgresql_domain.test", "data_type", "numeric(10,2)"),
+					resource.TestCheckResourceAttr("postgresql_domain.test", "not_null", "true"),
+					resource.TestCheckResourceAttr("postgresql_domain.test", "check.#", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, dbName, roleName, "10", false, "VALUE >= 10"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTypeExists("postgresql_domain.test"),
+					resource.TestCheckResourceAttr("postgresql_domain.test", "not_null", "false"),
+					resource.TestCheckResourceAttr("postgresql_domain.test", "check.0.name", "positive"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_domain.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"drop_cascade"},
+			},
+		},
+	})
+}
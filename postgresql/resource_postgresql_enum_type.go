@@ -0,0 +1,304 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const enumValuesAttr = "values"
+
+func resourcePostgreSQLEnumType() *schema.Resource {
+	s := typeCommonSchema("enum type")
+	s[enumValuesAttr] = &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MinItems:    1,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The ordered list of values of the enum. Values can only be appended, inserted or renamed",
+	}
+
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLEnumTypeCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLEnumTypeRead),
+		Update: PGResourceFunc(resourcePostgreSQLEnumTypeUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLEnumTypeDelete),
+		Exists: PGResourceExistsFunc(resourcePostgreSQLEnumTypeExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+			if d.Id() == "" || !d.HasChange(enumValuesAttr) {
+				return nil
+			}
+			o, n := d.GetChange(enumValuesAttr)
+			_, _, err := enumValuesQueries("", interfaceSliceToStrings(o.([]interface{})), interfaceSliceToStrings(n.([]interface{})))
+			return err
+		},
+
+		Schema: s,
+	}
+}
+
+func resourcePostgreSQLEnumTypeCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getTypeSchema(d)
+	typeName := d.Get(typeNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	owner := d.Get(typeOwnerAttr).(string)
+	var rolesToGrant []string
+	if owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		values := interfaceSliceToStrings(d.Get(enumValuesAttr).([]interface{}))
+		if _, err := txn.Exec(createEnumTypeQuery(pgSchema, typeName, values)); err != nil {
+			return fmt.Errorf("could not create enum type %s: %w", typeName, err)
+		}
+
+		return setTypeOwner(txn, "TYPE", pgSchema, typeName, owner)
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateTypeID(database, pgSchema, typeName))
+
+	return resourcePostgreSQLEnumTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLEnumTypeExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	return typeExists(db, d, typTypeEnum)
+}
+
+func resourcePostgreSQLEnumTypeRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLEnumTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLEnumTypeReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, pgSchema, typeName, err := getDBTypeName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var typeOID uint32
+	var typeOwner string
+	err = txn.QueryRow(typeQuery, pgSchema, typeName, typTypeEnum).Scan(&typeOID, &typeOwner)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL enum type (%s) not found in database %s", typeName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading enum type: %w", err)
+	}
+
+	var values []string
+	if err := txn.QueryRow(
+		"SELECT COALESCE(array_agg(enumlabel::text ORDER BY enumsortorder), '{}') FROM pg_catalog.pg_enum WHERE enumtypid = $1",
+		typeOID,
+	).Scan(pq.Array(&values)); err != nil {
+		return fmt.Errorf("could not read values of enum type %s: %w", typeName, err)
+	}
+
+	d.Set(typeNameAttr, typeName)
+	d.Set(typeSchemaAttr, pgSchema)
+	d.Set(typeDatabaseAttr, database)
+	d.Set(typeOwnerAttr, typeOwner)
+	d.Set(enumValuesAttr, values)
+	d.SetId(generateTypeID(database, pgSchema, typeName))
+
+	return nil
+}
+
+func resourcePostgreSQLEnumTypeUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getTypeSchema(d)
+
+	var addQueries, renameQueries []string
+	if d.HasChange(enumValuesAttr) {
+		// The type is still under its old name at this point.
+		oldName, _ := d.GetChange(typeNameAttr)
+		o, n := d.GetChange(enumValuesAttr)
+
+		var err error
+		addQueries, renameQueries, err = enumValuesQueries(
+			tableIdentifier(pgSchema, oldName.(string)),
+			interfaceSliceToStrings(o.([]interface{})),
+			interfaceSliceToStrings(n.([]interface{})),
+		)
+		if err != nil {
+			return err
+		}
+		if len(renameQueries) > 0 && !db.featureSupported(featureEnumRenameValue) {
+			return fmt.Errorf(
+				"Renaming enum values is not supported for this Postgres version (%s)",
+				db.version,
+			)
+		}
+	}
+
+	// Before Postgres 12, ADD VALUE cannot be executed inside a transaction block.
+	// Thanks to IF NOT EXISTS, these statements can safely be replayed if the update fails later on.
+	if !db.featureSupported(featureEnumAddValueInTransaction) && len(addQueries) > 0 {
+		conn := db
+		if database != db.client.databaseName {
+			var err error
+			if conn, err = db.client.config.NewClient(database).Connect(); err != nil {
+				return err
+			}
+		}
+		if err := withRolesGrantedOutsideTransaction(conn, typeOwnerRoles(d), func() error {
+			for _, query := range addQueries {
+				if _, err := conn.Exec(query); err != nil {
+					return fmt.Errorf("could not add enum value: %w", err)
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		addQueries = nil
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := withRolesGranted(txn, typeOwnerRoles(d), func() error {
+		for _, query := range append(renameQueries, addQueries...) {
+			if _, err := txn.Exec(query); err != nil {
+				return fmt.Errorf("could not update enum values: %w", err)
+			}
+		}
+
+		if err := setTypeName(txn, d, "TYPE", pgSchema); err != nil {
+			return err
+		}
+
+		if d.HasChange(typeOwnerAttr) {
+			return setTypeOwner(txn, "TYPE", pgSchema, d.Get(typeNameAttr).(string), d.Get(typeOwnerAttr).(string))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourcePostgreSQLEnumTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLEnumTypeDelete(db *DBConnection, d *schema.ResourceData) error {
+	return dropType(db, d, "TYPE")
+}
+
+func createEnumTypeQuery(pgSchema, typeName string, values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = pq.QuoteLiteral(v)
+	}
+
+	return fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)", tableIdentifier(pgSchema, typeName), strings.Join(quoted, ", "))
+}
+
+// enumValuesQueries computes the statements needed to go from the old list of values to the new one.
+// Postgres cannot remove or reorder enum values so the only supported changes are:
+//   - renaming values in place (same number of values)
+//   - adding values anywhere in the list (the old values must keep their relative order)
+func enumValuesQueries(typeIdent string, oldValues, newValues []string) ([]string, []string, error) {
+	oldSet := make(map[string]bool, len(oldValues))
+	for _, v := range oldValues {
+		oldSet[v] = true
+	}
+
+	var adds, renames []string
+
+	switch {
+	case len(newValues) < len(oldValues):
+		return nil, nil, errors.New("values cannot be removed from an enum type, the type has to be recreated")
+
+	case len(newValues) == len(oldValues):
+		for i := range oldValues {
+			if oldValues[i] == newValues[i] {
+				continue
+			}
+			if oldSet[newValues[i]] {
+				return nil, nil, errors.New("values of an enum type cannot be reordered, the type has to be recreated")
+			}
+			renames = append(renames, fmt.Sprintf(
+				"ALTER TYPE %s RENAME VALUE %s TO %s",
+				typeIdent, pq.QuoteLiteral(oldValues[i]), pq.QuoteLiteral(newValues[i]),
+			))
+		}
+
+	case len(oldValues) == 0:
+		// Each value is appended at the end of the empty enum, so they keep their order.
+		for _, v := range newValues {
+			adds = append(adds, fmt.Sprintf("ALTER TYPE %s ADD VALUE IF NOT EXISTS %s", typeIdent, pq.QuoteLiteral(v)))
+		}
+
+	default:
+		// The old values must be a subsequence of the new ones.
+		firstOld := -1
+		j := 0
+		for i, v := range newValues {
+			if j < len(oldValues) && v == oldValues[j] {
+				if firstOld == -1 {
+					firstOld = i
+				}
+				j++
+			} else if oldSet[v] {
+				return nil, nil, errors.New("values of an enum type cannot be reordered, the type has to be recreated")
+			}
+		}
+		if j != len(oldValues) {
+			return nil, nil, errors.New("enum values cannot be added and renamed in the same change")
+		}
+
+		// Values before the first existing one are added backward, each one before its successor.
+		for i := firstOld - 1; i >= 0; i-- {
+			adds = append(adds, fmt.Sprintf(
+				"ALTER TYPE %s ADD VALUE IF NOT EXISTS %s BEFORE %s",
+				typeIdent, pq.QuoteLiteral(newValues[i]), pq.QuoteLiteral(newValues[i+1]),
+			))
+		}
+		for i := firstOld + 1; i < len(newValues); i++ {
+			if oldSet[newValues[i]] {
+				continue
+			}
+			adds = append(adds, fmt.Sprintf(
+				"ALTER TYPE %s ADD VALUE IF NOT EXISTS %s AFTER %s",
+				typeIdent, pq.QuoteLiteral(newValues[i]), pq.QuoteLiteral(newValues[i-1]),
+			))
+		}
+	}
+
+	return adds, renames, nil
+}
@@ -0,0 +1,247 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateEnumTypeQuery(t *testing.T) {
+	assert.Equal(t,
+		`CREATE TYPE "public"."mood" AS ENUM ('sad', 'ok', 'it''s fine')`,
+		createEnumTypeQuery("public", "mood", []string{"sad", "ok", "it's fine"}),
+	)
+}
+
+func TestEnumValuesQueries(t *testing.T) {
+	cases := []struct {
+		name      string
+		oldValues []string
+		newValues []string
+		adds      []string
+		renames   []string
+		expectErr bool
+	}{
+		{
+			name:      "append",
+			oldValues: []string{"a", "b"},
+			newValues: []string{"a", "b", "c", "d"},
+			adds: []string{
+				`ALTER TYPE t ADD VALUE IF NOT EXISTS 'c' AFTER 'b'`,
+				`ALTER TYPE t ADD VALUE IF NOT EXISTS 'd' AFTER 'c'`,
+			},
+		},
+		{
+			name:      "insert at the beginning and in the middle",
+			oldValues: []string{"b", "d"},
+			newValues: []string{"z", "a", "b", "c", "d"},
+			adds: []string{
+				`ALTER TYPE t ADD VALUE IF NOT EXISTS 'a' BEFORE 'b'`,
+				`ALTER TYPE t ADD VALUE IF NOT EXISTS 'z' BEFORE 'a'`,
+				`ALTER TYPE t ADD VALUE IF NOT EXISTS 'c' AFTER 'b'`,
+			},
+		},
+		{
+			name:      "add to an empty enum",
+			oldValues: []string{},
+			newValues: []string{"b", "a"},
+			adds: []string{
+				`ALTER TYPE t ADD VALUE IF NOT EXISTS 'b'`,
+				`ALTER TYPE t ADD VALUE IF NOT EXISTS 'a'`,
+			},
+		},
+		{
+			name:      "rename",
+			oldValues: []string{"a", "b", "c"},
+			newValues: []string{"a", "bb", "c"},
+			renames:   []string{`ALTER TYPE t RENAME VALUE 'b' TO 'bb'`},
+		},
+		{
+			name:      "remove",
+			oldValues: []string{"a", "b"},
+			newValues: []string{"a"},
+			expectErr: true,
+		},
+		{
+			name:      "reorder",
+			oldValues: []string{"a", "b"},
+			newValues: []string{"b", "a"},
+			expectErr: true,
+		},
+		{
+			name:      "reorder and add",
+			oldValues: []string{"a", "b"},
+			newValues: []string{"b", "a", "c"},
+			expectErr: true,
+		},
+		{
+			name:      "rename and add",
+			oldValues: []string{"a", "b"},
+			newValues: []string{"a", "bb", "c"},
+			expectErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			adds, renames, err := enumValuesQueries("t", c.oldValues, c.newValues)
+			if c.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.adds, adds)
+			assert.Equal(t, c.renames, renames)
+		})
+	}
+}
+
+func TestAccPostgresqlEnumType_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+
+	config := `
+resource "postgresql_enum_type" "test" {
+  database = "%s"
+  schema   = "test_schema"
+  name     = "mood"
+  owner    = "%s"
+  values   = [%s]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureEnumRenameValue)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlTypeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, dbName, roleName, `"sad", "happy"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTypeExists("postgresql_enum_type.test"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "owner", roleName),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.#", "2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, dbName, roleName, `"sad", "ok", "happy", "ecstatic"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTypeExists("postgresql_enum_type.test"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.#", "4"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.1", "ok"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.3", "ecstatic"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, dbName, roleName, `"sad", "ok", "very_happy", "ecstatic"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTypeExists("postgresql_enum_type.test"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.2", "very_happy"),
+				),
+			},
+			{
+				Config:      fmt.Sprintf(config, dbName, roleName, `"sad", "ecstatic"`),
+				ExpectError: regexp.MustCompile("values cannot be removed from an enum type"),
+			},
+			{
+				ResourceName:            "postgresql_enum_type.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"drop_cascade"},
+			},
+		},
+	})
+}
+
+// typTypes maps the user-defined types resources to their pg_type.typtype value.
+var typTypes = map[string]string{
+	"postgresql_enum_type":      typTypeEnum,
+	"postgresql_composite_type": typTypeComposite,
+	"postgresql_domain":         typTypeDomain,
+	"postgresql_range_type":     typTypeRange,
+}
+
+func testAccCheckPostgresqlTypeExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkTypeExists(txn, typTypes[rs.Type], rs.Primary.Attributes["schema"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking type %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Type not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPostgresqlTypeDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		typType, ok := typTypes[rs.Type]
+		if !ok {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkTypeExists(txn, typType, rs.Primary.Attributes["schema"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking type %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Type still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func checkTypeExists(txn *sql.Tx, typType, pgSchema, typeName string) (bool, error) {
+	var oid uint32
+	var owner string
+	err := txn.QueryRow(typeQuery, pgSchema, typeName, typType).Scan(&oid, &owner)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about type: %s", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,415 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	eventTriggerNameAttr     = "name"
+	eventTriggerDatabaseAttr = "database"
+	eventTriggerEventAttr    = "event"
+	eventTriggerTagsAttr     = "tags"
+	eventTriggerFunctionAttr = "function"
+	eventTriggerEnabledAttr  = "enabled"
+	eventTriggerOwnerAttr    = "owner"
+)
+
+var eventTriggerEvents = []string{"ddl_command_start", "ddl_command_end", "table_rewrite", "sql_drop", "login"}
+
+func resourcePostgreSQLEventTrigger() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLEventTriggerCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLEventTriggerRead),
+		Update: PGResourceFunc(resourcePostgreSQLEventTriggerUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLEventTriggerDelete),
+		Exists: PGResourceExistsFunc(resourcePostgreSQLEventTriggerExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			eventTriggerNameAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the event trigger",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			eventTriggerDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the event trigger is located. If not specified, the provider default database is used",
+			},
+			eventTriggerEventAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The event that fires the trigger. One of: " + strings.Join(eventTriggerEvents, ", "),
+				ValidateFunc: validation.StringInSlice(eventTriggerEvents, false),
+			},
+			eventTriggerTagsAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "The command tags (e.g.: CREATE TABLE) for which the trigger fires. If not specified, it fires for all commands",
+			},
+			eventTriggerFunctionAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The function called by the event trigger, optionally schema-qualified. It must return event_trigger",
+				ValidateFunc: validation.StringIsNotEmpty,
+
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeTriggerFunction(old) == normalizeTriggerFunction(new)
+				},
+			},
+			eventTriggerEnabledAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ORIGIN",
+				Description:  "The firing mode of the event trigger. One of: ORIGIN, REPLICA, ALWAYS, DISABLED",
+				ValidateFunc: validation.StringInSlice([]string{"ORIGIN", "REPLICA", "ALWAYS", "DISABLED"}, false),
+			},
+			eventTriggerOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ROLE which owns the event trigger (it must be a superuser)",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLEventTriggerCreate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureEventTrigger) {
+		return fmt.Errorf(
+			"postgresql_event_trigger resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+	if d.Get(eventTriggerEventAttr).(string) == "login" && !db.featureSupported(featureEventTriggerLogin) {
+		return fmt.Errorf(
+			"login event triggers are not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	triggerName := d.Get(eventTriggerNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	query := createEventTriggerQuery(d, db.featureSupported(featureTriggerExecuteFunction))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("could not create event trigger %s: %w", triggerName, err)
+	}
+
+	if d.Get(eventTriggerEnabledAttr).(string) != "ORIGIN" {
+		if err := setEventTriggerEnabled(txn, d); err != nil {
+			return err
+		}
+	}
+
+	if err := setEventTriggerOwner(txn, d); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateEventTriggerID(database, triggerName))
+
+	return resourcePostgreSQLEventTriggerReadImpl(db, d)
+}
+
+func resourcePostgreSQLEventTriggerExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	if !db.featureSupported(featureEventTrigger) {
+		return false, fmt.Errorf(
+			"postgresql_event_trigger resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database, triggerName, err := getDBEventTriggerName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var oid uint32
+	err = txn.QueryRow("SELECT oid FROM pg_catalog.pg_event_trigger WHERE evtname = $1", triggerName).Scan(&oid)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading event trigger: %w", err)
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLEventTriggerRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureEventTrigger) {
+		return fmt.Errorf(
+			"postgresql_event_trigger resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return resourcePostgreSQLEventTriggerReadImpl(db, d)
+}
+
+func resourcePostgreSQLEventTriggerReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, triggerName, err := getDBEventTriggerName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var event, function, enabled, owner string
+	var tags []string
+	err = txn.QueryRow(`
+SELECT evtevent, evtfoid::regproc::TEXT, evtenabled::TEXT, pg_catalog.pg_get_userbyid(evtowner),
+       COALESCE(evttags, '{}')
+  FROM pg_catalog.pg_event_trigger
+ WHERE evtname = $1
+`, triggerName).Scan(&event, &function, &enabled, &owner, pq.Array(&tags))
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL event trigger (%s) not found in database %s", triggerName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading event trigger: %w", err)
+	}
+
+	// Keep the function name as configured if it's the same function.
+	if normalizeTriggerFunction(function) == normalizeTriggerFunction(d.Get(eventTriggerFunctionAttr).(string)) {
+		function = d.Get(eventTriggerFunctionAttr).(string)
+	}
+
+	// Tags are stored in upper case by Postgres.
+	configuredTags := map[string]string{}
+	for _, tag := range d.Get(eventTriggerTagsAttr).(*schema.Set).List() {
+		configuredTags[strings.ToUpper(tag.(string))] = tag.(string)
+	}
+	for i, tag := range tags {
+		if configured, ok := configuredTags[tag]; ok {
+			tags[i] = configured
+		}
+	}
+
+	d.Set(eventTriggerNameAttr, triggerName)
+	d.Set(eventTriggerDatabaseAttr, database)
+	d.Set(eventTriggerEventAttr, event)
+	d.Set(eventTriggerTagsAttr, stringSliceToSet(tags))
+	d.Set(eventTriggerFunctionAttr, function)
+	d.Set(eventTriggerEnabledAttr, triggerEnabledCodes[enabled])
+	d.Set(eventTriggerOwnerAttr, owner)
+	d.SetId(generateEventTriggerID(database, triggerName))
+
+	return nil
+}
+
+func resourcePostgreSQLEventTriggerUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureEventTrigger) {
+		return fmt.Errorf(
+			"postgresql_event_trigger resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := setEventTriggerName(txn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange(eventTriggerEnabledAttr) {
+		if err := setEventTriggerEnabled(txn, d); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange(eventTriggerOwnerAttr) {
+		if err := setEventTriggerOwner(txn, d); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourcePostgreSQLEventTriggerReadImpl(db, d)
+}
+
+func resourcePostgreSQLEventTriggerDelete(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureEventTrigger) {
+		return fmt.Errorf(
+			"postgresql_event_trigger resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database, triggerName, err := getDBEventTriggerName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(fmt.Sprintf("DROP EVENT TRIGGER IF EXISTS %s", pq.QuoteIdentifier(triggerName))); err != nil {
+		return fmt.Errorf("could not drop event trigger %s: %w", triggerName, err)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func createEventTriggerQuery(d *schema.ResourceData, executeFunction bool) string {
+	b := bytes.NewBufferString("CREATE EVENT TRIGGER ")
+	fmt.Fprint(b, pq.QuoteIdentifier(d.Get(eventTriggerNameAttr).(string)), " ON ", d.Get(eventTriggerEventAttr).(string))
+
+	if tagsSet := d.Get(eventTriggerTagsAttr).(*schema.Set); tagsSet.Len() > 0 {
+		tags := interfaceSliceToStrings(tagsSet.List())
+		sort.Strings(tags)
+		for i, tag := range tags {
+			tags[i] = pq.QuoteLiteral(tag)
+		}
+		fmt.Fprint(b, " WHEN TAG IN (", strings.Join(tags, ", "), ")")
+	}
+
+	if executeFunction {
+		b.WriteString(" EXECUTE FUNCTION ")
+	} else {
+		b.WriteString(" EXECUTE PROCEDURE ")
+	}
+	fmt.Fprint(b, d.Get(eventTriggerFunctionAttr).(string), "()")
+
+	return b.String()
+}
+
+func setEventTriggerEnabled(txn *sql.Tx, d *schema.ResourceData) error {
+	triggerName := d.Get(eventTriggerNameAttr).(string)
+
+	query := fmt.Sprintf(
+		"ALTER EVENT TRIGGER %s %s",
+		pq.QuoteIdentifier(triggerName), triggerEnabledModes[d.Get(eventTriggerEnabledAttr).(string)],
+	)
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("could not set firing mode of event trigger %s: %w", triggerName, err)
+	}
+
+	return nil
+}
+
+func setEventTriggerOwner(txn *sql.Tx, d *schema.ResourceData) error {
+	owner := d.Get(eventTriggerOwnerAttr).(string)
+	if owner == "" {
+		return nil
+	}
+
+	triggerName := d.Get(eventTriggerNameAttr).(string)
+	query := fmt.Sprintf("ALTER EVENT TRIGGER %s OWNER TO %s", pq.QuoteIdentifier(triggerName), pq.QuoteIdentifier(owner))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating event trigger owner: %w", err)
+	}
+
+	return nil
+}
+
+func setEventTriggerName(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(eventTriggerNameAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(eventTriggerNameAttr)
+	o := oraw.(string)
+	n := nraw.(string)
+	if n == "" {
+		return errors.New("Error setting event trigger name to an empty string")
+	}
+
+	query := fmt.Sprintf("ALTER EVENT TRIGGER %s RENAME TO %s", pq.QuoteIdentifier(o), pq.QuoteIdentifier(n))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating event trigger name: %w", err)
+	}
+
+	d.SetId(generateEventTriggerID(getDatabase(d, ""), n))
+
+	return nil
+}
+
+func generateEventTriggerID(database, triggerName string) string {
+	return strings.Join([]string{database, triggerName}, ".")
+}
+
+// getDBEventTriggerName returns database and event trigger name. If we are importing this resource,
+// they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBEventTriggerName(d *schema.ResourceData, client *Client) (string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	triggerName := d.Get(eventTriggerNameAttr).(string)
+
+	// When importing, we have to parse the ID to find database and event trigger names.
+	if triggerName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 2 {
+			return "", "", fmt.Errorf("event trigger ID %s has not the expected format 'database.event_trigger': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		triggerName = parsed[1]
+	}
+	return database, triggerName, nil
+}
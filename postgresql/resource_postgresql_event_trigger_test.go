@@ -0,0 +1,174 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateEventTriggerQuery(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLEventTrigger().Schema, map[string]interface{}{
+		"name":     "log_ddl",
+		"event":    "ddl_command_end",
+		"tags":     []interface{}{"DROP TABLE", "CREATE TABLE"},
+		"function": "audit.log_ddl",
+	})
+
+	assert.Equal(t,
+		`CREATE EVENT TRIGGER "log_ddl" ON ddl_command_end WHEN TAG IN ('CREATE TABLE', 'DROP TABLE') EXECUTE FUNCTION audit.log_ddl()`,
+		createEventTriggerQuery(d, true),
+	)
+
+	d = schema.TestResourceDataRaw(t, resourcePostgreSQLEventTrigger().Schema, map[string]interface{}{
+		"name":     "log_drop",
+		"event":    "sql_drop",
+		"function": "log_drop",
+	})
+
+	assert.Equal(t,
+		`CREATE EVENT TRIGGER "log_drop" ON sql_drop EXECUTE PROCEDURE log_drop()`,
+		createEventTriggerQuery(d, false),
+	)
+}
+
+func TestAccPostgresqlEventTrigger_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+	testSuperuserPreCheck(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	config := `
+resource "postgresql_function" "event_function" {
+  database = "%s"
+  name     = "test_event_function"
+  returns  = "event_trigger"
+  language = "plpgsql"
+  body     = <<-EOF
+    BEGIN
+      RAISE NOTICE 'command %%', tg_tag;
+    END;
+  EOF
+}
+
+resource "postgresql_event_trigger" "test" {
+  database = "%s"
+  name     = "%s"
+  event    = "ddl_command_end"
+  tags     = ["CREATE TABLE"]
+  function = postgresql_function.event_function.name
+  enabled  = "%s"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureEventTrigger)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlEventTriggerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, dbName, dbName, "test_event_trigger", "ORIGIN"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlEventTriggerExists("postgresql_event_trigger.test"),
+					resource.TestCheckResourceAttr("postgresql_event_trigger.test", "event", "ddl_command_end"),
+					resource.TestCheckResourceAttr("postgresql_event_trigger.test", "tags.#", "1"),
+					resource.TestCheckResourceAttr("postgresql_event_trigger.test", "enabled", "ORIGIN"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, dbName, dbName, "test_event_trigger_renamed", "DISABLED"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlEventTriggerExists("postgresql_event_trigger.test"),
+					resource.TestCheckResourceAttr("postgresql_event_trigger.test", "name", "test_event_trigger_renamed"),
+					resource.TestCheckResourceAttr("postgresql_event_trigger.test", "enabled", "DISABLED"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_event_trigger.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlEventTriggerExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkEventTriggerExists(txn, rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking event trigger %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Event trigger not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPostgresqlEventTriggerDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_event_trigger" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkEventTriggerExists(txn, rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking event trigger %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Event trigger still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func checkEventTriggerExists(txn *sql.Tx, triggerName string) (bool, error) {
+	var oid uint32
+	err := txn.QueryRow("SELECT oid FROM pg_catalog.pg_event_trigger WHERE evtname = $1", triggerName).Scan(&oid)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about event trigger: %s", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,458 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	fdwNameAttr        = "name"
+	fdwDatabaseAttr    = "database"
+	fdwHandlerAttr     = "handler"
+	fdwValidatorAttr   = "validator"
+	fdwOptionsAttr     = "options"
+	fdwOwnerAttr       = "owner"
+	fdwDropCascadeAttr = "drop_cascade"
+)
+
+func resourcePostgreSQLForeignDataWrapper() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLForeignDataWrapperCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLForeignDataWrapperRead),
+		Update: PGResourceFunc(resourcePostgreSQLForeignDataWrapperUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLForeignDataWrapperDelete),
+		Exists: PGResourceExistsFunc(resourcePostgreSQLForeignDataWrapperExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			fdwNameAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the foreign-data wrapper",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			fdwDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the foreign-data wrapper is created. If not specified, the provider default database is used",
+			},
+			fdwHandlerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The handler function of the foreign-data wrapper, optionally schema-qualified. It must return fdw_handler",
+
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeTriggerFunction(old) == normalizeTriggerFunction(new)
+				},
+			},
+			fdwValidatorAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The validator function of the foreign-data wrapper, optionally schema-qualified. It checks the options given to the foreign-data wrapper and its servers, user mappings and foreign tables",
+
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeTriggerFunction(old) == normalizeTriggerFunction(new)
+				},
+			},
+			fdwOptionsAttr: {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "The options of the foreign-data wrapper. Their names and values are dependent on the foreign-data wrapper",
+			},
+			fdwOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ROLE which owns the foreign-data wrapper (it must be a superuser)",
+			},
+			fdwDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Automatically drop objects that depend on the foreign-data wrapper (such as servers), and in turn all objects that depend on those objects. Drop RESTRICT is the default",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLForeignDataWrapperCreate(db *DBConnection, d *schema.ResourceData) error {
+	if _, ok := d.GetOk(fdwHandlerAttr); ok && !db.featureSupported(featureForeignDataWrapperHandler) {
+		return fmt.Errorf(
+			"foreign-data wrapper handlers are not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	fdwName := d.Get(fdwNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(createForeignDataWrapperQuery(d)); err != nil {
+		return fmt.Errorf("could not create foreign-data wrapper %s: %w", fdwName, err)
+	}
+
+	if err := setForeignDataWrapperOwner(txn, d); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateForeignDataWrapperID(database, fdwName))
+
+	return resourcePostgreSQLForeignDataWrapperReadImpl(db, d)
+}
+
+func resourcePostgreSQLForeignDataWrapperExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, fdwName, err := getDBForeignDataWrapperName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var oid uint32
+	err = txn.QueryRow("SELECT oid FROM pg_catalog.pg_foreign_data_wrapper WHERE fdwname = $1", fdwName).Scan(&oid)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading foreign-data wrapper: %w", err)
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLForeignDataWrapperRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLForeignDataWrapperReadImpl(db, d)
+}
+
+func resourcePostgreSQLForeignDataWrapperReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, fdwName, err := getDBForeignDataWrapperName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var handler, validator, owner string
+	var options []string
+	err = txn.QueryRow(`
+SELECT CASE WHEN fdwhandler = 0 THEN '' ELSE fdwhandler::regproc::TEXT END,
+       CASE WHEN fdwvalidator = 0 THEN '' ELSE fdwvalidator::regproc::TEXT END,
+       pg_catalog.pg_get_userbyid(fdwowner),
+       COALESCE(fdwoptions, '{}')
+  FROM pg_catalog.pg_foreign_data_wrapper
+ WHERE fdwname = $1
+`, fdwName).Scan(&handler, &validator, &owner, pq.Array(&options))
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL foreign-data wrapper (%s) not found in database %s", fdwName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading foreign-data wrapper: %w", err)
+	}
+
+	// Keep the function names as configured if they are the same functions.
+	if normalizeTriggerFunction(handler) == normalizeTriggerFunction(d.Get(fdwHandlerAttr).(string)) {
+		handler = d.Get(fdwHandlerAttr).(string)
+	}
+	if normalizeTriggerFunction(validator) == normalizeTriggerFunction(d.Get(fdwValidatorAttr).(string)) {
+		validator = d.Get(fdwValidatorAttr).(string)
+	}
+
+	d.Set(fdwNameAttr, fdwName)
+	d.Set(fdwDatabaseAttr, database)
+	d.Set(fdwHandlerAttr, handler)
+	d.Set(fdwValidatorAttr, validator)
+	d.Set(fdwOwnerAttr, owner)
+	d.Set(fdwOptionsAttr, parseForeignOptions(options))
+	d.SetId(generateForeignDataWrapperID(database, fdwName))
+
+	return nil
+}
+
+func resourcePostgreSQLForeignDataWrapperUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if d.HasChange(fdwHandlerAttr) && !db.featureSupported(featureForeignDataWrapperHandler) {
+		return fmt.Errorf(
+			"foreign-data wrapper handlers are not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := setForeignDataWrapperName(txn, d); err != nil {
+		return err
+	}
+
+	if query := alterForeignDataWrapperQuery(d); query != "" {
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("Error updating foreign-data wrapper: %w", err)
+		}
+	}
+
+	if d.HasChange(fdwOwnerAttr) {
+		if err := setForeignDataWrapperOwner(txn, d); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourcePostgreSQLForeignDataWrapperReadImpl(db, d)
+}
+
+func resourcePostgreSQLForeignDataWrapperDelete(db *DBConnection, d *schema.ResourceData) error {
+	database, fdwName, err := getDBForeignDataWrapperName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	dropMode := "RESTRICT"
+	if d.Get(fdwDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	query := fmt.Sprintf("DROP FOREIGN DATA WRAPPER IF EXISTS %s %s", pq.QuoteIdentifier(fdwName), dropMode)
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("could not drop foreign-data wrapper %s: %w", fdwName, err)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func createForeignDataWrapperQuery(d *schema.ResourceData) string {
+	b := bytes.NewBufferString("CREATE FOREIGN DATA WRAPPER ")
+	fmt.Fprint(b, pq.QuoteIdentifier(d.Get(fdwNameAttr).(string)))
+
+	if v, ok := d.GetOk(fdwHandlerAttr); ok {
+		fmt.Fprint(b, " HANDLER ", v.(string))
+	}
+	if v, ok := d.GetOk(fdwValidatorAttr); ok {
+		fmt.Fprint(b, " VALIDATOR ", v.(string))
+	}
+	if clause := foreignOptionsClause(d.Get(fdwOptionsAttr).(map[string]interface{})); clause != "" {
+		fmt.Fprint(b, " ", clause)
+	}
+
+	return b.String()
+}
+
+// alterForeignDataWrapperQuery returns the ALTER FOREIGN DATA WRAPPER statement needed to apply
+// the changes of handler, validator and options, or an empty string if none of them changed.
+func alterForeignDataWrapperQuery(d *schema.ResourceData) string {
+	var clauses []string
+
+	if d.HasChange(fdwHandlerAttr) {
+		if handler := d.Get(fdwHandlerAttr).(string); handler != "" {
+			clauses = append(clauses, "HANDLER "+handler)
+		} else {
+			clauses = append(clauses, "NO HANDLER")
+		}
+	}
+
+	if d.HasChange(fdwValidatorAttr) {
+		if validator := d.Get(fdwValidatorAttr).(string); validator != "" {
+			clauses = append(clauses, "VALIDATOR "+validator)
+		} else {
+			clauses = append(clauses, "NO VALIDATOR")
+		}
+	}
+
+	if d.HasChange(fdwOptionsAttr) {
+		oldOptions, newOptions := d.GetChange(fdwOptionsAttr)
+		if clause := alterForeignOptionsClause(
+			oldOptions.(map[string]interface{}), newOptions.(map[string]interface{}),
+		); clause != "" {
+			clauses = append(clauses, clause)
+		}
+	}
+
+	if len(clauses) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"ALTER FOREIGN DATA WRAPPER %s %s",
+		pq.QuoteIdentifier(d.Get(fdwNameAttr).(string)), strings.Join(clauses, " "),
+	)
+}
+
+func setForeignDataWrapperOwner(txn *sql.Tx, d *schema.ResourceData) error {
+	owner := d.Get(fdwOwnerAttr).(string)
+	if owner == "" {
+		return nil
+	}
+
+	fdwName := d.Get(fdwNameAttr).(string)
+	query := fmt.Sprintf("ALTER FOREIGN DATA WRAPPER %s OWNER TO %s", pq.QuoteIdentifier(fdwName), pq.QuoteIdentifier(owner))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating foreign-data wrapper owner: %w", err)
+	}
+
+	return nil
+}
+
+func setForeignDataWrapperName(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(fdwNameAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(fdwNameAttr)
+	o := oraw.(string)
+	n := nraw.(string)
+	if n == "" {
+		return errors.New("Error setting foreign-data wrapper name to an empty string")
+	}
+
+	query := fmt.Sprintf("ALTER FOREIGN DATA WRAPPER %s RENAME TO %s", pq.QuoteIdentifier(o), pq.QuoteIdentifier(n))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating foreign-data wrapper name: %w", err)
+	}
+
+	d.SetId(generateForeignDataWrapperID(getDatabase(d, ""), n))
+
+	return nil
+}
+
+// foreignOptionsClause returns the OPTIONS clause of a foreign object (foreign-data wrapper,
+// foreign table or foreign table column), or an empty string if there are no options.
+func foreignOptionsClause(options map[string]interface{}) string {
+	if len(options) == 0 {
+		return ""
+	}
+
+	keys := sortedOptionKeys(options)
+	for i, k := range keys {
+		keys[i] = fmt.Sprintf("%s %s", pq.QuoteIdentifier(k), pq.QuoteLiteral(options[k].(string)))
+	}
+
+	return fmt.Sprintf("OPTIONS (%s)", strings.Join(keys, ", "))
+}
+
+// alterForeignOptionsClause returns the OPTIONS clause of an ALTER statement which
+// adds, sets and drops options to go from the old options to the new ones.
+// It returns an empty string if the options did not change.
+func alterForeignOptionsClause(oldOptions, newOptions map[string]interface{}) string {
+	var changes []string
+
+	for _, k := range sortedOptionKeys(newOptions) {
+		oldValue, ok := oldOptions[k]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("ADD %s %s", pq.QuoteIdentifier(k), pq.QuoteLiteral(newOptions[k].(string))))
+		case oldValue.(string) != newOptions[k].(string):
+			changes = append(changes, fmt.Sprintf("SET %s %s", pq.QuoteIdentifier(k), pq.QuoteLiteral(newOptions[k].(string))))
+		}
+	}
+	for _, k := range sortedOptionKeys(oldOptions) {
+		if _, ok := newOptions[k]; !ok {
+			changes = append(changes, "DROP "+pq.QuoteIdentifier(k))
+		}
+	}
+
+	if len(changes) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("OPTIONS (%s)", strings.Join(changes, ", "))
+}
+
+// parseForeignOptions parses the options of a foreign object (e.g.: {host=localhost,port=5432}).
+func parseForeignOptions(options []string) map[string]interface{} {
+	parsed := make(map[string]interface{}, len(options))
+	for k, v := range parseRelOptions(options) {
+		parsed[k] = v
+	}
+	return parsed
+}
+
+func sortedOptionKeys(options map[string]interface{}) []string {
+	keys := make([]string, 0, len(options))
+	for k := range options {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func generateForeignDataWrapperID(database, fdwName string) string {
+	return strings.Join([]string{database, fdwName}, ".")
+}
+
+// getDBForeignDataWrapperName returns database and foreign-data wrapper name. If we are importing this resource,
+// they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBForeignDataWrapperName(d *schema.ResourceData, client *Client) (string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	fdwName := d.Get(fdwNameAttr).(string)
+
+	// When importing, we have to parse the ID to find database and foreign-data wrapper names.
+	if fdwName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 2 {
+			return "", "", fmt.Errorf("foreign-data wrapper ID %s has not the expected format 'database.fdw': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		fdwName = parsed[1]
+	}
+	return database, fdwName, nil
+}
@@ -0,0 +1,205 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateForeignDataWrapperQuery(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLForeignDataWrapper().Schema, map[string]interface{}{
+		"name":      "my_fdw",
+		"handler":   "postgres_fdw_handler",
+		"validator": "public.postgres_fdw_validator",
+		"options": map[string]interface{}{
+			"debug": "true",
+			"area":  "it's",
+		},
+	})
+
+	assert.Equal(t,
+		`CREATE FOREIGN DATA WRAPPER "my_fdw" HANDLER postgres_fdw_handler VALIDATOR public.postgres_fdw_validator OPTIONS ("area" 'it''s', "debug" 'true')`,
+		createForeignDataWrapperQuery(d),
+	)
+
+	d = schema.TestResourceDataRaw(t, resourcePostgreSQLForeignDataWrapper().Schema, map[string]interface{}{
+		"name": "dummy",
+	})
+
+	assert.Equal(t, `CREATE FOREIGN DATA WRAPPER "dummy"`, createForeignDataWrapperQuery(d))
+}
+
+func TestAlterForeignOptionsClause(t *testing.T) {
+	cases := map[string]struct {
+		old      map[string]interface{}
+		new      map[string]interface{}
+		expected string
+	}{
+		"unchanged": {
+			old:      map[string]interface{}{"host": "foo"},
+			new:      map[string]interface{}{"host": "foo"},
+			expected: "",
+		},
+		"add set and drop": {
+			old:      map[string]interface{}{"host": "foo", "port": "5432"},
+			new:      map[string]interface{}{"host": "bar", "dbname": "db"},
+			expected: `OPTIONS (ADD "dbname" 'db', SET "host" 'bar', DROP "port")`,
+		},
+		"drop all": {
+			old:      map[string]interface{}{"host": "foo"},
+			new:      map[string]interface{}{},
+			expected: `OPTIONS (DROP "host")`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.expected, alterForeignOptionsClause(c.old, c.new))
+		})
+	}
+}
+
+func TestParseForeignOptions(t *testing.T) {
+	assert.Equal(t,
+		map[string]interface{}{"host": "localhost", "password": "a=b"},
+		parseForeignOptions([]string{"host=localhost", "password=a=b"}),
+	)
+}
+
+func TestAccPostgresqlForeignDataWrapper_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+	testSuperuserPreCheck(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	config := `
+resource "postgresql_extension" "postgres_fdw" {
+  database = "%s"
+  name     = "postgres_fdw"
+}
+
+resource "postgresql_foreign_data_wrapper" "test" {
+  database  = "%s"
+  name      = "%s"
+  handler   = "postgres_fdw_handler"
+  validator = %s
+  options   = %s
+
+  depends_on = [postgresql_extension.postgres_fdw]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureForeignDataWrapperHandler)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlForeignDataWrapperDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, dbName, dbName, "test_fdw", `"postgres_fdw_validator"`, `{ debug = "true" }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlForeignDataWrapperExists("postgresql_foreign_data_wrapper.test"),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "handler", "postgres_fdw_handler"),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "validator", "postgres_fdw_validator"),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "options.debug", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, dbName, dbName, "test_fdw_renamed", "null", `{}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlForeignDataWrapperExists("postgresql_foreign_data_wrapper.test"),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "name", "test_fdw_renamed"),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "validator", ""),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "options.%", "0"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_foreign_data_wrapper.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"drop_cascade"},
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlForeignDataWrapperExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkForeignDataWrapperExists(txn, rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking foreign-data wrapper %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Foreign-data wrapper not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPostgresqlForeignDataWrapperDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_foreign_data_wrapper" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkForeignDataWrapperExists(txn, rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking foreign-data wrapper %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Foreign-data wrapper still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func checkForeignDataWrapperExists(txn *sql.Tx, fdwName string) (bool, error) {
+	var oid uint32
+	err := txn.QueryRow("SELECT oid FROM pg_catalog.pg_foreign_data_wrapper WHERE fdwname = $1", fdwName).Scan(&oid)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about foreign-data wrapper: %s", err)
+	}
+
+	return true, nil
+}
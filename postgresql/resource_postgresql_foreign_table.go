@@ -0,0 +1,694 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	foreignTableNameAttr           = "name"
+	foreignTableSchemaAttr         = "schema"
+	foreignTableDatabaseAttr       = "database"
+	foreignTableOwnerAttr          = "owner"
+	foreignTableServerAttr         = "server"
+	foreignTableOptionsAttr        = "options"
+	foreignTableColumnAttr         = "column"
+	foreignTablePartitionOfAttr    = "partition_of"
+	foreignTablePartitionBoundAttr = "partition_bound"
+	foreignTableDropCascadeAttr    = "drop_cascade"
+
+	foreignTableColumnOptionsAttr = "options"
+)
+
+// pgForeignTableColumn is the model of a column in a postgresql_foreign_table resource.
+type pgForeignTableColumn struct {
+	Name     string
+	Type     string
+	Nullable bool
+	Default  string
+	Options  map[string]interface{}
+}
+
+func resourcePostgreSQLForeignTable() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLForeignTableCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLForeignTableRead),
+		Update: PGResourceFunc(resourcePostgreSQLForeignTableUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLForeignTableDelete),
+		Exists: PGResourceExistsFunc(resourcePostgreSQLForeignTableExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			foreignTableNameAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the foreign table",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			foreignTableSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The schema where the foreign table is located. If not specified, the public schema is used",
+			},
+			foreignTableDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the foreign table is located. If not specified, the provider default database is used",
+			},
+			foreignTableOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ROLE which owns the foreign table",
+			},
+			foreignTableServerAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the foreign server to use for the foreign table",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			foreignTableOptionsAttr: {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "The options of the foreign table. Their names and values are dependent on the foreign-data wrapper of the server",
+			},
+			foreignTableColumnAttr: {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Description:   "The columns of the foreign table",
+				ConflictsWith: []string{foreignTablePartitionOfAttr},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tableColumnNameAttr: {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The name of the column",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						tableColumnTypeAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The data type of the column",
+
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return normalizeColumnType(old) == normalizeColumnType(new)
+							},
+						},
+						tableColumnNullableAttr: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "If false, the column is declared NOT NULL",
+						},
+						tableColumnDefaultAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The default expression of the column",
+
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return normalizeTableExpression(old) == normalizeTableExpression(new)
+							},
+						},
+						foreignTableColumnOptionsAttr: {
+							Type: schema.TypeMap,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional:    true,
+							Description: "The options of the column (e.g.: column_name for postgres_fdw)",
+						},
+					},
+				},
+			},
+			foreignTablePartitionOfAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "The partitioned table, optionally schema-qualified, of which the foreign table is a partition. Its columns are inherited from the partitioned table",
+				RequiredWith: []string{foreignTablePartitionBoundAttr},
+
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					pgSchema := getTableSchema(d)
+					return qualifiedRelationName(old, pgSchema) == qualifiedRelationName(new, pgSchema)
+				},
+			},
+			foreignTablePartitionBoundAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "The partition bound specification, e.g.: FOR VALUES IN ('fr') or DEFAULT",
+				RequiredWith: []string{foreignTablePartitionOfAttr},
+
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeTableExpression(old) == normalizeTableExpression(new)
+				},
+			},
+			foreignTableDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Automatically drop objects that depend on the foreign table (such as views), and in turn all objects that depend on those objects. Drop RESTRICT is the default",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLForeignTableCreate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureForeignTable) {
+		return fmt.Errorf(
+			"postgresql_foreign_table resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+	if _, ok := d.GetOk(foreignTablePartitionOfAttr); ok && !db.featureSupported(featureForeignTablePartition) {
+		return fmt.Errorf(
+			"foreign table partitions are not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getTableSchema(d)
+	tableName := d.Get(foreignTableNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	owner := d.Get(foreignTableOwnerAttr).(string)
+	var rolesToGrant []string
+	if owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		if _, err := txn.Exec(createForeignTableQuery(d, pgSchema)); err != nil {
+			return fmt.Errorf("could not create foreign table %s: %w", tableName, err)
+		}
+
+		if owner != "" {
+			return setForeignTableOwner(txn, pgSchema, tableName, owner)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateTableID(database, pgSchema, tableName))
+
+	return resourcePostgreSQLForeignTableReadImpl(db, d)
+}
+
+const foreignTableOIDQuery = `
+SELECT c.oid
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind = 'f'
+`
+
+func resourcePostgreSQLForeignTableExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	if !db.featureSupported(featureForeignTable) {
+		return false, fmt.Errorf(
+			"postgresql_foreign_table resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database, pgSchema, tableName, err := getDBTableName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var oid uint32
+	err = txn.QueryRow(foreignTableOIDQuery, pgSchema, tableName).Scan(&oid)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading foreign table: %w", err)
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLForeignTableRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureForeignTable) {
+		return fmt.Errorf(
+			"postgresql_foreign_table resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return resourcePostgreSQLForeignTableReadImpl(db, d)
+}
+
+func resourcePostgreSQLForeignTableReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, pgSchema, tableName, err := getDBTableName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var tableOID uint32
+	var owner, server string
+	var options []string
+	err = txn.QueryRow(`
+SELECT c.oid, pg_catalog.pg_get_userbyid(c.relowner), s.srvname, COALESCE(ft.ftoptions, '{}')
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+  JOIN pg_catalog.pg_foreign_table ft ON ft.ftrelid = c.oid
+  JOIN pg_catalog.pg_foreign_server s ON s.oid = ft.ftserver
+ WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind = 'f'
+`, pgSchema, tableName).Scan(&tableOID, &owner, &server, pq.Array(&options))
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL foreign table (%s) not found in database %s", tableName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading foreign table: %w", err)
+	}
+
+	columns, err := readForeignTableColumns(txn, tableOID)
+	if err != nil {
+		return err
+	}
+
+	var partitionOf, partitionBound string
+	if db.featureSupported(featureForeignTablePartition) {
+		var parentSchema, parentName string
+		err = txn.QueryRow(`
+SELECT pn.nspname, pc.relname, pg_catalog.pg_get_expr(c.relpartbound, c.oid)
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_inherits i ON i.inhrelid = c.oid
+  JOIN pg_catalog.pg_class pc ON pc.oid = i.inhparent
+  JOIN pg_catalog.pg_namespace pn ON pn.oid = pc.relnamespace
+ WHERE c.oid = $1 AND c.relispartition
+`, tableOID).Scan(&parentSchema, &parentName, &partitionBound)
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return fmt.Errorf("Error reading partition of foreign table: %w", err)
+		default:
+			partitionOf = parentSchema + "." + parentName
+			if configured := d.Get(foreignTablePartitionOfAttr).(string); qualifiedRelationName(configured, pgSchema) == partitionOf {
+				partitionOf = configured
+			}
+			if configured := d.Get(foreignTablePartitionBoundAttr).(string); normalizeTableExpression(configured) == normalizeTableExpression(partitionBound) {
+				partitionBound = configured
+			}
+		}
+	}
+
+	var columnsList []interface{}
+	for _, c := range columns {
+		columnsList = append(columnsList, map[string]interface{}{
+			tableColumnNameAttr:           c.Name,
+			tableColumnTypeAttr:           c.Type,
+			tableColumnNullableAttr:       c.Nullable,
+			tableColumnDefaultAttr:        c.Default,
+			foreignTableColumnOptionsAttr: c.Options,
+		})
+	}
+
+	d.Set(foreignTableNameAttr, tableName)
+	d.Set(foreignTableSchemaAttr, pgSchema)
+	d.Set(foreignTableDatabaseAttr, database)
+	d.Set(foreignTableOwnerAttr, owner)
+	d.Set(foreignTableServerAttr, server)
+	d.Set(foreignTableOptionsAttr, parseForeignOptions(options))
+	d.Set(foreignTableColumnAttr, sortByName(columnsList, d.Get(foreignTableColumnAttr).([]interface{})))
+	d.Set(foreignTablePartitionOfAttr, partitionOf)
+	d.Set(foreignTablePartitionBoundAttr, partitionBound)
+	d.SetId(generateTableID(database, pgSchema, tableName))
+
+	return nil
+}
+
+// readForeignTableColumns reads the columns defined on the foreign table itself,
+// the columns inherited from a partitioned table are not returned.
+func readForeignTableColumns(txn *sql.Tx, tableOID uint32) ([]pgForeignTableColumn, error) {
+	rows, err := txn.Query(`
+SELECT a.attname, pg_catalog.format_type(a.atttypid, a.atttypmod), a.attnotnull,
+       COALESCE(pg_catalog.pg_get_expr(ad.adbin, ad.adrelid), ''), COALESCE(a.attfdwoptions, '{}')
+  FROM pg_catalog.pg_attribute a
+  LEFT JOIN pg_catalog.pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum
+ WHERE a.attrelid = $1 AND a.attnum > 0 AND NOT a.attisdropped AND a.attislocal
+ ORDER BY a.attnum
+`, tableOID)
+	if err != nil {
+		return nil, fmt.Errorf("could not read columns of foreign table: %w", err)
+	}
+	defer rows.Close()
+
+	columns := []pgForeignTableColumn{}
+	for rows.Next() {
+		var column pgForeignTableColumn
+		var notNull bool
+		var options []string
+		if err := rows.Scan(&column.Name, &column.Type, &notNull, &column.Default, pq.Array(&options)); err != nil {
+			return nil, fmt.Errorf("could not scan column of foreign table: %w", err)
+		}
+		column.Nullable = !notNull
+		column.Options = parseForeignOptions(options)
+		columns = append(columns, column)
+	}
+
+	return columns, rows.Err()
+}
+
+func resourcePostgreSQLForeignTableUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureForeignTable) {
+		return fmt.Errorf(
+			"postgresql_foreign_table resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getTableSchema(d)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	owner := d.Get(foreignTableOwnerAttr).(string)
+	if d.HasChange(foreignTableOwnerAttr) {
+		oldOwner, _ := d.GetChange(foreignTableOwnerAttr)
+		owner = oldOwner.(string)
+	}
+	var rolesToGrant []string
+	if owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		if err := setForeignTableName(txn, d, pgSchema); err != nil {
+			return err
+		}
+
+		tableName := d.Get(foreignTableNameAttr).(string)
+
+		if d.HasChange(foreignTableOptionsAttr) {
+			oldOptions, newOptions := d.GetChange(foreignTableOptionsAttr)
+			if clause := alterForeignOptionsClause(
+				oldOptions.(map[string]interface{}), newOptions.(map[string]interface{}),
+			); clause != "" {
+				query := fmt.Sprintf("ALTER FOREIGN TABLE %s %s", tableIdentifier(pgSchema, tableName), clause)
+				if _, err := txn.Exec(query); err != nil {
+					return fmt.Errorf("could not alter options of foreign table %s: %w", tableName, err)
+				}
+			}
+		}
+
+		if d.HasChange(foreignTableColumnAttr) {
+			oldColumns, newColumns := d.GetChange(foreignTableColumnAttr)
+			queries := alterForeignTableColumnsQueries(
+				pgSchema, tableName,
+				foreignTableColumnsFromResourceData(oldColumns.([]interface{})),
+				foreignTableColumnsFromResourceData(newColumns.([]interface{})),
+			)
+			for _, query := range queries {
+				if _, err := txn.Exec(query); err != nil {
+					return fmt.Errorf("could not alter columns of foreign table %s: %w", tableName, err)
+				}
+			}
+		}
+
+		if newOwner := d.Get(foreignTableOwnerAttr).(string); d.HasChange(foreignTableOwnerAttr) && newOwner != "" {
+			return setForeignTableOwner(txn, pgSchema, tableName, newOwner)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourcePostgreSQLForeignTableReadImpl(db, d)
+}
+
+func resourcePostgreSQLForeignTableDelete(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureForeignTable) {
+		return fmt.Errorf(
+			"postgresql_foreign_table resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database, pgSchema, tableName, err := getDBTableName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var rolesToGrant []string
+	if owner := d.Get(foreignTableOwnerAttr).(string); owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		dropMode := "RESTRICT"
+		if d.Get(foreignTableDropCascadeAttr).(bool) {
+			dropMode = "CASCADE"
+		}
+
+		query := fmt.Sprintf("DROP FOREIGN TABLE IF EXISTS %s %s", tableIdentifier(pgSchema, tableName), dropMode)
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not drop foreign table %s: %w", tableName, err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func createForeignTableQuery(d *schema.ResourceData, pgSchema string) string {
+	b := bytes.NewBufferString("CREATE FOREIGN TABLE ")
+	fmt.Fprint(b, tableIdentifier(pgSchema, d.Get(foreignTableNameAttr).(string)))
+
+	if partitionOf, ok := d.GetOk(foreignTablePartitionOfAttr); ok {
+		fmt.Fprint(
+			b, " PARTITION OF ", quoteQualifiedRelationName(partitionOf.(string), pgSchema),
+			" ", d.Get(foreignTablePartitionBoundAttr).(string),
+		)
+	} else {
+		b.WriteString(" (")
+		for i, c := range foreignTableColumnsFromResourceData(d.Get(foreignTableColumnAttr).([]interface{})) {
+			if i > 0 {
+				b.WriteRune(',')
+			}
+			fmt.Fprint(b, "\n    ", foreignTableColumnDefinition(c))
+		}
+		b.WriteString("\n)")
+	}
+
+	fmt.Fprint(b, " SERVER ", pq.QuoteIdentifier(d.Get(foreignTableServerAttr).(string)))
+
+	if clause := foreignOptionsClause(d.Get(foreignTableOptionsAttr).(map[string]interface{})); clause != "" {
+		fmt.Fprint(b, " ", clause)
+	}
+
+	return b.String()
+}
+
+func foreignTableColumnDefinition(c pgForeignTableColumn) string {
+	b := bytes.NewBufferString(pq.QuoteIdentifier(c.Name))
+	fmt.Fprint(b, " ", c.Type)
+
+	if clause := foreignOptionsClause(c.Options); clause != "" {
+		fmt.Fprint(b, " ", clause)
+	}
+	if !c.Nullable {
+		b.WriteString(" NOT NULL")
+	}
+	if c.Default != "" {
+		fmt.Fprint(b, " DEFAULT ", c.Default)
+	}
+
+	return b.String()
+}
+
+// alterForeignTableColumnsQueries returns the ALTER FOREIGN TABLE statements needed to go from the old
+// columns definition to the new one. Columns are matched by name.
+func alterForeignTableColumnsQueries(pgSchema, tableName string, oldColumns, newColumns []pgForeignTableColumn) []string {
+	table := tableIdentifier(pgSchema, tableName)
+	queries := []string{}
+
+	oldByName := make(map[string]pgForeignTableColumn, len(oldColumns))
+	for _, c := range oldColumns {
+		oldByName[c.Name] = c
+	}
+	newByName := make(map[string]pgForeignTableColumn, len(newColumns))
+	for _, c := range newColumns {
+		newByName[c.Name] = c
+	}
+
+	for _, c := range oldColumns {
+		if _, ok := newByName[c.Name]; !ok {
+			queries = append(queries, fmt.Sprintf("ALTER FOREIGN TABLE %s DROP COLUMN %s", table, pq.QuoteIdentifier(c.Name)))
+		}
+	}
+
+	for _, n := range newColumns {
+		o, ok := oldByName[n.Name]
+		if !ok {
+			queries = append(queries, fmt.Sprintf("ALTER FOREIGN TABLE %s ADD COLUMN %s", table, foreignTableColumnDefinition(n)))
+			continue
+		}
+
+		column := fmt.Sprintf("ALTER FOREIGN TABLE %s ALTER COLUMN %s", table, pq.QuoteIdentifier(n.Name))
+
+		if normalizeTableExpression(o.Default) != normalizeTableExpression(n.Default) && n.Default == "" {
+			queries = append(queries, column+" DROP DEFAULT")
+		}
+		if normalizeColumnType(o.Type) != normalizeColumnType(n.Type) {
+			queries = append(queries, fmt.Sprintf("%s TYPE %s", column, n.Type))
+		}
+		if normalizeTableExpression(o.Default) != normalizeTableExpression(n.Default) && n.Default != "" {
+			queries = append(queries, fmt.Sprintf("%s SET DEFAULT %s", column, n.Default))
+		}
+		if o.Nullable != n.Nullable {
+			if n.Nullable {
+				queries = append(queries, column+" DROP NOT NULL")
+			} else {
+				queries = append(queries, column+" SET NOT NULL")
+			}
+		}
+		if !reflect.DeepEqual(o.Options, n.Options) {
+			if clause := alterForeignOptionsClause(o.Options, n.Options); clause != "" {
+				queries = append(queries, fmt.Sprintf("%s %s", column, clause))
+			}
+		}
+	}
+
+	return queries
+}
+
+func foreignTableColumnsFromResourceData(list []interface{}) []pgForeignTableColumn {
+	columns := []pgForeignTableColumn{}
+
+	for _, raw := range list {
+		m := raw.(map[string]interface{})
+		options, _ := m[foreignTableColumnOptionsAttr].(map[string]interface{})
+		if options == nil {
+			options = map[string]interface{}{}
+		}
+		columns = append(columns, pgForeignTableColumn{
+			Name:     m[tableColumnNameAttr].(string),
+			Type:     m[tableColumnTypeAttr].(string),
+			Nullable: m[tableColumnNullableAttr].(bool),
+			Default:  m[tableColumnDefaultAttr].(string),
+			Options:  options,
+		})
+	}
+
+	return columns
+}
+
+func setForeignTableOwner(txn *sql.Tx, pgSchema, tableName, owner string) error {
+	query := fmt.Sprintf(
+		"ALTER FOREIGN TABLE %s OWNER TO %s", tableIdentifier(pgSchema, tableName), pq.QuoteIdentifier(owner),
+	)
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating foreign table owner: %w", err)
+	}
+
+	return nil
+}
+
+func setForeignTableName(txn *sql.Tx, d *schema.ResourceData, pgSchema string) error {
+	if !d.HasChange(foreignTableNameAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(foreignTableNameAttr)
+	o := oraw.(string)
+	n := nraw.(string)
+	if n == "" {
+		return errors.New("Error setting foreign table name to an empty string")
+	}
+
+	query := fmt.Sprintf("ALTER FOREIGN TABLE %s RENAME TO %s", tableIdentifier(pgSchema, o), pq.QuoteIdentifier(n))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating foreign table name: %w", err)
+	}
+
+	d.SetId(generateTableID(getDatabase(d, ""), pgSchema, n))
+
+	return nil
+}
+
+// qualifiedRelationName returns the name of a relation prefixed by the default schema
+// if it's not already schema-qualified, e.g.: measurement => public.measurement
+func qualifiedRelationName(name, defaultSchema string) string {
+	if name == "" || strings.Contains(name, ".") {
+		return name
+	}
+	return defaultSchema + "." + name
+}
+
+// quoteQualifiedRelationName quotes a relation name which may be schema-qualified.
+func quoteQualifiedRelationName(name, defaultSchema string) string {
+	parts := strings.SplitN(qualifiedRelationName(name, defaultSchema), ".", 2)
+	return tableIdentifier(parts[0], parts[1])
+}
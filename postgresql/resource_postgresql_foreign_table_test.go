@@ -0,0 +1,304 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateForeignTableQuery(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLForeignTable().Schema, map[string]interface{}{
+		"name":   "films",
+		"server": "film_server",
+		"options": map[string]interface{}{
+			"table_name": "remote_films",
+		},
+		"column": []interface{}{
+			map[string]interface{}{
+				"name":     "code",
+				"type":     "char(5)",
+				"nullable": false,
+				"options": map[string]interface{}{
+					"column_name": "film_code",
+				},
+			},
+			map[string]interface{}{
+				"name":    "kind",
+				"type":    "varchar(10)",
+				"default": "'drama'",
+			},
+		},
+	})
+
+	assert.Equal(t,
+		`CREATE FOREIGN TABLE "public"."films" (
+    "code" char(5) OPTIONS ("column_name" 'film_code') NOT NULL,
+    "kind" varchar(10) DEFAULT 'drama'
+) SERVER "film_server" OPTIONS ("table_name" 'remote_films')`,
+		createForeignTableQuery(d, "public"),
+	)
+
+	d = schema.TestResourceDataRaw(t, resourcePostgreSQLForeignTable().Schema, map[string]interface{}{
+		"name":            "measurement_fr",
+		"server":          "server_fr",
+		"partition_of":    "measurement",
+		"partition_bound": "FOR VALUES IN ('fr')",
+	})
+
+	assert.Equal(t,
+		`CREATE FOREIGN TABLE "data"."measurement_fr" PARTITION OF "data"."measurement" FOR VALUES IN ('fr') SERVER "server_fr"`,
+		createForeignTableQuery(d, "data"),
+	)
+}
+
+func TestAlterForeignTableColumnsQueries(t *testing.T) {
+	oldColumns := []pgForeignTableColumn{
+		{Name: "id", Type: "integer", Nullable: false, Options: map[string]interface{}{}},
+		{Name: "name", Type: "text", Nullable: true, Options: map[string]interface{}{"column_name": "n"}},
+		{Name: "legacy", Type: "text", Nullable: true, Options: map[string]interface{}{}},
+	}
+	newColumns := []pgForeignTableColumn{
+		{Name: "id", Type: "bigint", Nullable: false, Options: map[string]interface{}{}},
+		{Name: "name", Type: "text", Nullable: false, Options: map[string]interface{}{"column_name": "full_name"}},
+		{Name: "created_at", Type: "timestamptz", Nullable: true, Default: "now()", Options: map[string]interface{}{}},
+	}
+
+	assert.Equal(t,
+		[]string{
+			`ALTER FOREIGN TABLE "public"."t" DROP COLUMN "legacy"`,
+			`ALTER FOREIGN TABLE "public"."t" ALTER COLUMN "id" TYPE bigint`,
+			`ALTER FOREIGN TABLE "public"."t" ALTER COLUMN "name" SET NOT NULL`,
+			`ALTER FOREIGN TABLE "public"."t" ALTER COLUMN "name" OPTIONS (SET "column_name" 'full_name')`,
+			`ALTER FOREIGN TABLE "public"."t" ADD COLUMN "created_at" timestamptz DEFAULT now()`,
+		},
+		alterForeignTableColumnsQueries("public", "t", oldColumns, newColumns),
+	)
+}
+
+func TestQualifiedRelationName(t *testing.T) {
+	assert.Equal(t, "public.measurement", qualifiedRelationName("measurement", "public"))
+	assert.Equal(t, "data.measurement", qualifiedRelationName("data.measurement", "public"))
+	assert.Equal(t, "", qualifiedRelationName("", "public"))
+	assert.Equal(t, `"data"."measurement"`, quoteQualifiedRelationName("data.measurement", "public"))
+}
+
+func TestAccPostgresqlForeignTable_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+	testSuperuserPreCheck(t)
+
+	config := `
+resource "postgresql_extension" "file_fdw" {
+  name = "file_fdw"
+}
+
+resource "postgresql_server" "files" {
+  server_name = "test_foreign_table_server"
+  fdw_name    = "file_fdw"
+
+  depends_on = [postgresql_extension.file_fdw]
+}
+
+resource "postgresql_foreign_table" "test" {
+  name    = "%s"
+  server  = postgresql_server.files.server_name
+  options = {
+    filename = "/dev/null"
+    format   = "%s"
+  }
+
+  column {
+    name     = "id"
+    type     = "integer"
+    nullable = false
+  }
+
+  %s
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureForeignTable)
+			testCheckCompatibleVersion(t, featureServer)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlForeignTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, "test_foreign_table", "csv", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlForeignTableExists("postgresql_foreign_table.test"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "server", "test_foreign_table_server"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "options.format", "csv"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "column.#", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, "test_foreign_table_renamed", "text", `
+  column {
+    name    = "label"
+    type    = "varchar(20)"
+    options = {
+      force_not_null = "true"
+    }
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlForeignTableExists("postgresql_foreign_table.test"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "name", "test_foreign_table_renamed"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "options.format", "text"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "column.#", "2"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "column.1.type", "character varying(20)"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "column.1.options.force_not_null", "true"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_foreign_table.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"drop_cascade"},
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlForeignTable_Partition(t *testing.T) {
+	skipIfNotAcc(t)
+	testSuperuserPreCheck(t)
+
+	config := `
+resource "postgresql_extension" "file_fdw" {
+  name = "file_fdw"
+}
+
+resource "postgresql_server" "files" {
+  server_name = "test_foreign_partition_server"
+  fdw_name    = "file_fdw"
+
+  depends_on = [postgresql_extension.file_fdw]
+}
+
+resource "postgresql_foreign_table" "test" {
+  name            = "test_foreign_partition_fr"
+  server          = postgresql_server.files.server_name
+  partition_of    = "public.test_foreign_partitioned"
+  partition_bound = "FOR VALUES IN ('fr')"
+  options = {
+    filename = "/dev/null"
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureForeignTablePartition)
+			testCheckCompatibleVersion(t, featureServer)
+
+			client := testAccProvider.Meta().(*Client)
+			db, err := client.Connect()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := db.Exec(
+				"CREATE TABLE IF NOT EXISTS test_foreign_partitioned (country text, value integer) PARTITION BY LIST (country)",
+			); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() {
+				if _, err := db.Exec("DROP TABLE IF EXISTS test_foreign_partitioned"); err != nil {
+					t.Errorf("could not drop partitioned table: %v", err)
+				}
+			})
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlForeignTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlForeignTableExists("postgresql_foreign_table.test"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "partition_of", "public.test_foreign_partitioned"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "partition_bound", "FOR VALUES IN ('fr')"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "column.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlForeignTableExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkForeignTableExists(txn, rs.Primary.Attributes["schema"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking foreign table %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Foreign table not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPostgresqlForeignTableDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_foreign_table" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkForeignTableExists(txn, rs.Primary.Attributes["schema"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking foreign table %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Foreign table still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func checkForeignTableExists(txn *sql.Tx, pgSchema, tableName string) (bool, error) {
+	var oid uint32
+	err := txn.QueryRow(foreignTableOIDQuery, pgSchema, tableName).Scan(&oid)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about foreign table: %s", err)
+	}
+
+	return true, nil
+}
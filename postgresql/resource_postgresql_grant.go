@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -24,14 +26,43 @@ var allowedObjectTypes = []string{
 	"foreign_data_wrapper",
 	"foreign_server",
 	"column",
+	"type",
+	"domain",
+	"language",
+	"tablespace",
+	"large_object",
+	"parameter",
+}
+
+// objectTypesWithoutSchema are the object types which don't belong to a schema.
+var objectTypesWithoutSchema = []string{
+	"database",
+	"foreign_data_wrapper",
+	"foreign_server",
+	"language",
+	"tablespace",
+	"large_object",
+	"parameter",
+}
+
+// objectTypesRequiringObjects are the object types which can only be granted on explicit objects,
+// Postgres having no ON ALL ... IN SCHEMA form for them.
+var objectTypesRequiringObjects = []string{
+	"type",
+	"domain",
+	"language",
+	"tablespace",
+	"large_object",
+	"parameter",
 }
 
 var objectTypes = map[string]string{
-	"table":    "r",
-	"sequence": "S",
-	"function": "f",
-	"type":     "T",
-	"schema":   "n",
+	"table":        "r",
+	"sequence":     "S",
+	"function":     "f",
+	"type":         "T",
+	"schema":       "n",
+	"large_object": "L",
 }
 
 func resourcePostgreSQLGrant() *schema.Resource {
@@ -40,6 +71,9 @@ func resourcePostgreSQLGrant() *schema.Resource {
 		Update: PGResourceFunc(resourcePostgreSQLGrantUpdate),
 		Read:   PGResourceFunc(resourcePostgreSQLGrantRead),
 		Delete: PGResourceFunc(resourcePostgreSQLGrantDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: PGResourceImportFunc(resourcePostgreSQLGrantImport),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"role": {
@@ -60,6 +94,27 @@ func resourcePostgreSQLGrant() *schema.Resource {
 				// ForceNew:    true,
 				Description: "The database schema to grant privileges on for this role",
 			},
+			"schemas": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				ConflictsWith: []string{"schema", "schema_like_any_patterns", "schema_regex_pattern"},
+				Description:   "The database schemas to grant privileges on for this role",
+			},
+			"schema_like_any_patterns": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"schema", "schemas"},
+				Description:   "Grant privileges on the database schemas matching any of these patterns with the PostgreSQL LIKE ANY operator",
+			},
+			"schema_regex_pattern": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"schema", "schemas"},
+				Description:   "Grant privileges on the database schemas matching this pattern with the PostgreSQL ~ (regular expression match) operator",
+			},
 			"object_type": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -137,9 +192,13 @@ func resourcePostgreSQLGrantCreateOrUpdate(db *DBConnection, d *schema.ResourceD
 
 	// Validate parameters.
 	objectType := d.Get("object_type").(string)
-	if d.Get("schema").(string) == "" && !sliceContainsStr([]string{"database", "foreign_data_wrapper", "foreign_server"}, objectType) {
+	multiSchema := isMultiSchemaGrant(d.Get)
+	if d.Get("schema").(string) == "" && !multiSchema && !sliceContainsStr(objectTypesWithoutSchema, objectType) {
 		return fmt.Errorf("parameter 'schema' is mandatory for postgresql_grant resource")
 	}
+	if multiSchema && (sliceContainsStr(objectTypesWithoutSchema, objectType) || objectType == "column") {
+		return fmt.Errorf("cannot specify `schemas` or schema patterns when `object_type` is `%s`", objectType)
+	}
 	if d.Get("objects").(*schema.Set).Len() > 0 && (objectType == "database" || objectType == "schema") {
 		return fmt.Errorf("cannot specify `objects` when `object_type` is `database` or `schema`")
 	}
@@ -158,6 +217,16 @@ func resourcePostgreSQLGrantCreateOrUpdate(db *DBConnection, d *schema.ResourceD
 	if d.Get("objects").(*schema.Set).Len() != 1 && (objectType == "foreign_data_wrapper" || objectType == "foreign_server") {
 		return fmt.Errorf("one element must be specified in `objects` when `object_type` is `foreign_data_wrapper` or `foreign_server`")
 	}
+	if d.Get("objects").(*schema.Set).Len() == 0 && sliceContainsStr(objectTypesRequiringObjects, objectType) {
+		return fmt.Errorf("at least one element must be specified in `objects` when `object_type` is `%s`", objectType)
+	}
+	if objectType == "large_object" {
+		for _, object := range d.Get("objects").(*schema.Set).List() {
+			if _, err := strconv.ParseUint(object.(string), 10, 32); err != nil {
+				return fmt.Errorf("invalid large object OID %q in `objects`", object.(string))
+			}
+		}
+	}
 	if err := validatePrivileges(d); err != nil {
 		return err
 	}
@@ -280,8 +349,7 @@ WHERE grantee = $2
 	return nil
 }
 
-func readSchemaRolePriviges(txn *sql.Tx, d *schema.ResourceData, roleOID uint32) error {
-	dbName := d.Get("schema").(string)
+func readSchemaRolePriviges(txn *sql.Tx, d *schema.ResourceData, roleOID uint32, pgSchema string) error {
 	query := `
 SELECT array_agg(privilege_type)
 FROM (
@@ -291,8 +359,8 @@ WHERE grantee = $2
 `
 
 	var privileges pq.ByteaArray
-	if err := txn.QueryRow(query, dbName, roleOID).Scan(&privileges); err != nil {
-		return fmt.Errorf("could not read privileges for schema %s: %w", dbName, err)
+	if err := txn.QueryRow(query, pgSchema, roleOID).Scan(&privileges); err != nil {
+		return fmt.Errorf("could not read privileges for schema %s: %w", pgSchema, err)
 	}
 
 	d.Set("privileges", pgArrayToSet(privileges))
@@ -418,24 +486,57 @@ ORDER BY col_privs.attname
 }
 
 func readRolePrivileges(txn *sql.Tx, d *schema.ResourceData) error {
-	role := d.Get("role").(string)
-	objectType := d.Get("object_type").(string)
-	objects := d.Get("objects").(*schema.Set)
+	roleOID, err := getRoleOID(txn, d.Get("role").(string))
+	if err != nil {
+		return err
+	}
 
-	roleOID, err := getRoleOID(txn, role)
+	schemas, err := getGrantSchemas(txn, d.Get)
 	if err != nil {
 		return err
 	}
 
+	// The declared schemas which don't exist anymore are reported as a drift.
+	if missing := getMissingGrantSchemas(d.Get, schemas); len(missing) > 0 {
+		log.Printf("[WARN] Schemas %v of the grant for role %s do not exist", missing, d.Get("role"))
+		remaining := d.Get("schemas").(*schema.Set)
+		for _, pgSchema := range missing {
+			remaining.Remove(pgSchema)
+		}
+		if err := d.Set("schemas", remaining); err != nil {
+			return err
+		}
+	}
+
+	expected := d.Get("privileges").(*schema.Set)
+	for _, pgSchema := range schemas {
+		if err := readRolePrivilegesInSchema(txn, d, roleOID, pgSchema); err != nil {
+			return err
+		}
+		// Stop at the first schema which doesn't have the expected privileges so its drift is reported.
+		if !d.Get("privileges").(*schema.Set).Equal(expected) {
+			log.Printf("[DEBUG] Schema %s has not the expected privileges for role %s", pgSchema, d.Get("role"))
+			break
+		}
+	}
+
+	return nil
+}
+
+func readRolePrivilegesInSchema(txn *sql.Tx, d *schema.ResourceData, roleOID uint32, pgSchema string) error {
+	objectType := d.Get("object_type").(string)
+	objects := d.Get("objects").(*schema.Set)
+
 	var query string
 	var rows *sql.Rows
+	var err error
 
 	switch objectType {
 	case "database":
 		return readDatabaseRolePriviges(txn, d, roleOID)
 
 	case "schema":
-		return readSchemaRolePriviges(txn, d, roleOID)
+		return readSchemaRolePriviges(txn, d, roleOID, pgSchema)
 
 	case "foreign_data_wrapper":
 		return readForeignDataWrapperRolePrivileges(txn, d, roleOID)
@@ -460,12 +561,82 @@ USING (proname, pronamespace)
 GROUP BY pg_proc.proname
 `
 		rows, err = txn.Query(
-			query, roleOID, d.Get("schema"),
+			query, roleOID, pgSchema,
 		)
 
 	case "column":
 		return readColumnRolePrivileges(txn, d)
 
+	case "type", "domain":
+		// typacl is NULL until the privileges are changed, in which case the default privileges apply.
+		query = `
+SELECT t.typname, array_remove(array_agg(a.privilege_type), NULL)
+FROM pg_catalog.pg_type t
+JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+LEFT JOIN LATERAL (
+    SELECT * FROM pg_catalog.aclexplode(COALESCE(t.typacl, pg_catalog.acldefault('T', t.typowner)))
+    WHERE grantee = $1
+) a ON true
+WHERE n.nspname = $2 AND t.typname = ANY($3) AND (t.typtype = 'd') = $4
+GROUP BY t.typname
+`
+		rows, err = txn.Query(
+			query, roleOID, pgSchema, pq.Array(interfaceSliceToStrings(objects.List())), objectType == "domain",
+		)
+
+	case "language":
+		query = `
+SELECT l.lanname, array_remove(array_agg(a.privilege_type), NULL)
+FROM pg_catalog.pg_language l
+LEFT JOIN LATERAL (
+    SELECT * FROM pg_catalog.aclexplode(COALESCE(l.lanacl, pg_catalog.acldefault('l', l.lanowner)))
+    WHERE grantee = $1
+) a ON true
+WHERE l.lanname = ANY($2)
+GROUP BY l.lanname
+`
+		rows, err = txn.Query(query, roleOID, pq.Array(interfaceSliceToStrings(objects.List())))
+
+	case "tablespace":
+		query = `
+SELECT s.spcname, array_remove(array_agg(a.privilege_type), NULL)
+FROM pg_catalog.pg_tablespace s
+LEFT JOIN LATERAL (
+    SELECT * FROM pg_catalog.aclexplode(COALESCE(s.spcacl, pg_catalog.acldefault('t', s.spcowner)))
+    WHERE grantee = $1
+) a ON true
+WHERE s.spcname = ANY($2)
+GROUP BY s.spcname
+`
+		rows, err = txn.Query(query, roleOID, pq.Array(interfaceSliceToStrings(objects.List())))
+
+	case "large_object":
+		query = `
+SELECT m.oid::text, array_remove(array_agg(a.privilege_type), NULL)
+FROM pg_catalog.pg_largeobject_metadata m
+LEFT JOIN LATERAL (
+    SELECT * FROM pg_catalog.aclexplode(COALESCE(m.lomacl, pg_catalog.acldefault('L', m.lomowner)))
+    WHERE grantee = $1
+) a ON true
+WHERE m.oid::text = ANY($2)
+GROUP BY m.oid
+`
+		rows, err = txn.Query(query, roleOID, pq.Array(interfaceSliceToStrings(objects.List())))
+
+	case "parameter":
+		// pg_parameter_acl only has a row for the parameters on which privileges have been granted.
+		query = `
+SELECT o.name, array_remove(array_agg(a.privilege_type), NULL)
+FROM unnest($2::text[]) AS o(name)
+LEFT JOIN pg_catalog.pg_parameter_acl p ON p.parname = lower(o.name)
+LEFT JOIN LATERAL (
+    SELECT * FROM pg_catalog.aclexplode(COALESCE(p.paracl, '{}'::aclitem[]))
+    WHERE grantee = $1
+) a ON true
+GROUP BY o.name
+`
+		rows, err = txn.Query(query, roleOID, pq.Array(interfaceSliceToStrings(objects.List())))
+
 	default:
 		query = `
 SELECT pg_class.relname, array_remove(array_agg(privilege_type), NULL)
@@ -482,7 +653,7 @@ WHERE nspname = $2 AND relkind = $3
 GROUP BY pg_class.relname
 `
 		rows, err = txn.Query(
-			query, roleOID, d.Get("schema"), objectTypes[objectType],
+			query, roleOID, pgSchema, objectTypes[objectType],
 		)
 	}
 
@@ -494,6 +665,7 @@ GROUP BY pg_class.relname
 	if err != nil {
 		return err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var objName string
@@ -525,6 +697,11 @@ GROUP BY pg_class.relname
 }
 
 func createGrantQuery(d *schema.ResourceData, privileges []string) string {
+	return createSchemaGrantQuery(d, d.Get("schema").(string), privileges)
+}
+
+// createSchemaGrantQuery creates the grant query for the objects of the given schema.
+func createSchemaGrantQuery(d *schema.ResourceData, pgSchema string, privileges []string) string {
 	var query string
 
 	switch strings.ToUpper(d.Get("object_type").(string)) {
@@ -539,7 +716,7 @@ func createGrantQuery(d *schema.ResourceData, privileges []string) string {
 		query = fmt.Sprintf(
 			"GRANT %s ON SCHEMA %s TO %s",
 			strings.Join(privileges, ","),
-			pq.QuoteIdentifier(d.Get("schema").(string)),
+			pq.QuoteIdentifier(pgSchema),
 			pq.QuoteIdentifier(d.Get("role").(string)),
 		)
 	case "FOREIGN_DATA_WRAPPER":
@@ -564,7 +741,37 @@ func createGrantQuery(d *schema.ResourceData, privileges []string) string {
 			"GRANT %s (%s) ON TABLE %s TO %s",
 			strings.Join(privileges, ","),
 			setToPgIdentListWithoutSchema(d.Get("columns").(*schema.Set)),
-			setToPgIdentList(d.Get("schema").(string), objects),
+			setToPgIdentList(pgSchema, objects),
+			pq.QuoteIdentifier(d.Get("role").(string)),
+		)
+	case "TYPE", "DOMAIN":
+		query = fmt.Sprintf(
+			"GRANT %s ON %s %s TO %s",
+			strings.Join(privileges, ","),
+			strings.ToUpper(d.Get("object_type").(string)),
+			setToPgIdentList(pgSchema, d.Get("objects").(*schema.Set)),
+			pq.QuoteIdentifier(d.Get("role").(string)),
+		)
+	case "LANGUAGE", "TABLESPACE":
+		query = fmt.Sprintf(
+			"GRANT %s ON %s %s TO %s",
+			strings.Join(privileges, ","),
+			strings.ToUpper(d.Get("object_type").(string)),
+			setToPgIdentListWithoutSchema(d.Get("objects").(*schema.Set)),
+			pq.QuoteIdentifier(d.Get("role").(string)),
+		)
+	case "LARGE_OBJECT":
+		query = fmt.Sprintf(
+			"GRANT %s ON LARGE OBJECT %s TO %s",
+			strings.Join(privileges, ","),
+			setToPgIdentSimpleList(d.Get("objects").(*schema.Set)),
+			pq.QuoteIdentifier(d.Get("role").(string)),
+		)
+	case "PARAMETER":
+		query = fmt.Sprintf(
+			"GRANT %s ON PARAMETER %s TO %s",
+			strings.Join(privileges, ","),
+			setToPgParameterList(d.Get("objects").(*schema.Set)),
 			pq.QuoteIdentifier(d.Get("role").(string)),
 		)
 	case "TABLE", "SEQUENCE", "FUNCTION", "PROCEDURE", "ROUTINE":
@@ -574,7 +781,7 @@ func createGrantQuery(d *schema.ResourceData, privileges []string) string {
 				"GRANT %s ON %s %s TO %s",
 				strings.Join(privileges, ","),
 				strings.ToUpper(d.Get("object_type").(string)),
-				setToPgIdentList(d.Get("schema").(string), objects),
+				setToPgIdentList(pgSchema, objects),
 				pq.QuoteIdentifier(d.Get("role").(string)),
 			)
 		} else {
@@ -582,7 +789,7 @@ func createGrantQuery(d *schema.ResourceData, privileges []string) string {
 				"GRANT %s ON ALL %sS IN SCHEMA %s TO %s",
 				strings.Join(privileges, ","),
 				strings.ToUpper(d.Get("object_type").(string)),
-				pq.QuoteIdentifier(d.Get("schema").(string)),
+				pq.QuoteIdentifier(pgSchema),
 				pq.QuoteIdentifier(d.Get("role").(string)),
 			)
 		}
@@ -643,6 +850,32 @@ func createRevokeQuery(getter ResourceSchemGetter) string {
 				pq.QuoteIdentifier(getter("role").(string)),
 			)
 		}
+	case "TYPE", "DOMAIN":
+		query = fmt.Sprintf(
+			"REVOKE ALL PRIVILEGES ON %s %s FROM %s",
+			strings.ToUpper(getter("object_type").(string)),
+			setToPgIdentList(getter("schema").(string), getter("objects").(*schema.Set)),
+			pq.QuoteIdentifier(getter("role").(string)),
+		)
+	case "LANGUAGE", "TABLESPACE":
+		query = fmt.Sprintf(
+			"REVOKE ALL PRIVILEGES ON %s %s FROM %s",
+			strings.ToUpper(getter("object_type").(string)),
+			setToPgIdentListWithoutSchema(getter("objects").(*schema.Set)),
+			pq.QuoteIdentifier(getter("role").(string)),
+		)
+	case "LARGE_OBJECT":
+		query = fmt.Sprintf(
+			"REVOKE ALL PRIVILEGES ON LARGE OBJECT %s FROM %s",
+			setToPgIdentSimpleList(getter("objects").(*schema.Set)),
+			pq.QuoteIdentifier(getter("role").(string)),
+		)
+	case "PARAMETER":
+		query = fmt.Sprintf(
+			"REVOKE ALL PRIVILEGES ON PARAMETER %s FROM %s",
+			setToPgParameterList(getter("objects").(*schema.Set)),
+			pq.QuoteIdentifier(getter("role").(string)),
+		)
 	case "TABLE", "SEQUENCE", "FUNCTION", "PROCEDURE", "ROUTINE":
 		objects := getter("objects").(*schema.Set)
 		privileges := getter("privileges").(*schema.Set)
@@ -687,11 +920,24 @@ func grantRolePrivileges(txn *sql.Tx, d *schema.ResourceData) error {
 		log.Printf("[DEBUG] no privileges to grant for role %s in database: %s,", d.Get("role").(string), d.Get("database"))
 		return nil
 	}
-	query := createGrantQuery(d, privileges)
-	log.Printf("[INFO] executing %s", query)
 
-	_, err := txn.Exec(query)
-	return err
+	schemas, err := getGrantSchemas(txn, d.Get)
+	if err != nil {
+		return err
+	}
+	if missing := getMissingGrantSchemas(d.Get, schemas); len(missing) > 0 {
+		return fmt.Errorf("schemas %s do not exist in database %s", strings.Join(missing, ", "), d.Get("database"))
+	}
+
+	for _, pgSchema := range schemas {
+		query := createSchemaGrantQuery(d, pgSchema, privileges)
+		log.Printf("[INFO] executing %s", query)
+
+		if _, err := txn.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func revokeRolePrivileges(txn *sql.Tx, d *schema.ResourceData, usePrevious bool) error {
@@ -706,18 +952,106 @@ func revokeRolePrivileges(txn *sql.Tx, d *schema.ResourceData, usePrevious bool)
 			return d.Get(name)
 		}
 	}
-	query := createRevokeQuery(getter)
-	if len(query) == 0 {
-		// Query is empty, don't run anything
-		return nil
+
+	schemas, err := getGrantSchemas(txn, getter)
+	if err != nil {
+		return err
 	}
-	log.Printf("[INFO] executing %s", query)
-	if _, err := txn.Exec(query); err != nil {
-		return fmt.Errorf("could not execute revoke query: %w", err)
+
+	for _, pgSchema := range schemas {
+		query := createRevokeQuery(withGrantSchema(getter, pgSchema))
+		if len(query) == 0 {
+			// Query is empty, don't run anything
+			continue
+		}
+		log.Printf("[INFO] executing %s", query)
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not execute revoke query: %w", err)
+		}
 	}
 	return nil
 }
 
+// isMultiSchemaGrant returns true if the grant is applied to a set of schemas
+// or to the schemas matching a pattern instead of a single schema.
+func isMultiSchemaGrant(getter ResourceSchemGetter) bool {
+	return getter("schemas").(*schema.Set).Len() > 0 ||
+		len(getter("schema_like_any_patterns").([]interface{})) > 0 ||
+		getter("schema_regex_pattern").(string) != ""
+}
+
+// withGrantSchema returns a getter returning the given schema instead of the schema attribute,
+// so the queries can be created for each of the schemas of the grant.
+func withGrantSchema(getter ResourceSchemGetter, pgSchema string) ResourceSchemGetter {
+	return func(name string) interface{} {
+		if name == "schema" {
+			return pgSchema
+		}
+		return getter(name)
+	}
+}
+
+// getGrantSchemas returns the existing schemas declared in `schemas` or matching the schema patterns,
+// or the `schema` attribute when the grant is not applied to several schemas.
+func getGrantSchemas(txn *sql.Tx, getter ResourceSchemGetter) ([]string, error) {
+	if !isMultiSchemaGrant(getter) {
+		return []string{getter("schema").(string)}, nil
+	}
+
+	query := `
+SELECT nspname
+FROM pg_catalog.pg_namespace
+WHERE nspname NOT LIKE 'pg\_%' AND nspname <> 'information_schema'
+`
+	filters := []string{}
+	args := []interface{}{}
+	if schemas := getter("schemas").(*schema.Set); schemas.Len() > 0 {
+		args = append(args, pq.Array(schemas.List()))
+		filters = append(filters, fmt.Sprintf("nspname = ANY($%d)", len(args)))
+	}
+	if patterns := getter("schema_like_any_patterns").([]interface{}); len(patterns) > 0 {
+		args = append(args, pq.Array(patterns))
+		filters = append(filters, fmt.Sprintf("nspname LIKE ANY($%d)", len(args)))
+	}
+	if pattern := getter("schema_regex_pattern").(string); pattern != "" {
+		args = append(args, pattern)
+		filters = append(filters, fmt.Sprintf("nspname ~ $%d", len(args)))
+	}
+	query = finalizeQueryWithFilters(query, queryConcatKeywordAnd, filters) + " ORDER BY nspname"
+
+	rows, err := txn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not list schemas: %w", err)
+	}
+	defer rows.Close()
+
+	schemas := []string{}
+	for rows.Next() {
+		var pgSchema string
+		if err := rows.Scan(&pgSchema); err != nil {
+			return nil, fmt.Errorf("could not scan schema name: %w", err)
+		}
+		schemas = append(schemas, pgSchema)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not list schemas: %w", err)
+	}
+
+	return schemas, nil
+}
+
+// getMissingGrantSchemas returns the schemas declared in `schemas` which are not in the existing schemas.
+func getMissingGrantSchemas(getter ResourceSchemGetter, schemas []string) []string {
+	missing := []string{}
+	for _, pgSchema := range getter("schemas").(*schema.Set).List() {
+		if !sliceContainsStr(schemas, pgSchema.(string)) {
+			missing = append(missing, pgSchema.(string))
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
 func checkRoleDBSchemaExists(client *Client, d *schema.ResourceData) (bool, error) {
 	txn, err := startTransaction(client, "")
 	if err != nil {
@@ -751,7 +1085,7 @@ func checkRoleDBSchemaExists(client *Client, d *schema.ResourceData) (bool, erro
 
 	pgSchema := d.Get("schema").(string)
 
-	if !sliceContainsStr([]string{"database", "foreign_data_wrapper", "foreign_server"}, d.Get("object_type").(string)) && pgSchema != "" {
+	if !sliceContainsStr(objectTypesWithoutSchema, d.Get("object_type").(string)) && pgSchema != "" {
 		// Connect on this database to check if schema exists
 		dbTxn, err := startTransaction(client, database)
 		if err != nil {
@@ -777,8 +1111,8 @@ func generateGrantID(d *schema.ResourceData) string {
 	parts := []string{d.Get("role").(string), d.Get("database").(string)}
 
 	objectType := d.Get("object_type").(string)
-	if objectType != "database" && objectType != "foreign_data_wrapper" && objectType != "foreign_server" {
-		parts = append(parts, d.Get("schema").(string))
+	if !sliceContainsStr(objectTypesWithoutSchema, objectType) {
+		parts = append(parts, generateGrantSchemaID(d))
 	}
 	parts = append(parts, objectType)
 
@@ -793,20 +1127,303 @@ func generateGrantID(d *schema.ResourceData) string {
 	return strings.Join(parts, "_")
 }
 
+// generateGrantSchemaID returns the schema of the grant, or its schemas and schema patterns separated by commas.
+func generateGrantSchemaID(d *schema.ResourceData) string {
+	if !isMultiSchemaGrant(d.Get) {
+		return d.Get("schema").(string)
+	}
+
+	schemas := interfaceSliceToStrings(d.Get("schemas").(*schema.Set).List())
+	sort.Strings(schemas)
+	schemas = append(schemas, interfaceSliceToStrings(d.Get("schema_like_any_patterns").([]interface{}))...)
+	if regexPattern := d.Get("schema_regex_pattern").(string); regexPattern != "" {
+		schemas = append(schemas, regexPattern)
+	}
+
+	return strings.Join(schemas, ",")
+}
+
+const grantImportIDFormat = "role/database/schema/object_type[/objects[/columns]]"
+
+// resourcePostgreSQLGrantImport parses an import ID of the form role/database/schema/object_type[/objects[/columns]],
+// objects and columns being comma separated lists. The privileges are then read from the catalogs.
+func resourcePostgreSQLGrantImport(db *DBConnection, d *schema.ResourceData) error {
+	if err := setGrantFromImportID(d); err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, d.Get("database").(string))
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	// The column privileges are read for the privilege set in the state,
+	// so it needs to be found first.
+	if d.Get("object_type").(string) == "column" {
+		privilege, err := getColumnsPrivilege(txn, d)
+		if err != nil {
+			return err
+		}
+		d.Set("privileges", stringSliceToSet([]string{privilege}))
+	}
+
+	// with_grant_option forces a new resource so it needs to be read when importing.
+	withGrantOption, err := getGrantOption(txn, d)
+	if err != nil {
+		return err
+	}
+	d.Set("with_grant_option", withGrantOption)
+
+	return nil
+}
+
+func setGrantFromImportID(d *schema.ResourceData) error {
+	parts, err := splitImportID(d.Id(), 4, 6, grantImportIDFormat)
+	if err != nil {
+		return err
+	}
+	role, database, pgSchema, objectType, objects, columns := parts[0], parts[1], parts[2], parts[3], parts[4], parts[5]
+
+	if !sliceContainsStr(allowedObjectTypes, objectType) {
+		return fmt.Errorf("invalid object type %q in import ID %q (one of: %s)", objectType, d.Id(), strings.Join(allowedObjectTypes, ", "))
+	}
+	if role == "" || database == "" {
+		return fmt.Errorf("invalid import ID %q, expected format: %s", d.Id(), grantImportIDFormat)
+	}
+	if (objectType == "foreign_data_wrapper" || objectType == "foreign_server") && len(splitImportList(objects)) != 1 {
+		return fmt.Errorf("one object must be specified in import ID %q when object_type is %s", d.Id(), objectType)
+	}
+	if sliceContainsStr(objectTypesRequiringObjects, objectType) && objects == "" {
+		return fmt.Errorf("objects must be specified in import ID %q when object_type is %s", d.Id(), objectType)
+	}
+	if objectType == "column" && columns == "" {
+		return fmt.Errorf("columns must be specified in import ID %q when object_type is column", d.Id())
+	}
+
+	d.Set("role", role)
+	d.Set("database", database)
+	d.Set("schema", pgSchema)
+	d.Set("object_type", objectType)
+	d.Set("objects", stringSliceToSet(splitImportList(objects)))
+	d.Set("columns", stringSliceToSet(splitImportList(columns)))
+	d.SetId(generateGrantID(d))
+
+	return nil
+}
+
+// getGrantOption returns whether the privileges of the imported objects are granted to the role with the grant option.
+func getGrantOption(txn *sql.Tx, d *schema.ResourceData) (bool, error) {
+	roleOID, err := getRoleOID(txn, d.Get("role").(string))
+	if err != nil {
+		return false, err
+	}
+
+	objectType := d.Get("object_type").(string)
+	pgSchema := d.Get("schema").(string)
+	objects := interfaceSliceToStrings(d.Get("objects").(*schema.Set).List())
+
+	// aclsQuery returns the ACLs of the imported objects, the role OID being bound to $1.
+	var aclsQuery string
+	args := []interface{}{roleOID}
+
+	switch objectType {
+	case "database":
+		aclsQuery = "SELECT datacl FROM pg_catalog.pg_database WHERE datname = $2"
+		args = append(args, d.Get("database").(string))
+
+	case "schema":
+		aclsQuery = "SELECT nspacl FROM pg_catalog.pg_namespace WHERE nspname = $2"
+		args = append(args, pgSchema)
+
+	case "function", "procedure", "routine":
+		// The objects can contain the arguments of the functions.
+		names := make([]string, 0, len(objects))
+		for _, object := range objects {
+			names = append(names, strings.TrimSpace(strings.SplitN(object, "(", 2)[0]))
+		}
+		aclsQuery = `
+SELECT p.proacl
+  FROM pg_catalog.pg_proc p
+  JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace
+ WHERE n.nspname = $2 AND (cardinality($3::text[]) = 0 OR p.proname = ANY($3))
+`
+		args = append(args, pgSchema, pq.Array(names))
+
+	case "column":
+		aclsQuery = `
+SELECT att.attacl
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+  JOIN pg_catalog.pg_attribute att ON att.attrelid = c.oid
+ WHERE n.nspname = $2 AND c.relname = ANY($3) AND att.attname = ANY($4)
+`
+		args = append(args, pgSchema, pq.Array(objects), pq.Array(interfaceSliceToStrings(d.Get("columns").(*schema.Set).List())))
+
+	case "type", "domain":
+		aclsQuery = `
+SELECT t.typacl
+  FROM pg_catalog.pg_type t
+  JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+ WHERE n.nspname = $2 AND t.typname = ANY($3)
+`
+		args = append(args, pgSchema, pq.Array(objects))
+
+	case "foreign_data_wrapper":
+		aclsQuery = "SELECT fdwacl FROM pg_catalog.pg_foreign_data_wrapper WHERE fdwname = ANY($2)"
+		args = append(args, pq.Array(objects))
+
+	case "foreign_server":
+		aclsQuery = "SELECT srvacl FROM pg_catalog.pg_foreign_server WHERE srvname = ANY($2)"
+		args = append(args, pq.Array(objects))
+
+	case "language":
+		aclsQuery = "SELECT lanacl FROM pg_catalog.pg_language WHERE lanname = ANY($2)"
+		args = append(args, pq.Array(objects))
+
+	case "tablespace":
+		aclsQuery = "SELECT spcacl FROM pg_catalog.pg_tablespace WHERE spcname = ANY($2)"
+		args = append(args, pq.Array(objects))
+
+	case "large_object":
+		aclsQuery = "SELECT lomacl FROM pg_catalog.pg_largeobject_metadata WHERE oid::text = ANY($2)"
+		args = append(args, pq.Array(objects))
+
+	case "parameter":
+		names := make([]string, 0, len(objects))
+		for _, object := range objects {
+			names = append(names, strings.ToLower(object))
+		}
+		aclsQuery = "SELECT paracl FROM pg_catalog.pg_parameter_acl WHERE parname = ANY($2)"
+		args = append(args, pq.Array(names))
+
+	default:
+		aclsQuery = `
+SELECT c.relacl
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $2 AND c.relkind = $3 AND (cardinality($4::text[]) = 0 OR c.relname = ANY($4))
+`
+		args = append(args, pgSchema, objectTypes[objectType], pq.Array(objects))
+	}
+
+	var withGrantOption bool
+	if err := txn.QueryRow(fmt.Sprintf(`
+SELECT COALESCE(bool_or(a.is_grantable), false)
+  FROM (%s) o(acl)
+ CROSS JOIN LATERAL pg_catalog.aclexplode(o.acl) a
+ WHERE a.grantee = $1
+`, aclsQuery), args...).Scan(&withGrantOption); err != nil {
+		return false, fmt.Errorf("could not read grant option of role %s: %w", d.Get("role"), err)
+	}
+
+	return withGrantOption, nil
+}
+
+// getColumnsPrivilege returns the privilege granted to the role on the imported columns.
+// A postgresql_grant resource of type column only manages one privilege.
+func getColumnsPrivilege(txn *sql.Tx, d *schema.ResourceData) (string, error) {
+	objects := d.Get("objects").(*schema.Set).List()
+	if len(objects) != 1 {
+		return "", fmt.Errorf("exactly one table must be imported when object_type is column")
+	}
+
+	var privileges []string
+	if err := txn.QueryRow(`
+SELECT ARRAY(
+    SELECT DISTINCT a.privilege_type
+      FROM pg_catalog.pg_class c
+      JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+      JOIN pg_catalog.pg_attribute att ON att.attrelid = c.oid
+     CROSS JOIN LATERAL pg_catalog.aclexplode(att.attacl) a
+      JOIN pg_catalog.pg_roles r ON r.oid = a.grantee
+     WHERE n.nspname = $1 AND c.relname = $2 AND att.attname = ANY($3) AND r.rolname = $4
+     ORDER BY 1
+)::TEXT[]
+`,
+		d.Get("schema").(string), objects[0].(string),
+		pq.Array(interfaceSliceToStrings(d.Get("columns").(*schema.Set).List())), d.Get("role").(string),
+	).Scan(pq.Array(&privileges)); err != nil {
+		return "", fmt.Errorf("could not read column privileges: %w", err)
+	}
+
+	if len(privileges) != 1 {
+		return "", fmt.Errorf(
+			"expected exactly one privilege granted to %s on the imported columns of %s, got %v",
+			d.Get("role").(string), objects[0].(string), privileges,
+		)
+	}
+
+	return privileges[0], nil
+}
+
 func getRolesToGrant(txn *sql.Tx, d *schema.ResourceData) ([]string, error) {
 	// If user we use for Terraform is not a superuser (e.g.: in RDS)
 	// we need to grant owner of the schema and owners of tables in the schema
 	// in order to change theirs permissions.
 	owners := []string{}
-	objectType := d.Get("object_type")
+	objectType := d.Get("object_type").(string)
 
-	if objectType == "database" || objectType == "foreign_data_wrapper" || objectType == "foreign_server" {
+	if sliceContainsStr(objectTypesWithoutSchema, objectType) {
 		return owners, nil
 	}
 
-	schemaName := d.Get("schema").(string)
+	schemas, err := getGrantSchemas(txn, d.Get)
+	if err != nil {
+		return nil, err
+	}
 
-	if objectType != "schema" {
+	// The privileges on the schemas which are no longer declared are revoked as well.
+	previous := func(name string) interface{} {
+		old, _ := d.GetChange(name)
+		return old
+	}
+	if isMultiSchemaGrant(previous) {
+		previousSchemas, err := getGrantSchemas(txn, previous)
+		if err != nil {
+			return nil, err
+		}
+		for _, pgSchema := range previousSchemas {
+			if !sliceContainsStr(schemas, pgSchema) {
+				schemas = append(schemas, pgSchema)
+			}
+		}
+	}
+
+	for _, schemaName := range schemas {
+		schemaOwners, err := getSchemaRolesToGrant(txn, objectType, schemaName)
+		if err != nil {
+			return nil, err
+		}
+		for _, owner := range schemaOwners {
+			if !sliceContainsStr(owners, owner) {
+				owners = append(owners, owner)
+			}
+		}
+	}
+
+	owners, err = resolveOwners(txn, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	return owners, nil
+}
+
+// getSchemaRolesToGrant returns the owners of the schema and of its objects of the given type.
+func getSchemaRolesToGrant(txn *sql.Tx, objectType, schemaName string) ([]string, error) {
+	owners := []string{}
+
+	switch objectType {
+	case "schema":
+		// Only the owner of the schema is needed.
+	case "type", "domain":
+		var err error
+		owners, err = getTypesOwner(txn, schemaName)
+		if err != nil {
+			return nil, err
+		}
+	default:
 		var err error
 		owners, err = getTablesOwner(txn, schemaName)
 		if err != nil {
@@ -822,11 +1439,6 @@ func getRolesToGrant(txn *sql.Tx, d *schema.ResourceData) ([]string, error) {
 		owners = append(owners, schemaOwner)
 	}
 
-	owners, err = resolveOwners(txn, owners)
-	if err != nil {
-		return nil, err
-	}
-
 	return owners, nil
 }
 
@@ -849,5 +1461,17 @@ func validateFeatureSupport(db *DBConnection, d *schema.ResourceData) error {
 			db.version,
 		)
 	}
+	if (d.Get("object_type") == "type" || d.Get("object_type") == "domain") && !db.featureSupported(featureGrantOnType) {
+		return fmt.Errorf(
+			"object type %s is not supported for this Postgres version (%s)",
+			strings.ToUpper(d.Get("object_type").(string)), db.version,
+		)
+	}
+	if d.Get("object_type") == "parameter" && !db.featureSupported(featureGrantOnParameter) {
+		return fmt.Errorf(
+			"object type PARAMETER is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
 	return nil
 }
@@ -24,6 +24,30 @@ WHERE
   pg_get_userbyid(member) = $1 AND
   pg_get_userbyid(roleid) = $2;
 `
+
+	// Since PostgreSQL 16, a role can be granted several times to the same member by different grantors
+	// and each membership has its own options.
+	getGrantRoleWithOptionsQuery = `
+SELECT
+  pg_get_userbyid(member) as role,
+  pg_get_userbyid(roleid) as grant_role,
+  admin_option,
+  inherit_option,
+  set_option,
+  pg_get_userbyid(grantor) as granted_by
+FROM
+  pg_auth_members
+WHERE
+  pg_get_userbyid(member) = $1 AND
+  pg_get_userbyid(roleid) = $2 AND
+  ($3 = '' OR pg_get_userbyid(grantor) = $3)
+ORDER BY granted_by
+LIMIT 1;
+`
+
+	grantRoleWithInheritOptionAttr = "with_inherit_option"
+	grantRoleWithSetOptionAttr     = "with_set_option"
+	grantRoleGrantedByAttr         = "granted_by"
 )
 
 func resourcePostgreSQLGrantRole() *schema.Resource {
@@ -31,6 +55,9 @@ func resourcePostgreSQLGrantRole() *schema.Resource {
 		Create: PGResourceFunc(resourcePostgreSQLGrantRoleCreate),
 		Read:   PGResourceFunc(resourcePostgreSQLGrantRoleRead),
 		Delete: PGResourceFunc(resourcePostgreSQLGrantRoleDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: PGResourceImportFunc(resourcePostgreSQLGrantRoleImport),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"role": {
@@ -52,6 +79,27 @@ func resourcePostgreSQLGrantRole() *schema.Resource {
 				Default:     false,
 				Description: "Permit the grant recipient to grant it to others",
 			},
+			grantRoleWithInheritOptionAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Whether the member inherits the privileges of the granted role, defaults to the inherit attribute of the member (PostgreSQL 16+)",
+			},
+			grantRoleWithSetOptionAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Whether the member can SET ROLE to the granted role (PostgreSQL 16+)",
+			},
+			grantRoleGrantedByAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The role recorded as the grantor of the membership, defaults to the current user (PostgreSQL 16+)",
+			},
 		},
 	}
 }
@@ -75,6 +123,10 @@ func resourcePostgreSQLGrantRoleCreate(db *DBConnection, d *schema.ResourceData)
 		)
 	}
 
+	if err := checkGrantRoleOptionsSupported(db, d); err != nil {
+		return err
+	}
+
 	txn, err := startTransaction(db.client, "")
 	if err != nil {
 		return err
@@ -124,9 +176,70 @@ func resourcePostgreSQLGrantRoleDelete(db *DBConnection, d *schema.ResourceData)
 	return nil
 }
 
-func readGrantRole(db QueryAble, d *schema.ResourceData) error {
-	var roleName, grantRoleName string
-	var withAdminOption bool
+const grantRoleImportIDFormat = "role/grant_role[/granted_by]"
+
+// resourcePostgreSQLGrantRoleImport parses an import ID of the form role/grant_role[/granted_by]
+// and reads the options of the membership.
+func resourcePostgreSQLGrantRoleImport(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePrivileges) {
+		return fmt.Errorf(
+			"postgresql_grant_role resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	if err := setGrantRoleFromImportID(d); err != nil {
+		return err
+	}
+
+	if d.Get(grantRoleGrantedByAttr).(string) != "" && !db.featureSupported(featureGrantRoleGrantedBy) {
+		return fmt.Errorf("granted_by in import ID is not supported for this Postgres version (%s)", db.version)
+	}
+
+	importID := d.Id()
+	if err := readGrantRole(db, d); err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return fmt.Errorf("role membership %q not found", importID)
+	}
+
+	return nil
+}
+
+func setGrantRoleFromImportID(d *schema.ResourceData) error {
+	parts, err := splitImportID(d.Id(), 2, 3, grantRoleImportIDFormat)
+	if err != nil {
+		return err
+	}
+	if parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid import ID %q, expected format: %s", d.Id(), grantRoleImportIDFormat)
+	}
+
+	d.Set("role", parts[0])
+	d.Set("grant_role", parts[1])
+	d.Set(grantRoleGrantedByAttr, parts[2])
+
+	return nil
+}
+
+func checkGrantRoleOptionsSupported(db *DBConnection, d *schema.ResourceData) error {
+	if _, ok := d.GetOkExists(grantRoleWithInheritOptionAttr); ok && !db.featureSupported(featureGrantRoleInheritOption) { //nolint:staticcheck
+		return fmt.Errorf("%s is not supported for this Postgres version (%s)", grantRoleWithInheritOptionAttr, db.version)
+	}
+	if !d.Get(grantRoleWithSetOptionAttr).(bool) && !db.featureSupported(featureGrantRoleSetOption) {
+		return fmt.Errorf("%s is not supported for this Postgres version (%s)", grantRoleWithSetOptionAttr, db.version)
+	}
+	if d.Get(grantRoleGrantedByAttr).(string) != "" && !db.featureSupported(featureGrantRoleGrantedBy) {
+		return fmt.Errorf("%s is not supported for this Postgres version (%s)", grantRoleGrantedByAttr, db.version)
+	}
+	return nil
+}
+
+func readGrantRole(db *DBConnection, d *schema.ResourceData) error {
+	var roleName, grantRoleName, grantedBy string
+	var withAdminOption, withInheritOption bool
+	withSetOption := true
 
 	grantRoleID := d.Id()
 
@@ -136,7 +249,15 @@ func readGrantRole(db QueryAble, d *schema.ResourceData) error {
 		&withAdminOption,
 	}
 
-	err := db.QueryRow(getGrantRoleQuery, d.Get("role"), d.Get("grant_role")).Scan(values...)
+	var err error
+	if db.featureSupported(featureGrantRoleGrantedBy) {
+		values = append(values, &withInheritOption, &withSetOption, &grantedBy)
+		err = db.QueryRow(
+			getGrantRoleWithOptionsQuery, d.Get("role"), d.Get("grant_role"), d.Get(grantRoleGrantedByAttr),
+		).Scan(values...)
+	} else {
+		err = db.QueryRow(getGrantRoleQuery, d.Get("role"), d.Get("grant_role")).Scan(values...)
+	}
 	switch {
 	case err == sql.ErrNoRows:
 		log.Printf("[WARN] PostgreSQL grant role (%q) not found", grantRoleID)
@@ -149,6 +270,11 @@ func readGrantRole(db QueryAble, d *schema.ResourceData) error {
 	d.Set("role", roleName)
 	d.Set("grant_role", grantRoleName)
 	d.Set("with_admin_option", withAdminOption)
+	d.Set(grantRoleWithSetOptionAttr, withSetOption)
+	if db.featureSupported(featureGrantRoleGrantedBy) {
+		d.Set(grantRoleWithInheritOptionAttr, withInheritOption)
+		d.Set(grantRoleGrantedByAttr, grantedBy)
+	}
 
 	d.SetId(generateGrantRoleID(d))
 
@@ -164,8 +290,23 @@ func createGrantRoleQuery(d *schema.ResourceData) string {
 		pq.QuoteIdentifier(grantRole),
 		pq.QuoteIdentifier(role),
 	)
+
+	var options []string
 	if wao, _ := d.Get("with_admin_option").(bool); wao {
-		query = query + " WITH ADMIN OPTION"
+		options = append(options, "ADMIN OPTION")
+	}
+	if inherit, ok := d.GetOkExists(grantRoleWithInheritOptionAttr); ok { //nolint:staticcheck
+		options = append(options, fmt.Sprintf("INHERIT %s", strings.ToUpper(strconv.FormatBool(inherit.(bool)))))
+	}
+	if set, _ := d.Get(grantRoleWithSetOptionAttr).(bool); !set {
+		options = append(options, "SET FALSE")
+	}
+	if len(options) > 0 {
+		query = query + " WITH " + strings.Join(options, ", ")
+	}
+
+	if grantedBy, _ := d.Get(grantRoleGrantedByAttr).(string); grantedBy != "" {
+		query = query + " GRANTED BY " + pq.QuoteIdentifier(grantedBy)
 	}
 
 	return query
@@ -175,11 +316,16 @@ func createRevokeRoleQuery(d *schema.ResourceData) string {
 	grantRole, _ := d.Get("grant_role").(string)
 	role, _ := d.Get("role").(string)
 
-	return fmt.Sprintf(
+	query := fmt.Sprintf(
 		"REVOKE %s FROM %s",
 		pq.QuoteIdentifier(grantRole),
 		pq.QuoteIdentifier(role),
 	)
+	if grantedBy, _ := d.Get(grantRoleGrantedByAttr).(string); grantedBy != "" {
+		query = query + " GRANTED BY " + pq.QuoteIdentifier(grantedBy)
+	}
+
+	return query
 }
 
 func grantRole(txn *sql.Tx, d *schema.ResourceData) error {
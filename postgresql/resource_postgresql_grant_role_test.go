@@ -43,6 +43,28 @@ func TestCreateGrantRoleQuery(t *testing.T) {
 			},
 			expected: fmt.Sprintf("GRANT %s TO %s WITH ADMIN OPTION", pq.QuoteIdentifier(grantRoleName), pq.QuoteIdentifier(roleName)),
 		},
+		{
+			resource: map[string]interface{}{
+				"role":                roleName,
+				"grant_role":          grantRoleName,
+				"with_inherit_option": false,
+			},
+			expected: fmt.Sprintf("GRANT %s TO %s WITH INHERIT FALSE", pq.QuoteIdentifier(grantRoleName), pq.QuoteIdentifier(roleName)),
+		},
+		{
+			resource: map[string]interface{}{
+				"role":                roleName,
+				"grant_role":          grantRoleName,
+				"with_admin_option":   true,
+				"with_inherit_option": true,
+				"with_set_option":     false,
+				"granted_by":          "admin",
+			},
+			expected: fmt.Sprintf(
+				"GRANT %s TO %s WITH ADMIN OPTION, INHERIT TRUE, SET FALSE GRANTED BY %s",
+				pq.QuoteIdentifier(grantRoleName), pq.QuoteIdentifier(roleName), pq.QuoteIdentifier("admin"),
+			),
+		},
 	}
 
 	for _, c := range cases {
@@ -92,6 +114,18 @@ func TestRevokeRoleQuery(t *testing.T) {
 	}
 }
 
+func TestRevokeRoleQueryGrantedBy(t *testing.T) {
+	out := createRevokeRoleQuery(schema.TestResourceDataRaw(t, resourcePostgreSQLGrantRole().Schema, map[string]interface{}{
+		"role":       "foo",
+		"grant_role": "bar",
+		"granted_by": "admin",
+	}))
+	expected := `REVOKE "bar" FROM "foo" GRANTED BY "admin"`
+	if out != expected {
+		t.Fatalf("Error matching output and expected: %#v vs %#v", out, expected)
+	}
+}
+
 func TestAccPostgresqlGrantRole(t *testing.T) {
 	skipIfNotAcc(t)
 
@@ -135,6 +169,76 @@ func TestAccPostgresqlGrantRole(t *testing.T) {
 					checkGrantRole(t, dsn, roleName, grantedRoleName, true),
 				),
 			},
+			{
+				ResourceName:      "postgresql_grant_role.grant_role",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%s", roleName, grantedRoleName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlGrantRole_MembershipOptions(t *testing.T) {
+	skipIfNotAcc(t)
+
+	config := getTestConfig(t)
+	dsn := config.connStr("postgres")
+
+	dbSuffix, teardown := setupTestDatabase(t, false, true)
+	defer teardown()
+
+	_, roleName := getTestDBNames(dbSuffix)
+
+	testAccPostgresqlGrantRoleResources := fmt.Sprintf(`
+	resource postgresql_role "break_glass" {
+		name = "break_glass"
+	}
+	resource postgresql_grant_role "grant_role" {
+		role                = "%s"
+		grant_role          = postgresql_role.break_glass.name
+		with_inherit_option = false
+		with_set_option     = true
+	}
+	`, roleName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureGrantRoleInheritOption)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlGrantRoleResources,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant_role.grant_role", "with_inherit_option", "false"),
+					resource.TestCheckResourceAttr("postgresql_grant_role.grant_role", "with_set_option", "true"),
+					resource.TestCheckResourceAttrSet("postgresql_grant_role.grant_role", "granted_by"),
+					checkGrantRole(t, dsn, roleName, "break_glass", false),
+					func(*terraform.State) error {
+						db, err := sql.Open("postgres", dsn)
+						if err != nil {
+							return err
+						}
+						defer db.Close()
+
+						var inherit, set bool
+						if err := db.QueryRow(`
+						SELECT inherit_option, set_option
+						FROM pg_auth_members
+						WHERE pg_get_userbyid(member) = $1
+						AND pg_get_userbyid(roleid) = 'break_glass'
+						`, roleName).Scan(&inherit, &set); err != nil {
+							return fmt.Errorf("could not read membership options: %w", err)
+						}
+						if inherit || !set {
+							return fmt.Errorf("expected INHERIT FALSE and SET TRUE, got INHERIT %t and SET %t", inherit, set)
+						}
+						return nil
+					},
+				),
+			},
 		},
 	})
 }
@@ -167,6 +167,62 @@ func TestCreateGrantQuery(t *testing.T) {
 			privileges: []string{"ALL PRIVILEGES"},
 			expected:   fmt.Sprintf(`GRANT ALL PRIVILEGES ON FOREIGN SERVER "baz" TO %s WITH GRANT OPTION`, pq.QuoteIdentifier(roleName)),
 		},
+		{
+			resource: schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, map[string]interface{}{
+				"object_type": "type",
+				"schema":      databaseName,
+				"objects":     []interface{}{"t1"},
+				"role":        roleName,
+			}),
+			privileges: []string{"USAGE"},
+			expected:   fmt.Sprintf(`GRANT USAGE ON TYPE %s."t1" TO %s`, pq.QuoteIdentifier(databaseName), pq.QuoteIdentifier(roleName)),
+		},
+		{
+			resource: schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, map[string]interface{}{
+				"object_type": "domain",
+				"schema":      databaseName,
+				"objects":     []interface{}{"d1"},
+				"role":        roleName,
+			}),
+			privileges: []string{"USAGE"},
+			expected:   fmt.Sprintf(`GRANT USAGE ON DOMAIN %s."d1" TO %s`, pq.QuoteIdentifier(databaseName), pq.QuoteIdentifier(roleName)),
+		},
+		{
+			resource: schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, map[string]interface{}{
+				"object_type": "language",
+				"objects":     []interface{}{"plpgsql"},
+				"role":        roleName,
+			}),
+			privileges: []string{"USAGE"},
+			expected:   fmt.Sprintf(`GRANT USAGE ON LANGUAGE "plpgsql" TO %s`, pq.QuoteIdentifier(roleName)),
+		},
+		{
+			resource: schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, map[string]interface{}{
+				"object_type": "tablespace",
+				"objects":     []interface{}{"ts"},
+				"role":        roleName,
+			}),
+			privileges: []string{"CREATE"},
+			expected:   fmt.Sprintf(`GRANT CREATE ON TABLESPACE "ts" TO %s`, pq.QuoteIdentifier(roleName)),
+		},
+		{
+			resource: schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, map[string]interface{}{
+				"object_type": "large_object",
+				"objects":     []interface{}{"16384"},
+				"role":        roleName,
+			}),
+			privileges: []string{"SELECT", "UPDATE"},
+			expected:   fmt.Sprintf(`GRANT SELECT,UPDATE ON LARGE OBJECT 16384 TO %s`, pq.QuoteIdentifier(roleName)),
+		},
+		{
+			resource: schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, map[string]interface{}{
+				"object_type": "parameter",
+				"objects":     []interface{}{"pg_stat_statements.track"},
+				"role":        roleName,
+			}),
+			privileges: []string{"SET", "ALTER SYSTEM"},
+			expected:   fmt.Sprintf(`GRANT SET,ALTER SYSTEM ON PARAMETER "pg_stat_statements"."track" TO %s`, pq.QuoteIdentifier(roleName)),
+		},
 	}
 
 	for _, c := range cases {
@@ -290,6 +346,39 @@ func TestCreateRevokeQuery(t *testing.T) {
 			}),
 			expected: fmt.Sprintf(`REVOKE ALL PRIVILEGES ON FOREIGN SERVER "baz" FROM %s`, pq.QuoteIdentifier(roleName)),
 		},
+		{
+			resource: schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, map[string]interface{}{
+				"object_type": "type",
+				"schema":      databaseName,
+				"objects":     []interface{}{"t1"},
+				"role":        roleName,
+			}),
+			expected: fmt.Sprintf(`REVOKE ALL PRIVILEGES ON TYPE %s."t1" FROM %s`, pq.QuoteIdentifier(databaseName), pq.QuoteIdentifier(roleName)),
+		},
+		{
+			resource: schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, map[string]interface{}{
+				"object_type": "tablespace",
+				"objects":     []interface{}{"ts"},
+				"role":        roleName,
+			}),
+			expected: fmt.Sprintf(`REVOKE ALL PRIVILEGES ON TABLESPACE "ts" FROM %s`, pq.QuoteIdentifier(roleName)),
+		},
+		{
+			resource: schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, map[string]interface{}{
+				"object_type": "large_object",
+				"objects":     []interface{}{"16384"},
+				"role":        roleName,
+			}),
+			expected: fmt.Sprintf(`REVOKE ALL PRIVILEGES ON LARGE OBJECT 16384 FROM %s`, pq.QuoteIdentifier(roleName)),
+		},
+		{
+			resource: schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, map[string]interface{}{
+				"object_type": "parameter",
+				"objects":     []interface{}{"work_mem"},
+				"role":        roleName,
+			}),
+			expected: fmt.Sprintf(`REVOKE ALL PRIVILEGES ON PARAMETER "work_mem" FROM %s`, pq.QuoteIdentifier(roleName)),
+		},
 	}
 
 	for _, c := range cases {
@@ -303,6 +392,120 @@ func TestCreateRevokeQuery(t *testing.T) {
 	}
 }
 
+func TestSetGrantFromImportID(t *testing.T) {
+	cases := []struct {
+		id         string
+		expectedID string
+		objects    []string
+		columns    []string
+		err        bool
+	}{
+		{id: "foo/bar/test_schema/table", expectedID: "foo_bar_test_schema_table"},
+		{id: "foo/bar/test_schema/table/t1,t2", expectedID: "foo_bar_test_schema_table_t1_t2", objects: []string{"t1", "t2"}},
+		{id: "foo/bar//database", expectedID: "foo_bar_database"},
+		{id: "foo/bar/test_schema/column/t1/c1,c2", expectedID: "foo_bar_test_schema_column_t1_c1_c2", objects: []string{"t1"}, columns: []string{"c1", "c2"}},
+		{id: "foo/bar//foreign_server/srv", expectedID: "foo_bar_foreign_server_srv", objects: []string{"srv"}},
+		{id: "foo/bar/test_schema", err: true},
+		{id: "foo/bar/test_schema/view", err: true},
+		{id: "/bar/test_schema/table", err: true},
+		{id: "foo/bar//foreign_server", err: true},
+		{id: "foo/bar/test_schema/column/t1", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.id, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, map[string]interface{}{})
+			d.SetId(c.id)
+
+			err := setGrantFromImportID(d)
+			if c.err {
+				if err == nil {
+					t.Fatalf("expected an error for import ID %q", c.id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error for import ID %q: %v", c.id, err)
+			}
+			if d.Id() != c.expectedID {
+				t.Fatalf("Error matching ID and expected: %#v vs %#v", d.Id(), c.expectedID)
+			}
+			for attr, expected := range map[string][]string{"objects": c.objects, "columns": c.columns} {
+				if out := d.Get(attr).(*schema.Set); out.Len() != len(expected) {
+					t.Fatalf("Error matching %s and expected: %#v vs %#v", attr, out.List(), expected)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateGrantID(t *testing.T) {
+	cases := []struct {
+		resource map[string]interface{}
+		expected string
+	}{
+		{
+			resource: map[string]interface{}{"role": "foo", "database": "bar", "schema": "test_schema", "object_type": "table"},
+			expected: "foo_bar_test_schema_table",
+		},
+		{
+			resource: map[string]interface{}{"role": "foo", "database": "bar", "schemas": []interface{}{"s2", "s1"}, "object_type": "table"},
+			expected: "foo_bar_s1,s2_table",
+		},
+		{
+			resource: map[string]interface{}{
+				"role": "foo", "database": "bar", "object_type": "sequence",
+				"schema_like_any_patterns": []interface{}{"tenant_%"}, "schema_regex_pattern": "^app",
+			},
+			expected: "foo_bar_tenant_%,^app_sequence",
+		},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, c.resource)
+		if out := generateGrantID(d); out != c.expected {
+			t.Fatalf("Error matching output and expected: %#v vs %#v", out, c.expected)
+		}
+	}
+}
+
+func TestWithGrantSchema(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, map[string]interface{}{
+		"role":        "bar",
+		"schemas":     []interface{}{"s1", "s2"},
+		"object_type": "table",
+	})
+
+	out := createRevokeQuery(withGrantSchema(d.Get, "s1"))
+	expected := `REVOKE ALL PRIVILEGES ON ALL TABLES IN SCHEMA "s1" FROM "bar"`
+	if out != expected {
+		t.Fatalf("Error matching output and expected: %#v vs %#v", out, expected)
+	}
+
+	out = createSchemaGrantQuery(d, "s2", []string{"SELECT"})
+	expected = `GRANT SELECT ON ALL TABLES IN SCHEMA "s2" TO "bar"`
+	if out != expected {
+		t.Fatalf("Error matching output and expected: %#v vs %#v", out, expected)
+	}
+}
+
+func TestGetMissingGrantSchemas(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, map[string]interface{}{
+		"role":        "bar",
+		"schemas":     []interface{}{"s1", "s3", "s2"},
+		"object_type": "table",
+	})
+
+	out := strings.Join(getMissingGrantSchemas(d.Get, []string{"s1"}), ",")
+	if out != "s2,s3" {
+		t.Fatalf("Error matching output and expected: %#v vs %#v", out, "s2,s3")
+	}
+
+	if missing := getMissingGrantSchemas(d.Get, []string{"s1", "s2", "s3"}); len(missing) != 0 {
+		t.Fatalf("Expected no missing schemas, got %#v", missing)
+	}
+}
+
 func TestAccPostgresqlGrant(t *testing.T) {
 	skipIfNotAcc(t)
 
@@ -349,6 +552,12 @@ func TestAccPostgresqlGrant(t *testing.T) {
 					},
 				),
 			},
+			{
+				ResourceName:      "postgresql_grant.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%s/test_schema/table", roleName, dbName),
+				ImportStateVerify: true,
+			},
 			{
 				Config: fmt.Sprintf(testGrant, `["SELECT", "INSERT", "UPDATE"]`),
 				Check: resource.ComposeTestCheckFunc(
@@ -372,6 +581,19 @@ func TestAccPostgresqlGrant(t *testing.T) {
 					},
 				),
 			},
+			// The grant option is read back when importing.
+			{
+				Config: strings.Replace(fmt.Sprintf(testGrant, `["SELECT"]`), "object_type", "with_grant_option = true\n\t\tobject_type", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant.test", "with_grant_option", "true"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_grant.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%s/test_schema/table", roleName, dbName),
+				ImportStateVerify: true,
+			},
 			// We test to revoke everything
 			{
 				Config: fmt.Sprintf(testGrant, `[]`),
@@ -386,6 +608,84 @@ func TestAccPostgresqlGrant(t *testing.T) {
 	})
 }
 
+func TestAccPostgresqlGrantSchemas(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	createTestSchemas(t, dbSuffix, []string{"tenant_1", "tenant_2", "other"}, "")
+	testTables := []string{"tenant_1.test_table", "tenant_2.test_table"}
+	createTestTables(t, dbSuffix, append(testTables, "other.test_table"), "")
+
+	config := getTestConfig(t)
+	dbName, roleName := getTestDBNames(dbSuffix)
+
+	resourceConfig := `
+resource "postgresql_grant" "test" {
+	database    = "%s"
+	role        = "%s"
+	%s
+	object_type = "table"
+	privileges  = %s
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), fmt.Sprintf("GRANT USAGE ON SCHEMA tenant_1, tenant_2, other TO %s", roleName))
+				},
+				Config: fmt.Sprintf(resourceConfig, dbName, roleName, `schemas = ["tenant_1", "tenant_2"]`, `["SELECT"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"postgresql_grant.test", "id", fmt.Sprintf("%s_%s_tenant_1,tenant_2_table", roleName, dbName),
+					),
+					func(*terraform.State) error {
+						return testCheckTablesPrivileges(t, dbName, roleName, testTables, []string{"SELECT"})
+					},
+					func(*terraform.State) error {
+						return testCheckTablesPrivileges(t, dbName, roleName, []string{"other.test_table"}, []string{})
+					},
+				),
+			},
+			{
+				Config: fmt.Sprintf(resourceConfig, dbName, roleName, `schema_like_any_patterns = ["tenant_%"]`, `["SELECT", "INSERT"]`),
+				Check: resource.ComposeTestCheckFunc(
+					func(*terraform.State) error {
+						return testCheckTablesPrivileges(t, dbName, roleName, testTables, []string{"SELECT", "INSERT"})
+					},
+				),
+			},
+			{
+				// A new schema matching the pattern is detected and granted.
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), "CREATE SCHEMA tenant_3")
+					dbExecute(t, config.connStr(dbName), "CREATE TABLE tenant_3.test_table (val text)")
+					dbExecute(t, config.connStr(dbName), fmt.Sprintf("GRANT USAGE ON SCHEMA tenant_3 TO %s", roleName))
+				},
+				Config: fmt.Sprintf(resourceConfig, dbName, roleName, `schema_like_any_patterns = ["tenant_%"]`, `["SELECT", "INSERT"]`),
+				Check: resource.ComposeTestCheckFunc(
+					func(*terraform.State) error {
+						return testCheckTablesPrivileges(t, dbName, roleName, append(testTables, "tenant_3.test_table"), []string{"SELECT", "INSERT"})
+					},
+				),
+			},
+			{
+				// A declared schema which doesn't exist is an error instead of being skipped.
+				Config:      fmt.Sprintf(resourceConfig, dbName, roleName, `schemas = ["tenant_1", "tenant_4"]`, `["SELECT"]`),
+				ExpectError: regexp.MustCompile("schemas tenant_4 do not exist"),
+			},
+		},
+	})
+}
+
 func TestAccPostgresqlGrantColumns(t *testing.T) {
 	skipIfNotAcc(t)
 
@@ -1202,6 +1502,166 @@ resource "postgresql_grant" "test" {
 	})
 }
 
+func TestAccPostgresqlGrantTypeAndDomain(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	config := getTestConfig(t)
+	dbName, roleName := getTestDBNames(dbSuffix)
+	dsn := config.connStr(dbName)
+
+	dbExecute(t, dsn, "CREATE TYPE test_schema.test_enum AS ENUM ('a', 'b')")
+	dbExecute(t, dsn, "CREATE DOMAIN test_schema.test_domain AS integer CHECK (VALUE > 0)")
+	// Types and domains are usable by PUBLIC by default.
+	dbExecute(t, dsn, "REVOKE ALL ON TYPE test_schema.test_enum FROM PUBLIC")
+	dbExecute(t, dsn, "REVOKE ALL ON DOMAIN test_schema.test_domain FROM PUBLIC")
+
+	tfConfig := fmt.Sprintf(`
+resource "postgresql_grant" "type" {
+	database    = "%[1]s"
+	schema      = "test_schema"
+	role        = "%[2]s"
+	object_type = "type"
+	objects     = ["test_enum"]
+	privileges  = %%[1]s
+}
+
+resource "postgresql_grant" "domain" {
+	database    = "%[1]s"
+	schema      = "test_schema"
+	role        = "%[2]s"
+	object_type = "domain"
+	objects     = ["test_domain"]
+	privileges  = %%[1]s
+}
+`, dbName, roleName)
+
+	checkTypePrivilege := func(typeName string, expected bool) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			db, err := sql.Open("postgres", dsn)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			var hasPrivilege bool
+			if err := db.QueryRow(
+				"SELECT has_type_privilege($1, $2, 'USAGE')", roleName, "test_schema."+typeName,
+			).Scan(&hasPrivilege); err != nil {
+				return fmt.Errorf("could not check privileges on type %s: %w", typeName, err)
+			}
+			if hasPrivilege != expected {
+				return fmt.Errorf("expected USAGE privilege of %s on type %s to be %t", roleName, typeName, expected)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureGrantOnType)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(tfConfig, `["USAGE"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant.type", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("postgresql_grant.domain", "privileges.#", "1"),
+					checkTypePrivilege("test_enum", true),
+					checkTypePrivilege("test_domain", true),
+				),
+			},
+			{
+				Config: fmt.Sprintf(tfConfig, `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant.type", "privileges.#", "0"),
+					checkTypePrivilege("test_enum", false),
+					checkTypePrivilege("test_domain", false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlGrantParameter(t *testing.T) {
+	skipIfNotAcc(t)
+	skipIfNotSuperuser(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	config := getTestConfig(t)
+	dbName, roleName := getTestDBNames(dbSuffix)
+	dsn := config.connStr(dbName)
+
+	tfConfig := fmt.Sprintf(`
+resource "postgresql_grant" "test" {
+	database    = "%s"
+	role        = "%s"
+	object_type = "parameter"
+	objects     = ["log_min_duration_statement"]
+	privileges  = %%s
+}
+`, dbName, roleName)
+
+	checkParameterPrivilege := func(privilege string, expected bool) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			db, err := sql.Open("postgres", dsn)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			var hasPrivilege bool
+			if err := db.QueryRow(
+				"SELECT has_parameter_privilege($1, 'log_min_duration_statement', $2)", roleName, privilege,
+			).Scan(&hasPrivilege); err != nil {
+				return fmt.Errorf("could not check privileges on parameter: %w", err)
+			}
+			if hasPrivilege != expected {
+				return fmt.Errorf("expected %s privilege of %s on parameter to be %t", privilege, roleName, expected)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureGrantOnParameter)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(tfConfig, `["SET"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant.test", "privileges.#", "1"),
+					checkParameterPrivilege("SET", true),
+					checkParameterPrivilege("ALTER SYSTEM", false),
+				),
+			},
+			{
+				Config: fmt.Sprintf(tfConfig, `["SET", "ALTER SYSTEM"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant.test", "privileges.#", "2"),
+					checkParameterPrivilege("ALTER SYSTEM", true),
+				),
+			},
+			{
+				Config: fmt.Sprintf(tfConfig, `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant.test", "privileges.#", "0"),
+					checkParameterPrivilege("SET", false),
+				),
+			},
+		},
+	})
+}
+
 func TestAccPostgresqlGrantForeignDataWrapper(t *testing.T) {
 	skipIfNotAcc(t)
 	skipIfNotSuperuser(t)
@@ -0,0 +1,334 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	importForeignSchemaDatabaseAttr       = "database"
+	importForeignSchemaSchemaAttr         = "schema"
+	importForeignSchemaServerAttr         = "server"
+	importForeignSchemaRemoteSchemaAttr   = "remote_schema"
+	importForeignSchemaLimitToAttr        = "limit_to"
+	importForeignSchemaExceptAttr         = "except"
+	importForeignSchemaOptionsAttr        = "options"
+	importForeignSchemaImportedTablesAttr = "imported_tables"
+	importForeignSchemaDropCascadeAttr    = "drop_cascade"
+)
+
+func resourcePostgreSQLImportForeignSchema() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLImportForeignSchemaCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLImportForeignSchemaRead),
+		Update: PGResourceFunc(resourcePostgreSQLImportForeignSchemaUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLImportForeignSchemaDelete),
+
+		Schema: map[string]*schema.Schema{
+			importForeignSchemaDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the foreign tables are created. If not specified, the provider default database is used",
+			},
+			importForeignSchemaSchemaAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The local schema where the foreign tables are created",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			importForeignSchemaServerAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The foreign server to import from",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			importForeignSchemaRemoteSchemaAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The remote schema to import from",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			importForeignSchemaLimitToAttr: {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ForceNew:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				Description:   "Import only the foreign tables with these names",
+				ConflictsWith: []string{importForeignSchemaExceptAttr},
+			},
+			importForeignSchemaExceptAttr: {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ForceNew:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				Description:   "Exclude the foreign tables with these names from the import",
+				ConflictsWith: []string{importForeignSchemaLimitToAttr},
+			},
+			importForeignSchemaOptionsAttr: {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The options of the import. Their names and values are dependent on the foreign-data wrapper of the server",
+			},
+			importForeignSchemaImportedTablesAttr: {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "The foreign tables created by the import",
+			},
+			importForeignSchemaDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Automatically drop objects that depend on the imported foreign tables (such as views) on destroy. Drop RESTRICT is the default",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLImportForeignSchemaCreate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureImportForeignSchema) {
+		return fmt.Errorf(
+			"postgresql_import_foreign_schema resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := d.Get(importForeignSchemaSchemaAttr).(string)
+	server := d.Get(importForeignSchemaServerAttr).(string)
+	remoteSchema := d.Get(importForeignSchemaRemoteSchemaAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	// The foreign tables which already exist are not managed by this resource.
+	existingTables, err := listServerForeignTables(txn, pgSchema, server)
+	if err != nil {
+		return err
+	}
+
+	if _, err := txn.Exec(importForeignSchemaQuery(d)); err != nil {
+		return fmt.Errorf("could not import foreign schema %s from server %s: %w", remoteSchema, server, err)
+	}
+
+	tables, err := listServerForeignTables(txn, pgSchema, server)
+	if err != nil {
+		return err
+	}
+
+	var importedTables []string
+	for _, table := range tables {
+		if !sliceContainsStr(existingTables, table) {
+			importedTables = append(importedTables, table)
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.Set(importForeignSchemaDatabaseAttr, database)
+	d.Set(importForeignSchemaImportedTablesAttr, stringSliceToSet(importedTables))
+	d.SetId(generateImportForeignSchemaID(database, pgSchema, server, remoteSchema))
+
+	return resourcePostgreSQLImportForeignSchemaReadImpl(db, d)
+}
+
+func resourcePostgreSQLImportForeignSchemaRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureImportForeignSchema) {
+		return fmt.Errorf(
+			"postgresql_import_foreign_schema resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return resourcePostgreSQLImportForeignSchemaReadImpl(db, d)
+}
+
+// resourcePostgreSQLImportForeignSchemaReadImpl refreshes the list of imported foreign tables.
+// If all of them have been dropped, the resource is removed from the state so the schema is imported again.
+func resourcePostgreSQLImportForeignSchemaReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := d.Get(importForeignSchemaSchemaAttr).(string)
+	server := d.Get(importForeignSchemaServerAttr).(string)
+
+	exists, err := dbExists(db, database)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Printf("[WARN] PostgreSQL database (%s) for foreign schema import not found", database)
+		d.SetId("")
+		return nil
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	tables, err := listServerForeignTables(txn, pgSchema, server)
+	if err != nil {
+		return err
+	}
+
+	imported := interfaceSliceToStrings(d.Get(importForeignSchemaImportedTablesAttr).(*schema.Set).List())
+	var remaining []string
+	for _, table := range imported {
+		if sliceContainsStr(tables, table) {
+			remaining = append(remaining, table)
+		}
+	}
+
+	if len(imported) > 0 && len(remaining) == 0 {
+		log.Printf(
+			"[WARN] PostgreSQL foreign tables imported from server %s not found in schema %s of database %s",
+			server, pgSchema, database,
+		)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(importForeignSchemaDatabaseAttr, database)
+	d.Set(importForeignSchemaImportedTablesAttr, stringSliceToSet(remaining))
+
+	return nil
+}
+
+// Only drop_cascade can be updated, it's only used on destroy.
+func resourcePostgreSQLImportForeignSchemaUpdate(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLImportForeignSchemaRead(db, d)
+}
+
+func resourcePostgreSQLImportForeignSchemaDelete(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureImportForeignSchema) {
+		return fmt.Errorf(
+			"postgresql_import_foreign_schema resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	tables := interfaceSliceToStrings(d.Get(importForeignSchemaImportedTablesAttr).(*schema.Set).List())
+	if len(tables) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := d.Get(importForeignSchemaSchemaAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	sort.Strings(tables)
+	for i, table := range tables {
+		tables[i] = tableIdentifier(pgSchema, table)
+	}
+
+	dropMode := "RESTRICT"
+	if d.Get(importForeignSchemaDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	query := fmt.Sprintf("DROP FOREIGN TABLE IF EXISTS %s %s", strings.Join(tables, ", "), dropMode)
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("could not drop imported foreign tables: %w", err)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func importForeignSchemaQuery(d *schema.ResourceData) string {
+	b := bytes.NewBufferString("IMPORT FOREIGN SCHEMA ")
+	fmt.Fprint(b, pq.QuoteIdentifier(d.Get(importForeignSchemaRemoteSchemaAttr).(string)))
+
+	if limitTo := d.Get(importForeignSchemaLimitToAttr).(*schema.Set); limitTo.Len() > 0 {
+		fmt.Fprintf(b, " LIMIT TO (%s)", quoteSortedIdentifiers(limitTo))
+	}
+	if except := d.Get(importForeignSchemaExceptAttr).(*schema.Set); except.Len() > 0 {
+		fmt.Fprintf(b, " EXCEPT (%s)", quoteSortedIdentifiers(except))
+	}
+
+	fmt.Fprint(
+		b,
+		" FROM SERVER ", pq.QuoteIdentifier(d.Get(importForeignSchemaServerAttr).(string)),
+		" INTO ", pq.QuoteIdentifier(d.Get(importForeignSchemaSchemaAttr).(string)),
+	)
+
+	if clause := foreignOptionsClause(d.Get(importForeignSchemaOptionsAttr).(map[string]interface{})); clause != "" {
+		fmt.Fprint(b, " ", clause)
+	}
+
+	return b.String()
+}
+
+func quoteSortedIdentifiers(set *schema.Set) string {
+	names := interfaceSliceToStrings(set.List())
+	sort.Strings(names)
+	return quoteIdentifierList(names)
+}
+
+// listServerForeignTables returns the names of the foreign tables of a schema which use the given server.
+func listServerForeignTables(txn *sql.Tx, pgSchema, server string) ([]string, error) {
+	rows, err := txn.Query(`
+SELECT c.relname
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+  JOIN pg_catalog.pg_foreign_table ft ON ft.ftrelid = c.oid
+  JOIN pg_catalog.pg_foreign_server s ON s.oid = ft.ftserver
+ WHERE n.nspname = $1 AND s.srvname = $2
+ ORDER BY c.relname
+`, pgSchema, server)
+	if err != nil {
+		return nil, fmt.Errorf("could not list foreign tables of schema %s: %w", pgSchema, err)
+	}
+	defer rows.Close()
+
+	tables := []string{}
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, fmt.Errorf("could not scan foreign table name: %w", err)
+		}
+		tables = append(tables, table)
+	}
+
+	return tables, rows.Err()
+}
+
+func generateImportForeignSchemaID(database, pgSchema, server, remoteSchema string) string {
+	return strings.Join([]string{database, pgSchema, server, remoteSchema}, ".")
+}
@@ -0,0 +1,150 @@
+package postgresql
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportForeignSchemaQuery(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLImportForeignSchema().Schema, map[string]interface{}{
+		"schema":        "films",
+		"server":        "film_server",
+		"remote_schema": "public",
+		"limit_to":      []interface{}{"directors", "actors"},
+		"options": map[string]interface{}{
+			"import_default": "true",
+		},
+	})
+
+	assert.Equal(t,
+		`IMPORT FOREIGN SCHEMA "public" LIMIT TO ("actors", "directors") FROM SERVER "film_server" INTO "films" OPTIONS ("import_default" 'true')`,
+		importForeignSchemaQuery(d),
+	)
+
+	d = schema.TestResourceDataRaw(t, resourcePostgreSQLImportForeignSchema().Schema, map[string]interface{}{
+		"schema":        "films",
+		"server":        "film_server",
+		"remote_schema": "public",
+		"except":        []interface{}{"secrets"},
+	})
+
+	assert.Equal(t,
+		`IMPORT FOREIGN SCHEMA "public" EXCEPT ("secrets") FROM SERVER "film_server" INTO "films"`,
+		importForeignSchemaQuery(d),
+	)
+}
+
+func TestAccPostgresqlImportForeignSchema_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+	testSuperuserPreCheck(t)
+
+	config := getTestConfig(t)
+
+	tfConfig := fmt.Sprintf(`
+resource "postgresql_extension" "postgres_fdw" {
+  name = "postgres_fdw"
+}
+
+resource "postgresql_schema" "remote" {
+  name = "test_import_remote"
+}
+
+resource "postgresql_schema" "local" {
+  name = "test_import_local"
+}
+
+resource "postgresql_table" "remote" {
+  for_each = toset(["first", "second", "third"])
+
+  schema = postgresql_schema.remote.name
+  name   = each.key
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+}
+
+resource "postgresql_server" "loopback" {
+  server_name = "test_import_loopback"
+  fdw_name    = "postgres_fdw"
+  options = {
+    host   = "%s"
+    port   = "%s"
+    dbname = "postgres"
+  }
+
+  depends_on = [postgresql_extension.postgres_fdw]
+}
+
+resource "postgresql_user_mapping" "loopback" {
+  server_name = postgresql_server.loopback.server_name
+  user_name   = "%s"
+  options = {
+    user     = "%s"
+    password = "%s"
+  }
+}
+
+resource "postgresql_import_foreign_schema" "test" {
+  schema        = postgresql_schema.local.name
+  server        = postgresql_server.loopback.server_name
+  remote_schema = postgresql_schema.remote.name
+  except        = ["third"]
+
+  depends_on = [postgresql_table.remote, postgresql_user_mapping.loopback]
+}
+`, config.Host, strconv.Itoa(config.Port), config.Username, config.Username, config.Password)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureImportForeignSchema)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlImportForeignSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tfConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_import_foreign_schema.test", "imported_tables.#", "2"),
+					resource.TestCheckTypeSetElemAttr("postgresql_import_foreign_schema.test", "imported_tables.*", "first"),
+					resource.TestCheckTypeSetElemAttr("postgresql_import_foreign_schema.test", "imported_tables.*", "second"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlImportForeignSchemaDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_import_foreign_schema" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		tables, err := listServerForeignTables(txn, rs.Primary.Attributes["schema"], rs.Primary.Attributes["server"])
+		if err != nil {
+			return fmt.Errorf("Error checking imported foreign tables %s", err)
+		}
+
+		if len(tables) > 0 {
+			return fmt.Errorf("Imported foreign tables still exist after destroy: %v", tables)
+		}
+	}
+
+	return nil
+}
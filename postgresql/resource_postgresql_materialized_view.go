@@ -0,0 +1,357 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	matViewTablespaceAttr          = "tablespace"
+	matViewWithDataAttr            = "with_data"
+	matViewRefreshTriggersAttr     = "refresh_triggers"
+	matViewRefreshConcurrentlyAttr = "refresh_concurrently"
+)
+
+func resourcePostgreSQLMaterializedView() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLMaterializedViewCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLMaterializedViewRead),
+		Update: PGResourceFunc(resourcePostgreSQLMaterializedViewUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLMaterializedViewDelete),
+		Exists: PGResourceExistsFunc(resourcePostgreSQLMaterializedViewExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			viewNameAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the materialized view",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			viewSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The schema where the materialized view is located. If not specified, the public schema is used",
+			},
+			viewDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the materialized view is located. If not specified, the provider default database is used",
+			},
+			viewQueryAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The SELECT query of the materialized view",
+				ValidateFunc: validation.StringIsNotEmpty,
+
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeViewQuery(old) == normalizeViewQuery(new)
+				},
+			},
+			viewDefinitionAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The query of the materialized view as rewritten by PostgreSQL, used to detect changes made outside of Terraform",
+			},
+			viewOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ROLE which owns the materialized view",
+			},
+			matViewTablespaceAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The tablespace of the materialized view. If not specified, the default tablespace of the database is used",
+			},
+			matViewWithDataAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the materialized view is populated at creation time",
+			},
+			matViewRefreshTriggersAttr: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values that, when changed, will refresh the materialized view",
+			},
+			matViewRefreshConcurrentlyAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the materialized view is refreshed without locking out concurrent selects. It requires a unique index on the materialized view",
+			},
+			viewDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the materialized view",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLMaterializedViewCreate(db *DBConnection, d *schema.ResourceData) error {
+	if err := validateMaterializedViewFeatures(db, d); err != nil {
+		return err
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getViewSchema(d)
+	viewName := d.Get(viewNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	owner := d.Get(viewOwnerAttr).(string)
+	var rolesToGrant []string
+	if owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		if _, err := txn.Exec(createMaterializedViewQuery(d, pgSchema, viewName)); err != nil {
+			return fmt.Errorf("could not create materialized view %s: %w", viewName, err)
+		}
+
+		return setViewOwner(txn, "MATERIALIZED VIEW", pgSchema, viewName, owner)
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateViewID(database, pgSchema, viewName))
+
+	return resourcePostgreSQLMaterializedViewReadImpl(db, d, true)
+}
+
+func resourcePostgreSQLMaterializedViewExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	if !db.featureSupported(featureMaterializedView) {
+		return false, fmt.Errorf(
+			"postgresql_materialized_view resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return viewExists(db, d, "m")
+}
+
+func resourcePostgreSQLMaterializedViewRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureMaterializedView) {
+		return fmt.Errorf(
+			"postgresql_materialized_view resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return resourcePostgreSQLMaterializedViewReadImpl(db, d, false)
+}
+
+func resourcePostgreSQLMaterializedViewReadImpl(db *DBConnection, d *schema.ResourceData, refreshDefinition bool) error {
+	database, pgSchema, viewName, err := getDBViewName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var viewOwner, viewDefinition, viewTablespace string
+	err = txn.QueryRow(`
+SELECT pg_catalog.pg_get_userbyid(c.relowner), pg_catalog.pg_get_viewdef(c.oid), COALESCE(t.spcname, '')
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+  LEFT JOIN pg_catalog.pg_tablespace t ON t.oid = c.reltablespace
+ WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind = 'm'
+`, pgSchema, viewName).Scan(&viewOwner, &viewDefinition, &viewTablespace)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL materialized view (%s) not found in database %s", viewName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading materialized view: %w", err)
+	}
+
+	setViewQuery(d, viewDefinition, refreshDefinition)
+	d.Set(viewNameAttr, viewName)
+	d.Set(viewSchemaAttr, pgSchema)
+	d.Set(viewDatabaseAttr, database)
+	d.Set(viewOwnerAttr, viewOwner)
+	d.Set(matViewTablespaceAttr, viewTablespace)
+	d.SetId(generateViewID(database, pgSchema, viewName))
+
+	return nil
+}
+
+func resourcePostgreSQLMaterializedViewUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if err := validateMaterializedViewFeatures(db, d); err != nil {
+		return err
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getViewSchema(d)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	owner := d.Get(viewOwnerAttr).(string)
+	if d.HasChange(viewOwnerAttr) {
+		oldOwner, _ := d.GetChange(viewOwnerAttr)
+		owner = oldOwner.(string)
+	}
+	var rolesToGrant []string
+	if owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		if err := setViewName(txn, d, "MATERIALIZED VIEW", pgSchema); err != nil {
+			return err
+		}
+
+		viewName := d.Get(viewNameAttr).(string)
+
+		// A materialized view cannot be replaced in place, it's dropped and created again
+		// with the views depending on it.
+		recreated := d.HasChange(viewQueryAttr)
+		if recreated {
+			if err := recreateWithDependentViews(txn, "m", pgSchema, viewName, func() error {
+				if _, err := txn.Exec(fmt.Sprintf("DROP MATERIALIZED VIEW %s CASCADE", tableIdentifier(pgSchema, viewName))); err != nil {
+					return fmt.Errorf("could not drop materialized view %s: %w", viewName, err)
+				}
+				if _, err := txn.Exec(createMaterializedViewQuery(d, pgSchema, viewName)); err != nil {
+					return fmt.Errorf("could not create materialized view %s: %w", viewName, err)
+				}
+				return setViewOwner(txn, "MATERIALIZED VIEW", pgSchema, viewName, d.Get(viewOwnerAttr).(string))
+			}); err != nil {
+				return err
+			}
+		}
+
+		if d.HasChange(matViewTablespaceAttr) {
+			if tablespace := d.Get(matViewTablespaceAttr).(string); tablespace != "" {
+				query := fmt.Sprintf(
+					"ALTER MATERIALIZED VIEW %s SET TABLESPACE %s",
+					tableIdentifier(pgSchema, viewName), pq.QuoteIdentifier(tablespace),
+				)
+				if _, err := txn.Exec(query); err != nil {
+					return fmt.Errorf("Error updating materialized view tablespace: %w", err)
+				}
+			}
+		}
+
+		// A recreated materialized view is already populated according to with_data.
+		if !recreated && d.HasChanges(matViewRefreshTriggersAttr, matViewWithDataAttr) {
+			withData := d.Get(matViewWithDataAttr).(bool)
+			// CONCURRENTLY cannot be used if the view is not populated yet.
+			concurrently := d.Get(matViewRefreshConcurrentlyAttr).(bool) && !d.HasChange(matViewWithDataAttr)
+
+			if _, err := txn.Exec(refreshMaterializedViewQuery(pgSchema, viewName, withData, concurrently)); err != nil {
+				return fmt.Errorf("could not refresh materialized view %s: %w", viewName, err)
+			}
+		}
+
+		if d.HasChange(viewOwnerAttr) {
+			return setViewOwner(txn, "MATERIALIZED VIEW", pgSchema, viewName, d.Get(viewOwnerAttr).(string))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourcePostgreSQLMaterializedViewReadImpl(db, d, true)
+}
+
+func resourcePostgreSQLMaterializedViewDelete(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureMaterializedView) {
+		return fmt.Errorf(
+			"postgresql_materialized_view resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return dropView(db, d, "MATERIALIZED VIEW")
+}
+
+func createMaterializedViewQuery(d *schema.ResourceData, pgSchema, viewName string) string {
+	b := bytes.NewBufferString("CREATE MATERIALIZED VIEW ")
+	fmt.Fprint(b, tableIdentifier(pgSchema, viewName))
+
+	if tablespace, ok := d.GetOk(matViewTablespaceAttr); ok {
+		fmt.Fprint(b, " TABLESPACE ", pq.QuoteIdentifier(tablespace.(string)))
+	}
+
+	fmt.Fprint(b, " AS ", normalizeViewQuery(d.Get(viewQueryAttr).(string)))
+
+	if d.Get(matViewWithDataAttr).(bool) {
+		b.WriteString(" WITH DATA")
+	} else {
+		b.WriteString(" WITH NO DATA")
+	}
+
+	return b.String()
+}
+
+func refreshMaterializedViewQuery(pgSchema, viewName string, withData, concurrently bool) string {
+	b := bytes.NewBufferString("REFRESH MATERIALIZED VIEW ")
+
+	// CONCURRENTLY cannot be used with WITH NO DATA.
+	if withData && concurrently {
+		b.WriteString("CONCURRENTLY ")
+	}
+
+	fmt.Fprint(b, tableIdentifier(pgSchema, viewName))
+
+	if !withData {
+		b.WriteString(" WITH NO DATA")
+	}
+
+	return b.String()
+}
+
+func validateMaterializedViewFeatures(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureMaterializedView) {
+		return fmt.Errorf(
+			"postgresql_materialized_view resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+	if d.Get(matViewRefreshConcurrentlyAttr).(bool) && !db.featureSupported(featureRefreshMaterializedViewConcurrently) {
+		return fmt.Errorf(
+			"refresh_concurrently is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMaterializedViewQuery(t *testing.T) {
+	cases := []struct {
+		resource map[string]interface{}
+		expected string
+	}{
+		{
+			resource: map[string]interface{}{
+				"name":  "m",
+				"query": "SELECT 1",
+			},
+			expected: `CREATE MATERIALIZED VIEW "public"."m" AS SELECT 1 WITH DATA`,
+		},
+		{
+			resource: map[string]interface{}{
+				"name":       "m",
+				"query":      "SELECT 1;",
+				"tablespace": "fast",
+				"with_data":  false,
+			},
+			expected: `CREATE MATERIALIZED VIEW "public"."m" TABLESPACE "fast" AS SELECT 1 WITH NO DATA`,
+		},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourcePostgreSQLMaterializedView().Schema, c.resource)
+		assert.Equal(t, c.expected, createMaterializedViewQuery(d, "public", "m"))
+	}
+}
+
+func TestRefreshMaterializedViewQuery(t *testing.T) {
+	assert.Equal(t, `REFRESH MATERIALIZED VIEW "public"."m"`, refreshMaterializedViewQuery("public", "m", true, false))
+	assert.Equal(t, `REFRESH MATERIALIZED VIEW CONCURRENTLY "public"."m"`, refreshMaterializedViewQuery("public", "m", true, true))
+	assert.Equal(t, `REFRESH MATERIALIZED VIEW "public"."m" WITH NO DATA`, refreshMaterializedViewQuery("public", "m", false, true))
+}
+
+func TestAccPostgresqlMaterializedView_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	config := `
+resource "postgresql_materialized_view" "test" {
+  database  = "%s"
+  name      = "test_matview"
+  query     = "%s"
+  with_data = false
+
+  refresh_triggers = {
+    version = "%s"
+  }
+}
+
+resource "postgresql_view" "dependent" {
+  database = "%s"
+  name     = "dependent_view"
+  query    = "SELECT a FROM ${postgresql_materialized_view.test.name}"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureMaterializedView)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, dbName, "SELECT 1 AS a", "1", dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlViewExists("postgresql_materialized_view.test"),
+					testAccCheckPostgresqlViewExists("postgresql_view.dependent"),
+					resource.TestCheckResourceAttr("postgresql_materialized_view.test", "name", "test_matview"),
+					resource.TestCheckResourceAttr("postgresql_materialized_view.test", "with_data", "false"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, dbName, "SELECT 1 AS a", "2", dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlViewExists("postgresql_materialized_view.test"),
+					resource.TestCheckResourceAttr("postgresql_materialized_view.test", "refresh_triggers.version", "2"),
+				),
+			},
+			{
+				// The query change recreates the materialized view and its dependent view.
+				Config: fmt.Sprintf(config, dbName, "SELECT 2 AS a, 3 AS b", "2", dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlViewExists("postgresql_materialized_view.test"),
+					testAccCheckPostgresqlViewExists("postgresql_view.dependent"),
+					resource.TestCheckResourceAttr("postgresql_materialized_view.test", "query", "SELECT 2 AS a, 3 AS b"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,561 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	objectACLDatabaseAttr      = "database"
+	objectACLSchemaAttr        = "schema"
+	objectACLObjectTypeAttr    = "object_type"
+	objectACLObjectsAttr       = "objects"
+	objectACLGrantAttr         = "grant"
+	objectACLExcludedRolesAttr = "excluded_roles"
+
+	objectACLRoleAttr            = "role"
+	objectACLPrivilegesAttr      = "privileges"
+	objectACLWithGrantOptionAttr = "with_grant_option"
+)
+
+var objectACLObjectTypes = []string{"database", "schema", "table"}
+
+// objectACLQueries return, for each object, its owner and its ACL entries.
+// The ACL is NULL until the privileges of the object are changed, in which case the default privileges apply.
+var objectACLQueries = map[string]string{
+	"database": `
+SELECT d.datname, pg_catalog.pg_get_userbyid(d.datdba), COALESCE(r.rolname, 'public'), a.privilege_type, a.is_grantable
+FROM pg_catalog.pg_database d
+CROSS JOIN LATERAL pg_catalog.aclexplode(COALESCE(d.datacl, pg_catalog.acldefault('d', d.datdba))) a
+LEFT JOIN pg_catalog.pg_roles r ON r.oid = a.grantee
+WHERE d.datname = $1
+`,
+	"schema": `
+SELECT n.nspname, pg_catalog.pg_get_userbyid(n.nspowner), COALESCE(r.rolname, 'public'), a.privilege_type, a.is_grantable
+FROM pg_catalog.pg_namespace n
+CROSS JOIN LATERAL pg_catalog.aclexplode(COALESCE(n.nspacl, pg_catalog.acldefault('n', n.nspowner))) a
+LEFT JOIN pg_catalog.pg_roles r ON r.oid = a.grantee
+WHERE n.nspname = $1
+`,
+	// Views, materialized views, foreign tables and partitioned tables are also granted with ON TABLE.
+	"table": `
+SELECT c.relname, pg_catalog.pg_get_userbyid(c.relowner), COALESCE(r.rolname, 'public'), a.privilege_type, a.is_grantable
+FROM pg_catalog.pg_class c
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+CROSS JOIN LATERAL pg_catalog.aclexplode(COALESCE(c.relacl, pg_catalog.acldefault('r', c.relowner))) a
+LEFT JOIN pg_catalog.pg_roles r ON r.oid = a.grantee
+WHERE n.nspname = $1
+  AND c.relkind IN ('r', 'p', 'v', 'm', 'f')
+  AND (cardinality($2::text[]) = 0 OR c.relname = ANY($2))
+`,
+}
+
+func resourcePostgreSQLObjectACL() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLObjectACLCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLObjectACLRead),
+		Update: PGResourceFunc(resourcePostgreSQLObjectACLUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLObjectACLDelete),
+
+		Schema: map[string]*schema.Schema{
+			objectACLDatabaseAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The database holding the objects, or the database itself when object_type is database",
+			},
+			objectACLSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The schema, or the schema holding the tables when object_type is table",
+			},
+			objectACLObjectTypeAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(objectACLObjectTypes, false),
+				Description:  "The PostgreSQL object type whose ACL is managed (one of: " + strings.Join(objectACLObjectTypes, ", ") + ")",
+			},
+			objectACLObjectsAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "The tables whose ACL is managed (empty means all the tables of the schema)",
+			},
+			objectACLGrantAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The complete list of grantees and their privileges, the privileges of any other role (including PUBLIC) are revoked",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						objectACLRoleAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the role to grant privileges to, public for all roles",
+						},
+						objectACLPrivilegesAttr: {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Description: "The list of privileges to grant",
+						},
+						objectACLWithGrantOptionAttr: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Permit the grant recipient to grant the privileges to others",
+						},
+					},
+				},
+			},
+			objectACLExcludedRolesAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Roles whose privileges are not managed by this resource and never revoked (e.g.: rds_superuser)",
+			},
+		},
+	}
+}
+
+// objectACLGrant holds the privileges of a grantee on an object.
+type objectACLGrant struct {
+	privileges      []string
+	withGrantOption bool
+}
+
+// objectACL holds the owner and the privileges of each grantee on an object.
+type objectACL struct {
+	owner  string
+	grants map[string]*objectACLGrant
+}
+
+func resourcePostgreSQLObjectACLCreate(db *DBConnection, d *schema.ResourceData) error {
+	if err := validateObjectACL(db, d); err != nil {
+		return err
+	}
+
+	if err := setObjectACL(db, d); err != nil {
+		return err
+	}
+
+	d.SetId(generateObjectACLID(d))
+
+	return readObjectACL(db, d)
+}
+
+func resourcePostgreSQLObjectACLRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePrivileges) {
+		return fmt.Errorf(
+			"postgresql_object_acl resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return readObjectACL(db, d)
+}
+
+func resourcePostgreSQLObjectACLUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if err := validateObjectACL(db, d); err != nil {
+		return err
+	}
+
+	if err := setObjectACL(db, d); err != nil {
+		return err
+	}
+
+	return readObjectACL(db, d)
+}
+
+func resourcePostgreSQLObjectACLDelete(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePrivileges) {
+		return fmt.Errorf(
+			"postgresql_object_acl resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	txn, err := startTransaction(db.client, d.Get(objectACLDatabaseAttr).(string))
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	acls, err := getObjectACLs(txn, d)
+	if err != nil {
+		return err
+	}
+
+	// Only the privileges of the declared grantees are revoked,
+	// the ones of the other roles have already been revoked by this resource.
+	declared := objectACLDeclaredGrants(d)
+	if err := withRolesGranted(txn, objectACLOwners(d, acls), func() error {
+		for object, acl := range acls {
+			for grantee := range acl.grants {
+				if _, ok := declared[grantee]; !ok || grantee == acl.owner {
+					continue
+				}
+				if err := revokeObjectACLGrantee(txn, d, object, grantee); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func validateObjectACL(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePrivileges) {
+		return fmt.Errorf(
+			"postgresql_object_acl resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	objectType := d.Get(objectACLObjectTypeAttr).(string)
+	pgSchema := d.Get(objectACLSchemaAttr).(string)
+	switch {
+	case objectType == "database" && pgSchema != "":
+		return fmt.Errorf("cannot specify `schema` when `object_type` is `database`")
+	case objectType != "database" && pgSchema == "":
+		return fmt.Errorf("`schema` is mandatory when `object_type` is `%s`", objectType)
+	case objectType != "table" && d.Get(objectACLObjectsAttr).(*schema.Set).Len() > 0:
+		return fmt.Errorf("cannot specify `objects` when `object_type` is `%s`", objectType)
+	}
+
+	// The privileges are read back one by one from the ACL, so ALL cannot be used.
+	for _, g := range d.Get(objectACLGrantAttr).(*schema.Set).List() {
+		grant := g.(map[string]interface{})
+		for _, priv := range grant[objectACLPrivilegesAttr].(*schema.Set).List() {
+			if priv.(string) == "ALL" || !sliceContainsStr(allowedPrivileges[objectType], priv.(string)) {
+				return fmt.Errorf("%s is not an allowed privilege for object type %s", priv, objectType)
+			}
+		}
+	}
+
+	return nil
+}
+
+// setObjectACL revokes the privileges of the undeclared grantees
+// and grants their exact privileges to the declared ones, on each object.
+func setObjectACL(db *DBConnection, d *schema.ResourceData) error {
+	database := d.Get(objectACLDatabaseAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if d.Get(objectACLObjectTypeAttr).(string) == "database" {
+		if err := pgLockDatabase(txn, database); err != nil {
+			return err
+		}
+	}
+
+	acls, err := getObjectACLs(txn, d)
+	if err != nil {
+		return err
+	}
+
+	declared := objectACLDeclaredGrants(d)
+	excluded := d.Get(objectACLExcludedRolesAttr).(*schema.Set)
+
+	if err := withRolesGranted(txn, objectACLOwners(d, acls), func() error {
+		for _, object := range sortedObjectACLObjects(acls) {
+			acl := acls[object]
+			toRevoke, toGrant := objectACLChanges(acl, declared, interfaceSliceToStrings(excluded.List()))
+
+			for _, grantee := range toRevoke {
+				if err := revokeObjectACLGrantee(txn, d, object, grantee); err != nil {
+					return err
+				}
+			}
+			for _, grantee := range toGrant {
+				query := createObjectACLGrantQuery(d, object, grantee, declared[grantee])
+				log.Printf("[DEBUG] %s", query)
+				if _, err := txn.Exec(query); err != nil {
+					return fmt.Errorf("could not grant privileges on %s to %s: %w", object, grantee, err)
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// objectACLChanges returns the grantees whose privileges need to be revoked (undeclared and not excluded ones,
+// declared ones with different privileges) and the declared grantees to which the privileges need to be granted.
+// The owner of the object is never modified.
+func objectACLChanges(acl *objectACL, declared map[string]*objectACLGrant, excluded []string) ([]string, []string) {
+	toRevoke := []string{}
+	for grantee, current := range acl.grants {
+		if grantee == acl.owner || sliceContainsStr(excluded, grantee) {
+			continue
+		}
+		if expected, ok := declared[grantee]; !ok || !objectACLGrantEqual(current, expected) {
+			toRevoke = append(toRevoke, grantee)
+		}
+	}
+	sort.Strings(toRevoke)
+
+	toGrant := []string{}
+	for grantee, expected := range declared {
+		if grantee == acl.owner || len(expected.privileges) == 0 {
+			continue
+		}
+		if current, ok := acl.grants[grantee]; !ok || !objectACLGrantEqual(current, expected) {
+			toGrant = append(toGrant, grantee)
+		}
+	}
+	sort.Strings(toGrant)
+
+	return toRevoke, toGrant
+}
+
+func objectACLGrantEqual(a, b *objectACLGrant) bool {
+	return a.withGrantOption == b.withGrantOption &&
+		stringSliceToSet(a.privileges).Equal(stringSliceToSet(b.privileges))
+}
+
+func readObjectACL(db *DBConnection, d *schema.ResourceData) error {
+	database := d.Get(objectACLDatabaseAttr).(string)
+
+	exists, err := dbExists(db, database)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Printf("[WARN] PostgreSQL database (%s) for object ACL %s not found", database, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	objectType := d.Get(objectACLObjectTypeAttr).(string)
+	if objectType != "database" {
+		exists, err := schemaExists(txn, d.Get(objectACLSchemaAttr).(string))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			log.Printf("[WARN] PostgreSQL schema (%s) for object ACL %s not found", d.Get(objectACLSchemaAttr), d.Id())
+			d.SetId("")
+			return nil
+		}
+	}
+
+	acls, err := getObjectACLs(txn, d)
+	if err != nil {
+		return err
+	}
+
+	excluded := interfaceSliceToStrings(d.Get(objectACLExcludedRolesAttr).(*schema.Set).List())
+	declared := objectACLDeclaredGrants(d)
+
+	// If any object doesn't have the expected ACL, we return its grants to force an update.
+	grants := d.Get(objectACLGrantAttr).(*schema.Set).List()
+	for _, object := range sortedObjectACLObjects(acls) {
+		toRevoke, toGrant := objectACLChanges(acls[object], declared, excluded)
+		if len(toRevoke) > 0 || len(toGrant) > 0 {
+			log.Printf("[DEBUG] ACL of %s %s has not the expected grants", objectType, object)
+			grants = objectACLToGrants(acls[object], excluded)
+			break
+		}
+	}
+
+	d.Set(objectACLGrantAttr, grants)
+	d.SetId(generateObjectACLID(d))
+
+	return nil
+}
+
+// objectACLToGrants converts the ACL of an object to the grant attribute, ignoring the owner and the excluded roles.
+func objectACLToGrants(acl *objectACL, excluded []string) []interface{} {
+	grants := []interface{}{}
+	for grantee, grant := range acl.grants {
+		if grantee == acl.owner || sliceContainsStr(excluded, grantee) {
+			continue
+		}
+		grants = append(grants, map[string]interface{}{
+			objectACLRoleAttr:            grantee,
+			objectACLPrivilegesAttr:      stringSliceToSet(grant.privileges),
+			objectACLWithGrantOptionAttr: grant.withGrantOption,
+		})
+	}
+	return grants
+}
+
+func objectACLDeclaredGrants(d *schema.ResourceData) map[string]*objectACLGrant {
+	declared := map[string]*objectACLGrant{}
+	for _, g := range d.Get(objectACLGrantAttr).(*schema.Set).List() {
+		grant := g.(map[string]interface{})
+		declared[grant[objectACLRoleAttr].(string)] = &objectACLGrant{
+			privileges:      interfaceSliceToStrings(grant[objectACLPrivilegesAttr].(*schema.Set).List()),
+			withGrantOption: grant[objectACLWithGrantOptionAttr].(bool),
+		}
+	}
+	return declared
+}
+
+// getObjectACLs returns the ACL of each managed object.
+func getObjectACLs(txn *sql.Tx, d *schema.ResourceData) (map[string]*objectACL, error) {
+	objectType := d.Get(objectACLObjectTypeAttr).(string)
+
+	var rows *sql.Rows
+	var err error
+	switch objectType {
+	case "database":
+		rows, err = txn.Query(objectACLQueries[objectType], d.Get(objectACLDatabaseAttr).(string))
+	case "schema":
+		rows, err = txn.Query(objectACLQueries[objectType], d.Get(objectACLSchemaAttr).(string))
+	case "table":
+		rows, err = txn.Query(
+			objectACLQueries[objectType], d.Get(objectACLSchemaAttr).(string),
+			pq.Array(interfaceSliceToStrings(d.Get(objectACLObjectsAttr).(*schema.Set).List())),
+		)
+	default:
+		return nil, fmt.Errorf("unknown object type %s", objectType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read ACL of %s: %w", objectType, err)
+	}
+	defer rows.Close()
+
+	acls := map[string]*objectACL{}
+	for rows.Next() {
+		var object, owner, grantee, privilege string
+		var grantable bool
+		if err := rows.Scan(&object, &owner, &grantee, &privilege, &grantable); err != nil {
+			return nil, fmt.Errorf("could not scan ACL of %s: %w", objectType, err)
+		}
+
+		acl, ok := acls[object]
+		if !ok {
+			acl = &objectACL{owner: owner, grants: map[string]*objectACLGrant{}}
+			acls[object] = acl
+		}
+		grant, ok := acl.grants[grantee]
+		if !ok {
+			grant = &objectACLGrant{}
+			acl.grants[grantee] = grant
+		}
+		if !sliceContainsStr(grant.privileges, privilege) {
+			grant.privileges = append(grant.privileges, privilege)
+		}
+		// Privileges granted with and without grant option cannot be represented,
+		// so any privilege with grant option is reported as such to detect it.
+		grant.withGrantOption = grant.withGrantOption || grantable
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not read ACL of %s: %w", objectType, err)
+	}
+
+	return acls, nil
+}
+
+// objectACLOwners returns the owners of the objects, which need to be granted to the current user
+// to change their privileges if it's not a superuser.
+func objectACLOwners(d *schema.ResourceData, acls map[string]*objectACL) []string {
+	owners := []string{}
+	for _, acl := range acls {
+		if !sliceContainsStr(owners, acl.owner) {
+			owners = append(owners, acl.owner)
+		}
+	}
+	sort.Strings(owners)
+	return owners
+}
+
+func sortedObjectACLObjects(acls map[string]*objectACL) []string {
+	objects := make([]string, 0, len(acls))
+	for object := range acls {
+		objects = append(objects, object)
+	}
+	sort.Strings(objects)
+	return objects
+}
+
+// objectACLTarget returns the object on which privileges are granted, e.g.: TABLE "schema"."table".
+func objectACLTarget(d *schema.ResourceData, object string) string {
+	switch d.Get(objectACLObjectTypeAttr).(string) {
+	case "database":
+		return "DATABASE " + pq.QuoteIdentifier(object)
+	case "schema":
+		return "SCHEMA " + pq.QuoteIdentifier(object)
+	default:
+		return fmt.Sprintf("TABLE %s.%s", pq.QuoteIdentifier(d.Get(objectACLSchemaAttr).(string)), pq.QuoteIdentifier(object))
+	}
+}
+
+func createObjectACLGrantQuery(d *schema.ResourceData, object, grantee string, grant *objectACLGrant) string {
+	privileges := append([]string{}, grant.privileges...)
+	sort.Strings(privileges)
+
+	query := fmt.Sprintf(
+		"GRANT %s ON %s TO %s",
+		strings.Join(privileges, ","), objectACLTarget(d, object), pq.QuoteIdentifier(grantee),
+	)
+	if grant.withGrantOption {
+		query += " WITH GRANT OPTION"
+	}
+	return query
+}
+
+func revokeObjectACLGrantee(txn *sql.Tx, d *schema.ResourceData, object, grantee string) error {
+	// CASCADE also revokes the privileges the grantee granted to others with the grant option.
+	query := fmt.Sprintf(
+		"REVOKE ALL PRIVILEGES ON %s FROM %s CASCADE", objectACLTarget(d, object), pq.QuoteIdentifier(grantee),
+	)
+	log.Printf("[DEBUG] %s", query)
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("could not revoke privileges on %s from %s: %w", object, grantee, err)
+	}
+	return nil
+}
+
+func generateObjectACLID(d *schema.ResourceData) string {
+	parts := []string{d.Get(objectACLDatabaseAttr).(string)}
+	if pgSchema := d.Get(objectACLSchemaAttr).(string); pgSchema != "" {
+		parts = append(parts, pgSchema)
+	}
+	parts = append(parts, d.Get(objectACLObjectTypeAttr).(string))
+
+	objects := interfaceSliceToStrings(d.Get(objectACLObjectsAttr).(*schema.Set).List())
+	sort.Strings(objects)
+	parts = append(parts, objects...)
+
+	return strings.Join(parts, "_")
+}
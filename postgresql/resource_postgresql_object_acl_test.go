@@ -0,0 +1,281 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectACLChanges(t *testing.T) {
+	acl := &objectACL{
+		owner: "owner",
+		grants: map[string]*objectACLGrant{
+			"owner":         {privileges: []string{"SELECT", "INSERT"}, withGrantOption: true},
+			"public":        {privileges: []string{"SELECT"}},
+			"alice":         {privileges: []string{"INSERT", "SELECT"}},
+			"bob":           {privileges: []string{"SELECT"}},
+			"carol":         {privileges: []string{"SELECT"}, withGrantOption: true},
+			"rds_superuser": {privileges: []string{"SELECT"}},
+		},
+	}
+	declared := map[string]*objectACLGrant{
+		"alice": {privileges: []string{"SELECT", "INSERT"}},
+		"bob":   {privileges: []string{"SELECT", "UPDATE"}},
+		"carol": {privileges: []string{"SELECT"}},
+		"dave":  {privileges: []string{"SELECT"}},
+	}
+
+	toRevoke, toGrant := objectACLChanges(acl, declared, []string{"rds_superuser"})
+	assert.Equal(t, []string{"bob", "carol", "public"}, toRevoke)
+	assert.Equal(t, []string{"bob", "carol", "dave"}, toGrant)
+
+	toRevoke, toGrant = objectACLChanges(&objectACL{owner: "owner", grants: map[string]*objectACLGrant{}}, nil, nil)
+	assert.Empty(t, toRevoke)
+	assert.Empty(t, toGrant)
+}
+
+func TestObjectACLOwners(t *testing.T) {
+	acls := map[string]*objectACL{
+		"test_db": {owner: "db_owner", grants: map[string]*objectACLGrant{}},
+	}
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLObjectACL().Schema, map[string]interface{}{
+		"database":    "test_db",
+		"object_type": "database",
+	})
+	assert.Equal(t, []string{"db_owner"}, objectACLOwners(d, acls))
+
+	acls = map[string]*objectACL{
+		"t1": {owner: "owner_b", grants: map[string]*objectACLGrant{}},
+		"t2": {owner: "owner_a", grants: map[string]*objectACLGrant{}},
+		"t3": {owner: "owner_b", grants: map[string]*objectACLGrant{}},
+	}
+	d = schema.TestResourceDataRaw(t, resourcePostgreSQLObjectACL().Schema, map[string]interface{}{
+		"database":    "test_db",
+		"schema":      "test_schema",
+		"object_type": "table",
+	})
+	assert.Equal(t, []string{"owner_a", "owner_b"}, objectACLOwners(d, acls))
+}
+
+func TestCreateObjectACLGrantQuery(t *testing.T) {
+	cases := []struct {
+		resource map[string]interface{}
+		object   string
+		grant    *objectACLGrant
+		expected string
+	}{
+		{
+			resource: map[string]interface{}{"database": "test_db", "object_type": "database"},
+			object:   "test_db",
+			grant:    &objectACLGrant{privileges: []string{"TEMPORARY", "CONNECT"}},
+			expected: `GRANT CONNECT,TEMPORARY ON DATABASE "test_db" TO "test_role"`,
+		},
+		{
+			resource: map[string]interface{}{"database": "test_db", "schema": "test_schema", "object_type": "schema"},
+			object:   "test_schema",
+			grant:    &objectACLGrant{privileges: []string{"USAGE"}, withGrantOption: true},
+			expected: `GRANT USAGE ON SCHEMA "test_schema" TO "test_role" WITH GRANT OPTION`,
+		},
+		{
+			resource: map[string]interface{}{"database": "test_db", "schema": "test_schema", "object_type": "table"},
+			object:   "test_table",
+			grant:    &objectACLGrant{privileges: []string{"SELECT"}},
+			expected: `GRANT SELECT ON TABLE "test_schema"."test_table" TO "test_role"`,
+		},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourcePostgreSQLObjectACL().Schema, c.resource)
+		assert.Equal(t, c.expected, createObjectACLGrantQuery(d, c.object, "test_role", c.grant))
+	}
+}
+
+func TestGenerateObjectACLID(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLObjectACL().Schema, map[string]interface{}{
+		"database":    "test_db",
+		"schema":      "test_schema",
+		"object_type": "table",
+		"objects":     []interface{}{"table2", "table1"},
+	})
+	assert.Equal(t, "test_db_test_schema_table_table1_table2", generateObjectACLID(d))
+}
+
+func TestAccPostgresqlObjectACL_Tables(t *testing.T) {
+	skipIfNotAcc(t)
+
+	// We have to create the database outside of resource.Test
+	// because we need to create tables to assert that the ACL are applied.
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	testTables := []string{"test_schema.test_table", "test_schema.test_table2"}
+	createTestTables(t, dbSuffix, testTables, "")
+
+	config := getTestConfig(t)
+	dbName, roleName := getTestDBNames(dbSuffix)
+
+	resourceConfig := `
+resource "postgresql_object_acl" "test" {
+  database    = "%s"
+  schema      = "test_schema"
+  object_type = "table"
+
+  grant {
+    role       = "%s"
+    privileges = [%s]
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(resourceConfig, dbName, roleName, `"SELECT"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_object_acl.test", "grant.#", "1"),
+					func(*terraform.State) error {
+						return testCheckTablesPrivileges(t, dbName, roleName, testTables, []string{"SELECT"})
+					},
+				),
+			},
+			{
+				// Privileges granted outside of Terraform are revoked.
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), fmt.Sprintf("GRANT INSERT ON test_schema.test_table2 TO %s", roleName))
+				},
+				Config: fmt.Sprintf(resourceConfig, dbName, roleName, `"SELECT", "UPDATE"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_object_acl.test", "grant.#", "1"),
+					func(*terraform.State) error {
+						return testCheckTablesPrivileges(t, dbName, roleName, testTables, []string{"SELECT", "UPDATE"})
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlObjectACL_Schema(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	config := getTestConfig(t)
+	dbName, roleName := getTestDBNames(dbSuffix)
+
+	resourceConfig := fmt.Sprintf(`
+resource "postgresql_object_acl" "test" {
+  database    = "%s"
+  schema      = "test_schema"
+  object_type = "schema"
+
+  grant {
+    role       = "%s"
+    privileges = ["USAGE"]
+  }
+}
+`, dbName, roleName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// PUBLIC is granted USAGE and CREATE on the schema outside of Terraform.
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), "GRANT ALL ON SCHEMA test_schema TO PUBLIC")
+				},
+				Config: resourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_object_acl.test", "grant.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("postgresql_object_acl.test", "grant.*", map[string]string{"role": roleName}),
+					func(*terraform.State) error {
+						return testCheckSchemasPrivileges(t, dbName, roleName, []string{"test_schema"}, []string{"USAGE"})
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlObjectACL_DatabaseOwner(t *testing.T) {
+	skipIfNotAcc(t)
+
+	// The database is owned by a role which is not a superuser,
+	// which needs to be granted to the provider user to revoke the privileges of PUBLIC.
+	// It's created first so it's dropped after the database.
+	ownerName := "test_object_acl_db_owner"
+	teardownOwner := createTestRole(t, ownerName)
+	defer teardownOwner()
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	config := getTestConfig(t)
+	dbName, roleName := getTestDBNames(dbSuffix)
+
+	resourceConfig := fmt.Sprintf(`
+resource "postgresql_object_acl" "test" {
+  database    = "%s"
+  object_type = "database"
+
+  grant {
+    role       = "%s"
+    privileges = ["CONNECT"]
+  }
+}
+`, dbName, roleName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					dbExecute(t, config.connStr("postgres"), fmt.Sprintf("ALTER DATABASE %s OWNER TO %s", dbName, ownerName))
+				},
+				Config: resourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_object_acl.test", "grant.#", "1"),
+					testAccCheckPublicDatabasePrivilege(config.connStr("postgres"), dbName, "CONNECT", false),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPublicDatabasePrivilege(dsn, dbName, privilege string, expected bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		db, err := sql.Open("postgres", dsn)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		var granted bool
+		if err := db.QueryRow("SELECT has_database_privilege('public', $1, $2)", dbName, privilege).Scan(&granted); err != nil {
+			return fmt.Errorf("could not check %s privilege of PUBLIC on database %s: %w", privilege, dbName, err)
+		}
+		if granted != expected {
+			return fmt.Errorf("expected PUBLIC %s privilege on database %s to be %t, got %t", privilege, dbName, expected, granted)
+		}
+		return nil
+	}
+}
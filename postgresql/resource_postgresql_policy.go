@@ -0,0 +1,546 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	policyNameAttr             = "name"
+	policyTableAttr            = "table"
+	policySchemaAttr           = "schema"
+	policyDatabaseAttr         = "database"
+	policyCommandAttr          = "command"
+	policyAsAttr               = "as"
+	policyRolesAttr            = "roles"
+	policyUsingAttr            = "using"
+	policyWithCheckAttr        = "with_check"
+	policyRowLevelSecurityAttr = "row_level_security"
+)
+
+var (
+	policyCommands = []string{"ALL", "SELECT", "INSERT", "UPDATE", "DELETE"}
+
+	// Mapping of pg_policy.polcmd to the command keyword.
+	policyCommandCodes = map[string]string{
+		"*": "ALL",
+		"r": "SELECT",
+		"a": "INSERT",
+		"w": "UPDATE",
+		"d": "DELETE",
+	}
+)
+
+func resourcePostgreSQLPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLPolicyCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLPolicyRead),
+		Update: PGResourceFunc(resourcePostgreSQLPolicyUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLPolicyDelete),
+		Exists: PGResourceExistsFunc(resourcePostgreSQLPolicyExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			policyNameAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the policy",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			policyTableAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The table to which the policy applies",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			policySchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The schema of the table. If not specified, the public schema is used",
+			},
+			policyDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database of the table. If not specified, the provider default database is used",
+			},
+			policyCommandAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ALL",
+				ForceNew:     true,
+				Description:  "The command to which the policy applies. One of: " + strings.Join(policyCommands, ", "),
+				ValidateFunc: validation.StringInSlice(policyCommands, false),
+			},
+			policyAsAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "PERMISSIVE",
+				ForceNew:     true,
+				Description:  "Whether the policy is PERMISSIVE or RESTRICTIVE",
+				ValidateFunc: validation.StringInSlice([]string{"PERMISSIVE", "RESTRICTIVE"}, false),
+			},
+			policyRolesAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "The roles to which the policy applies. If not specified, the policy applies to PUBLIC",
+			},
+			policyUsingAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The USING expression of the policy, checked against existing rows",
+
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeTableExpression(old) == normalizeTableExpression(new)
+				},
+			},
+			policyWithCheckAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The WITH CHECK expression of the policy, checked against new rows",
+
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeTableExpression(old) == normalizeTableExpression(new)
+				},
+			},
+			policyRowLevelSecurityAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Enables (ENABLE) or forces (FORCE) row level security on the table. If not specified, it is only read",
+				ValidateFunc: validation.StringInSlice([]string{"", "ENABLE", "FORCE"}, false),
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLPolicyCreate(db *DBConnection, d *schema.ResourceData) error {
+	if err := validatePolicyFeatures(db, d); err != nil {
+		return err
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getPolicySchema(d)
+	tableName := d.Get(policyTableAttr).(string)
+	policyName := d.Get(policyNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(createPolicyQuery(d, pgSchema)); err != nil {
+		return fmt.Errorf("could not create policy %s on table %s: %w", policyName, tableName, err)
+	}
+
+	if err := setPolicyRowLevelSecurity(txn, d, pgSchema); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generatePolicyID(database, pgSchema, tableName, policyName))
+
+	return resourcePostgreSQLPolicyReadImpl(db, d)
+}
+
+func resourcePostgreSQLPolicyExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	if !db.featureSupported(featureRLS) {
+		return false, fmt.Errorf(
+			"postgresql_policy resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database, pgSchema, tableName, policyName, err := getDBPolicyName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var _rez int
+	err = txn.QueryRow(`
+SELECT 1
+  FROM pg_catalog.pg_policy p
+  JOIN pg_catalog.pg_class c ON c.oid = p.polrelid
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relname = $2 AND p.polname = $3
+`, pgSchema, tableName, policyName).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading policy: %w", err)
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLPolicyRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureRLS) {
+		return fmt.Errorf(
+			"postgresql_policy resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return resourcePostgreSQLPolicyReadImpl(db, d)
+}
+
+func resourcePostgreSQLPolicyReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, pgSchema, tableName, policyName, err := getDBPolicyName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	permissiveColumn := "true"
+	if db.featureSupported(featureRestrictivePolicy) {
+		permissiveColumn = "p.polpermissive"
+	}
+
+	var command, using, withCheck string
+	var permissive, rlsEnabled, rlsForced bool
+	var roles []string
+	err = txn.QueryRow(fmt.Sprintf(`
+SELECT p.polcmd::TEXT, %s,
+       ARRAY(
+           SELECT CASE WHEN r = 0 THEN 'public' ELSE pg_catalog.pg_get_userbyid(r) END
+             FROM unnest(p.polroles) AS r
+       )::TEXT[],
+       COALESCE(pg_catalog.pg_get_expr(p.polqual, p.polrelid), ''),
+       COALESCE(pg_catalog.pg_get_expr(p.polwithcheck, p.polrelid), ''),
+       c.relrowsecurity, c.relforcerowsecurity
+  FROM pg_catalog.pg_policy p
+  JOIN pg_catalog.pg_class c ON c.oid = p.polrelid
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relname = $2 AND p.polname = $3
+`, permissiveColumn), pgSchema, tableName, policyName).Scan(
+		&command, &permissive, pq.Array(&roles), &using, &withCheck, &rlsEnabled, &rlsForced,
+	)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL policy (%s) on table %s not found in database %s", policyName, tableName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading policy: %w", err)
+	}
+
+	// A policy without roles applies to PUBLIC.
+	if len(roles) == 1 && roles[0] == "public" && !d.Get(policyRolesAttr).(*schema.Set).Contains("public") {
+		roles = []string{}
+	}
+
+	as := "PERMISSIVE"
+	if !permissive {
+		as = "RESTRICTIVE"
+	}
+
+	d.Set(policyNameAttr, policyName)
+	d.Set(policyTableAttr, tableName)
+	d.Set(policySchemaAttr, pgSchema)
+	d.Set(policyDatabaseAttr, database)
+	d.Set(policyCommandAttr, policyCommandCodes[command])
+	d.Set(policyAsAttr, as)
+	d.Set(policyRolesAttr, stringSliceToSet(roles))
+	d.Set(policyUsingAttr, using)
+	d.Set(policyWithCheckAttr, withCheck)
+
+	// Row level security is always read so it's imported and disabling it
+	// outside of Terraform is detected when it's managed by this resource.
+	switch {
+	case rlsEnabled && rlsForced:
+		d.Set(policyRowLevelSecurityAttr, "FORCE")
+	case rlsEnabled:
+		d.Set(policyRowLevelSecurityAttr, "ENABLE")
+	default:
+		d.Set(policyRowLevelSecurityAttr, "")
+	}
+
+	d.SetId(generatePolicyID(database, pgSchema, tableName, policyName))
+
+	return nil
+}
+
+func resourcePostgreSQLPolicyUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if err := validatePolicyFeatures(db, d); err != nil {
+		return err
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getPolicySchema(d)
+	tableName := d.Get(policyTableAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := setPolicyName(txn, d, pgSchema); err != nil {
+		return err
+	}
+
+	policyName := d.Get(policyNameAttr).(string)
+
+	// ALTER POLICY cannot remove an expression, the policy has to be created again.
+	if policyExpressionRemoved(d, policyUsingAttr) || policyExpressionRemoved(d, policyWithCheckAttr) {
+		query := fmt.Sprintf("DROP POLICY %s ON %s", pq.QuoteIdentifier(policyName), tableIdentifier(pgSchema, tableName))
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not drop policy %s: %w", policyName, err)
+		}
+		if _, err := txn.Exec(createPolicyQuery(d, pgSchema)); err != nil {
+			return fmt.Errorf("could not create policy %s: %w", policyName, err)
+		}
+	} else if d.HasChanges(policyRolesAttr, policyUsingAttr, policyWithCheckAttr) {
+		if _, err := txn.Exec(alterPolicyQuery(d, pgSchema)); err != nil {
+			return fmt.Errorf("could not alter policy %s: %w", policyName, err)
+		}
+	}
+
+	if d.HasChange(policyRowLevelSecurityAttr) {
+		if err := setPolicyRowLevelSecurity(txn, d, pgSchema); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourcePostgreSQLPolicyReadImpl(db, d)
+}
+
+func resourcePostgreSQLPolicyDelete(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureRLS) {
+		return fmt.Errorf(
+			"postgresql_policy resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database, pgSchema, tableName, policyName, err := getDBPolicyName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	query := fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", pq.QuoteIdentifier(policyName), tableIdentifier(pgSchema, tableName))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("could not drop policy %s: %w", policyName, err)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func createPolicyQuery(d *schema.ResourceData, pgSchema string) string {
+	b := bytes.NewBufferString("CREATE POLICY ")
+	fmt.Fprint(b,
+		pq.QuoteIdentifier(d.Get(policyNameAttr).(string)),
+		" ON ", tableIdentifier(pgSchema, d.Get(policyTableAttr).(string)),
+	)
+
+	// AS is only supported by Postgres >= 10 so it's not added for the default value.
+	if as := d.Get(policyAsAttr).(string); as != "PERMISSIVE" {
+		fmt.Fprint(b, " AS ", as)
+	}
+
+	fmt.Fprint(b, " FOR ", d.Get(policyCommandAttr).(string))
+	fmt.Fprint(b, " TO ", policyRolesList(d))
+
+	if using := d.Get(policyUsingAttr).(string); using != "" {
+		fmt.Fprintf(b, " USING (%s)", using)
+	}
+	if withCheck := d.Get(policyWithCheckAttr).(string); withCheck != "" {
+		fmt.Fprintf(b, " WITH CHECK (%s)", withCheck)
+	}
+
+	return b.String()
+}
+
+func alterPolicyQuery(d *schema.ResourceData, pgSchema string) string {
+	b := bytes.NewBufferString("ALTER POLICY ")
+	fmt.Fprint(b,
+		pq.QuoteIdentifier(d.Get(policyNameAttr).(string)),
+		" ON ", tableIdentifier(pgSchema, d.Get(policyTableAttr).(string)),
+	)
+
+	fmt.Fprint(b, " TO ", policyRolesList(d))
+
+	if using := d.Get(policyUsingAttr).(string); using != "" {
+		fmt.Fprintf(b, " USING (%s)", using)
+	}
+	if withCheck := d.Get(policyWithCheckAttr).(string); withCheck != "" {
+		fmt.Fprintf(b, " WITH CHECK (%s)", withCheck)
+	}
+
+	return b.String()
+}
+
+func policyRolesList(d *schema.ResourceData) string {
+	roles := d.Get(policyRolesAttr).(*schema.Set).List()
+	if len(roles) == 0 {
+		return "PUBLIC"
+	}
+
+	quotedRoles := make([]string, len(roles))
+	for i, role := range roles {
+		if strings.ToLower(role.(string)) == "public" {
+			quotedRoles[i] = "PUBLIC"
+			continue
+		}
+		quotedRoles[i] = pq.QuoteIdentifier(role.(string))
+	}
+	return strings.Join(quotedRoles, ", ")
+}
+
+func policyExpressionRemoved(d *schema.ResourceData, attr string) bool {
+	if !d.HasChange(attr) {
+		return false
+	}
+	o, n := d.GetChange(attr)
+	return o.(string) != "" && n.(string) == ""
+}
+
+func setPolicyName(txn *sql.Tx, d *schema.ResourceData, pgSchema string) error {
+	if !d.HasChange(policyNameAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(policyNameAttr)
+	o := oraw.(string)
+	n := nraw.(string)
+	if n == "" {
+		return errors.New("Error setting policy name to an empty string")
+	}
+
+	tableName := d.Get(policyTableAttr).(string)
+	query := fmt.Sprintf(
+		"ALTER POLICY %s ON %s RENAME TO %s",
+		pq.QuoteIdentifier(o), tableIdentifier(pgSchema, tableName), pq.QuoteIdentifier(n),
+	)
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating policy name: %w", err)
+	}
+
+	d.SetId(generatePolicyID(getDatabase(d, ""), pgSchema, tableName, n))
+
+	return nil
+}
+
+func setPolicyRowLevelSecurity(txn *sql.Tx, d *schema.ResourceData, pgSchema string) error {
+	rls := d.Get(policyRowLevelSecurityAttr).(string)
+	if rls == "" {
+		return nil
+	}
+
+	force := "NO FORCE"
+	if rls == "FORCE" {
+		force = "FORCE"
+	}
+
+	table := tableIdentifier(pgSchema, d.Get(policyTableAttr).(string))
+	query := fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY, %s ROW LEVEL SECURITY", table, force)
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("could not set row level security on table %s: %w", table, err)
+	}
+
+	return nil
+}
+
+func validatePolicyFeatures(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureRLS) {
+		return fmt.Errorf(
+			"postgresql_policy resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+	if d.Get(policyAsAttr).(string) == "RESTRICTIVE" && !db.featureSupported(featureRestrictivePolicy) {
+		return fmt.Errorf(
+			"restrictive policies are not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+	return nil
+}
+
+func getPolicySchema(d *schema.ResourceData) string {
+	if v, ok := d.GetOk(policySchemaAttr); ok {
+		return v.(string)
+	}
+	return "public"
+}
+
+func generatePolicyID(database, pgSchema, tableName, policyName string) string {
+	return strings.Join([]string{database, pgSchema, tableName, policyName}, ".")
+}
+
+// getDBPolicyName returns database, schema, table and policy name. If we are importing this resource,
+// they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBPolicyName(d *schema.ResourceData, client *Client) (string, string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	pgSchema := getPolicySchema(d)
+	tableName := d.Get(policyTableAttr).(string)
+	policyName := d.Get(policyNameAttr).(string)
+
+	// When importing, we have to parse the ID to find database, schema, table and policy names.
+	if policyName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 4 {
+			return "", "", "", "", fmt.Errorf("policy ID %s has not the expected format 'database.schema.table.policy': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		pgSchema = parsed[1]
+		tableName = parsed[2]
+		policyName = parsed[3]
+	}
+	return database, pgSchema, tableName, policyName, nil
+}
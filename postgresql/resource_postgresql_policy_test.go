@@ -0,0 +1,207 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePolicyQuery(t *testing.T) {
+	cases := []struct {
+		resource map[string]interface{}
+		expected string
+	}{
+		{
+			resource: map[string]interface{}{
+				"name":  "p",
+				"table": "t",
+				"using": "owner = current_user",
+			},
+			expected: `CREATE POLICY "p" ON "public"."t" FOR ALL TO PUBLIC USING (owner = current_user)`,
+		},
+		{
+			resource: map[string]interface{}{
+				"name":       "p",
+				"table":      "t",
+				"as":         "RESTRICTIVE",
+				"command":    "INSERT",
+				"roles":      []interface{}{"app"},
+				"with_check": "tenant_id = 1",
+			},
+			expected: `CREATE POLICY "p" ON "public"."t" AS RESTRICTIVE FOR INSERT TO "app" WITH CHECK (tenant_id = 1)`,
+		},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourcePostgreSQLPolicy().Schema, c.resource)
+		assert.Equal(t, c.expected, createPolicyQuery(d, "public"))
+	}
+}
+
+func TestAlterPolicyQuery(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLPolicy().Schema, map[string]interface{}{
+		"name":       "p",
+		"table":      "t",
+		"roles":      []interface{}{"public"},
+		"using":      "true",
+		"with_check": "false",
+	})
+
+	assert.Equal(t,
+		`ALTER POLICY "p" ON "public"."t" TO PUBLIC USING (true) WITH CHECK (false)`,
+		alterPolicyQuery(d, "public"),
+	)
+}
+
+func TestPolicyExpressionDiffSuppress(t *testing.T) {
+	policySchema := resourcePostgreSQLPolicy().Schema
+	d := schema.TestResourceDataRaw(t, policySchema, map[string]interface{}{})
+
+	for _, attr := range []string{"using", "with_check"} {
+		suppress := policySchema[attr].DiffSuppressFunc
+		assert.True(t, suppress(attr, "((tenant_id = 1) AND (NOT deleted))", "tenant_id = 1 AND NOT deleted", d))
+		assert.True(t, suppress(attr, "(status = 'on hold'::text)", "status = 'on hold'", d))
+		assert.False(t, suppress(attr, "((a OR b) AND c)", "a OR (b AND c)", d))
+		assert.False(t, suppress(attr, "(status = 'on hold'::text)", "status = 'onhold'", d))
+	}
+}
+
+func TestAccPostgresqlPolicy_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+	defer createTestTables(t, dbSuffix, []string{"test_schema.test_table"}, "")()
+
+	config := `
+resource "postgresql_policy" "test" {
+  database           = "%s"
+  schema             = "test_schema"
+  table              = "test_table"
+  name               = "%s"
+  command            = "SELECT"
+  roles              = ["%s"]
+  using              = "%s"
+  row_level_security = "%s"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureRLS)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, dbName, "test_policy", roleName, "val IS NOT NULL", "ENABLE"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlPolicyExists("postgresql_policy.test"),
+					resource.TestCheckResourceAttr("postgresql_policy.test", "command", "SELECT"),
+					resource.TestCheckResourceAttr("postgresql_policy.test", "as", "PERMISSIVE"),
+					resource.TestCheckResourceAttr("postgresql_policy.test", "roles.#", "1"),
+					resource.TestCheckResourceAttr("postgresql_policy.test", "row_level_security", "ENABLE"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, dbName, "test_policy_renamed", roleName, "val <> ''", "FORCE"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlPolicyExists("postgresql_policy.test"),
+					resource.TestCheckResourceAttr("postgresql_policy.test", "name", "test_policy_renamed"),
+					resource.TestCheckResourceAttr("postgresql_policy.test", "row_level_security", "FORCE"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_policy.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"using"},
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlPolicyExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkPolicyExists(txn, rs.Primary.Attributes["schema"], rs.Primary.Attributes["table"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking policy %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Policy not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPostgresqlPolicyDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_policy" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkPolicyExists(txn, rs.Primary.Attributes["schema"], rs.Primary.Attributes["table"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking policy %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Policy still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func checkPolicyExists(txn *sql.Tx, pgSchema, tableName, policyName string) (bool, error) {
+	var _rez int
+	err := txn.QueryRow(`
+SELECT 1
+  FROM pg_catalog.pg_policy p
+  JOIN pg_catalog.pg_class c ON c.oid = p.polrelid
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relname = $2 AND p.polname = $3
+`, pgSchema, tableName, policyName).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about policy: %s", err)
+	}
+
+	return true, nil
+}
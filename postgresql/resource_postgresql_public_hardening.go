@@ -0,0 +1,450 @@
+package postgresql
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	publicHardeningDatabasesAttr       = "databases"
+	publicHardeningRevokeConnectAttr   = "revoke_connect"
+	publicHardeningRevokeTemporaryAttr = "revoke_temporary"
+	publicHardeningRevokeSchemaAttr    = "revoke_public_schema_create"
+	publicHardeningRevokeExecuteAttr   = "revoke_function_execute"
+	publicHardeningFunctionOwnersAttr  = "function_owners"
+
+	publicHardeningAllDatabasesID = "*"
+	publicHardeningPublicSchema   = "public"
+)
+
+// publicHardeningFunctionExecuteQuery checks if PUBLIC can execute the functions a role will create.
+// Without global default privileges for the role, the default privileges (EXECUTE for PUBLIC) apply.
+const publicHardeningFunctionExecuteQuery = `
+SELECT EXISTS (
+	SELECT 1
+	FROM pg_catalog.pg_roles r
+	LEFT JOIN pg_catalog.pg_default_acl a
+		ON a.defaclrole = r.oid AND a.defaclnamespace = 0 AND a.defaclobjtype = 'f'
+	CROSS JOIN LATERAL pg_catalog.aclexplode(COALESCE(a.defaclacl, pg_catalog.acldefault('f', r.oid))) e
+	WHERE r.rolname = $1 AND e.grantee = 0 AND e.privilege_type = 'EXECUTE'
+)
+`
+
+func resourcePostgreSQLPublicHardening() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLPublicHardeningCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLPublicHardeningRead),
+		Update: PGResourceFunc(resourcePostgreSQLPublicHardeningUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLPublicHardeningDelete),
+
+		Schema: map[string]*schema.Schema{
+			publicHardeningDatabasesAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "The databases to harden (empty means all the databases accepting connections, except templates and the database of the provider)",
+			},
+			publicHardeningRevokeConnectAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Revoke CONNECT on the databases from PUBLIC",
+			},
+			publicHardeningRevokeTemporaryAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Revoke TEMPORARY on the databases from PUBLIC",
+			},
+			publicHardeningRevokeSchemaAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Revoke CREATE on the public schema of the databases from PUBLIC (granted by default before PostgreSQL 15)",
+			},
+			publicHardeningRevokeExecuteAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Revoke EXECUTE on the functions created in the future from PUBLIC",
+			},
+			publicHardeningFunctionOwnersAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "The roles whose future functions are not executable by PUBLIC (empty means the provider user)",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLPublicHardeningCreate(db *DBConnection, d *schema.ResourceData) error {
+	if err := applyPublicHardening(db, d); err != nil {
+		return err
+	}
+
+	d.SetId(generatePublicHardeningID(d))
+
+	return readPublicHardening(db, d)
+}
+
+func resourcePostgreSQLPublicHardeningRead(db *DBConnection, d *schema.ResourceData) error {
+	return readPublicHardening(db, d)
+}
+
+func resourcePostgreSQLPublicHardeningUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if err := applyPublicHardening(db, d); err != nil {
+		return err
+	}
+
+	return readPublicHardening(db, d)
+}
+
+// The privileges of PUBLIC are not restored when the resource is destroyed,
+// as it would silently weaken the security of the cluster.
+func resourcePostgreSQLPublicHardeningDelete(db *DBConnection, d *schema.ResourceData) error {
+	log.Printf("[WARN] PostgreSQL privileges of PUBLIC are not restored when removing %s", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func applyPublicHardening(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePrivileges) {
+		return fmt.Errorf(
+			"postgresql_public_hardening resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	databases, err := getPublicHardeningDatabases(db, d)
+	if err != nil {
+		return err
+	}
+
+	for _, database := range databases {
+		if err := revokePublicDatabasePrivileges(db, d, database); err != nil {
+			return err
+		}
+		if err := hardenDatabase(db, d, database); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// revokePublicDatabasePrivileges revokes the privileges of PUBLIC on a database.
+// It runs in the database of the provider, which may no longer be able to connect to the database once CONNECT is revoked.
+func revokePublicDatabasePrivileges(db *DBConnection, d *schema.ResourceData, database string) error {
+	query := createPublicHardeningDatabaseQuery(d, database)
+	if query == "" {
+		return nil
+	}
+
+	txn, err := startTransaction(db.client, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := pgLockDatabase(txn, database); err != nil {
+		return err
+	}
+
+	// Without being a member of the owner, the REVOKE is a no-op only raising a warning.
+	owner, err := getDatabaseOwner(txn, database)
+	if err != nil {
+		return err
+	}
+	if err := withRolesGranted(txn, []string{owner}, func() error {
+		log.Printf("[DEBUG] %s", query)
+		_, err := txn.Exec(query)
+		return err
+	}); err != nil {
+		return fmt.Errorf("could not revoke privileges on database %s from PUBLIC: %w", database, err)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// hardenDatabase revokes the privileges of PUBLIC on the objects of a database, in a single transaction.
+func hardenDatabase(db *DBConnection, d *schema.ResourceData, database string) error {
+	if !d.Get(publicHardeningRevokeSchemaAttr).(bool) && !d.Get(publicHardeningRevokeExecuteAttr).(bool) {
+		return nil
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if d.Get(publicHardeningRevokeSchemaAttr).(bool) {
+		exists, err := schemaExists(txn, publicHardeningPublicSchema)
+		if err != nil {
+			return err
+		}
+		if exists {
+			owner, err := getSchemaOwner(txn, publicHardeningPublicSchema)
+			if err != nil {
+				return err
+			}
+			query := fmt.Sprintf("REVOKE CREATE ON SCHEMA %s FROM PUBLIC", pq.QuoteIdentifier(publicHardeningPublicSchema))
+			if err := withRolesGranted(txn, []string{owner}, func() error {
+				log.Printf("[DEBUG] %s", query)
+				_, err := txn.Exec(query)
+				return err
+			}); err != nil {
+				return fmt.Errorf("could not revoke CREATE on public schema of database %s from PUBLIC: %w", database, err)
+			}
+		}
+	}
+
+	if d.Get(publicHardeningRevokeExecuteAttr).(bool) {
+		owners := getPublicHardeningFunctionOwners(db, d)
+		if err := withRolesGranted(txn, owners, func() error {
+			for _, owner := range owners {
+				query := fmt.Sprintf(
+					"ALTER DEFAULT PRIVILEGES FOR ROLE %s REVOKE EXECUTE ON FUNCTIONS FROM PUBLIC",
+					pq.QuoteIdentifier(owner),
+				)
+				log.Printf("[DEBUG] %s", query)
+				if _, err := txn.Exec(query); err != nil {
+					return fmt.Errorf("could not revoke EXECUTE on functions of %s from PUBLIC: %w", owner, err)
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// readPublicHardening checks that PUBLIC has none of the revoked privileges in any of the databases.
+// If it's not the case, the corresponding attribute is set to false to force an update.
+func readPublicHardening(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePrivileges) {
+		return fmt.Errorf(
+			"postgresql_public_hardening resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	databases, err := getPublicHardeningDatabases(db, d)
+	if err != nil {
+		return err
+	}
+
+	hardened := map[string]bool{}
+	for _, attr := range []string{
+		publicHardeningRevokeConnectAttr,
+		publicHardeningRevokeTemporaryAttr,
+		publicHardeningRevokeSchemaAttr,
+		publicHardeningRevokeExecuteAttr,
+	} {
+		hardened[attr] = d.Get(attr).(bool)
+	}
+
+	for _, database := range databases {
+		if err := readDatabasePublicHardening(db, d, database, hardened); err != nil {
+			return err
+		}
+	}
+
+	for attr, value := range hardened {
+		d.Set(attr, value)
+	}
+	d.SetId(generatePublicHardeningID(d))
+
+	return nil
+}
+
+func readDatabasePublicHardening(db *DBConnection, d *schema.ResourceData, database string, hardened map[string]bool) error {
+	// The privileges on the database are read from the database of the provider,
+	// which may not be able to connect to the database once CONNECT is revoked.
+	privileges, err := getPublicDatabasePrivileges(db, database)
+	if err != nil {
+		return err
+	}
+	for attr, privilege := range map[string]string{
+		publicHardeningRevokeConnectAttr:   "CONNECT",
+		publicHardeningRevokeTemporaryAttr: "TEMPORARY",
+	} {
+		if hardened[attr] && pgArrayToSet(privileges).Contains(privilege) {
+			log.Printf("[DEBUG] PUBLIC has %s on database %s", privilege, database)
+			hardened[attr] = false
+		}
+	}
+
+	if !hardened[publicHardeningRevokeSchemaAttr] && !hardened[publicHardeningRevokeExecuteAttr] {
+		return nil
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if hardened[publicHardeningRevokeSchemaAttr] {
+		privileges, err := getPublicSchemaPrivileges(txn, publicHardeningPublicSchema)
+		if err != nil {
+			return err
+		}
+		if pgArrayToSet(privileges).Contains("CREATE") {
+			log.Printf("[DEBUG] PUBLIC has CREATE on public schema of database %s", database)
+			hardened[publicHardeningRevokeSchemaAttr] = false
+		}
+	}
+
+	if hardened[publicHardeningRevokeExecuteAttr] {
+		for _, owner := range getPublicHardeningFunctionOwners(db, d) {
+			var granted bool
+			if err := txn.QueryRow(publicHardeningFunctionExecuteQuery, owner).Scan(&granted); err != nil {
+				return fmt.Errorf("could not read default privileges of %s in database %s: %w", owner, database, err)
+			}
+			if granted {
+				log.Printf("[DEBUG] PUBLIC has EXECUTE on future functions of %s in database %s", owner, database)
+				hardened[publicHardeningRevokeExecuteAttr] = false
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// getPublicHardeningDatabases returns the declared databases which exist,
+// or all the databases accepting connections except the database of the provider if none is declared.
+func getPublicHardeningDatabases(db *DBConnection, d *schema.ResourceData) ([]string, error) {
+	declared := interfaceSliceToStrings(d.Get(publicHardeningDatabasesAttr).(*schema.Set).List())
+	sort.Strings(declared)
+
+	if len(declared) > 0 {
+		databases := []string{}
+		for _, database := range declared {
+			exists, err := dbExists(db, database)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				log.Printf("[WARN] PostgreSQL database (%s) to harden not found", database)
+				continue
+			}
+			databases = append(databases, database)
+		}
+		return databases, nil
+	}
+
+	connectable, err := getConnectableDatabases(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var templates []string
+	if err := db.QueryRow(
+		"SELECT ARRAY(SELECT datname FROM pg_catalog.pg_database WHERE datistemplate)::TEXT[]",
+	).Scan(pq.Array(&templates)); err != nil {
+		return nil, fmt.Errorf("could not list template databases: %w", err)
+	}
+
+	// The database of the provider is only hardened if it's declared,
+	// so revoking CONNECT from PUBLIC cannot lock the provider out.
+	databases := []string{}
+	for _, database := range connectable {
+		if database != db.client.databaseName && !sliceContainsStr(templates, database) {
+			databases = append(databases, database)
+		}
+	}
+
+	return databases, nil
+}
+
+// getPublicDatabasePrivileges returns the privileges of PUBLIC on a database.
+// The ACL is NULL until the privileges of the database are changed, in which case the default privileges apply.
+func getPublicDatabasePrivileges(db QueryAble, dbName string) (pq.ByteaArray, error) {
+	query := `
+SELECT array_agg(privilege_type)
+FROM (
+	SELECT (aclexplode(COALESCE(datacl, acldefault('d', datdba)))).* FROM pg_database WHERE datname=$1
+) as privileges
+WHERE grantee = 0
+`
+
+	var privileges pq.ByteaArray
+	if err := db.QueryRow(query, dbName).Scan(&privileges); err != nil {
+		return nil, fmt.Errorf("could not read privileges for database %s: %w", dbName, err)
+	}
+	return privileges, nil
+}
+
+// getPublicSchemaPrivileges returns the privileges of PUBLIC on a schema.
+// The ACL is NULL until the privileges of the schema are changed, in which case the default privileges apply.
+func getPublicSchemaPrivileges(db QueryAble, schemaName string) (pq.ByteaArray, error) {
+	query := `
+SELECT array_agg(privilege_type)
+FROM (
+	SELECT (aclexplode(COALESCE(nspacl, acldefault('n', nspowner)))).* FROM pg_namespace WHERE nspname=$1
+) as privileges
+WHERE grantee = 0
+`
+
+	var privileges pq.ByteaArray
+	if err := db.QueryRow(query, schemaName).Scan(&privileges); err != nil {
+		return nil, fmt.Errorf("could not read privileges for schema %s: %w", schemaName, err)
+	}
+	return privileges, nil
+}
+
+func getPublicHardeningFunctionOwners(db *DBConnection, d *schema.ResourceData) []string {
+	owners := interfaceSliceToStrings(d.Get(publicHardeningFunctionOwnersAttr).(*schema.Set).List())
+	if len(owners) == 0 {
+		return []string{db.client.config.getDatabaseUsername()}
+	}
+	sort.Strings(owners)
+	return owners
+}
+
+func createPublicHardeningDatabaseQuery(d *schema.ResourceData, database string) string {
+	privileges := []string{}
+	if d.Get(publicHardeningRevokeConnectAttr).(bool) {
+		privileges = append(privileges, "CONNECT")
+	}
+	if d.Get(publicHardeningRevokeTemporaryAttr).(bool) {
+		privileges = append(privileges, "TEMPORARY")
+	}
+	if len(privileges) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"REVOKE %s ON DATABASE %s FROM PUBLIC", strings.Join(privileges, ","), pq.QuoteIdentifier(database),
+	)
+}
+
+func generatePublicHardeningID(d *schema.ResourceData) string {
+	databases := interfaceSliceToStrings(d.Get(publicHardeningDatabasesAttr).(*schema.Set).List())
+	if len(databases) == 0 {
+		return publicHardeningAllDatabasesID
+	}
+	sort.Strings(databases)
+	return strings.Join(databases, "_")
+}
@@ -0,0 +1,137 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePublicHardeningDatabaseQuery(t *testing.T) {
+	cases := []struct {
+		resource map[string]interface{}
+		expected string
+	}{
+		{
+			resource: map[string]interface{}{},
+			expected: `REVOKE CONNECT,TEMPORARY ON DATABASE "test_db" FROM PUBLIC`,
+		},
+		{
+			resource: map[string]interface{}{"revoke_connect": false},
+			expected: `REVOKE TEMPORARY ON DATABASE "test_db" FROM PUBLIC`,
+		},
+		{
+			resource: map[string]interface{}{"revoke_connect": false, "revoke_temporary": false},
+			expected: "",
+		},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourcePostgreSQLPublicHardening().Schema, c.resource)
+		assert.Equal(t, c.expected, createPublicHardeningDatabaseQuery(d, "test_db"))
+	}
+}
+
+func TestGeneratePublicHardeningID(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLPublicHardening().Schema, map[string]interface{}{})
+	assert.Equal(t, "*", generatePublicHardeningID(d))
+
+	d = schema.TestResourceDataRaw(t, resourcePostgreSQLPublicHardening().Schema, map[string]interface{}{
+		"databases": []interface{}{"db2", "db1"},
+	})
+	assert.Equal(t, "db1_db2", generatePublicHardeningID(d))
+}
+
+func TestAccPostgresqlPublicHardening_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	config := getTestConfig(t)
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	resourceConfig := fmt.Sprintf(`
+resource "postgresql_public_hardening" "test" {
+  databases = ["%s"]
+}
+`, dbName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testSuperuserPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), "GRANT CREATE ON SCHEMA public TO PUBLIC")
+				},
+				Config: resourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_public_hardening.test", "id", dbName),
+					resource.TestCheckResourceAttr("postgresql_public_hardening.test", "revoke_connect", "true"),
+					testAccCheckPublicHardened(config.connStr(dbName), dbName, config.getDatabaseUsername()),
+				),
+			},
+			{
+				// Privileges granted to PUBLIC outside of Terraform are revoked again.
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), fmt.Sprintf("GRANT CONNECT ON DATABASE %s TO PUBLIC", dbName))
+					dbExecute(t, config.connStr(dbName), "ALTER DEFAULT PRIVILEGES GRANT EXECUTE ON FUNCTIONS TO PUBLIC")
+				},
+				Config: resourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPublicHardened(config.connStr(dbName), dbName, config.getDatabaseUsername()),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPublicHardened(dsn, dbName, owner string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		db, err := sql.Open("postgres", dsn)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		txn, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		privileges, err := getPublicDatabasePrivileges(txn, dbName)
+		if err != nil {
+			return err
+		}
+		if len(privileges) > 0 {
+			return fmt.Errorf("PUBLIC still has privileges on database %s: %s", dbName, privileges)
+		}
+
+		privileges, err = getPublicSchemaPrivileges(txn, "public")
+		if err != nil {
+			return err
+		}
+		if pgArrayToSet(privileges).Contains("CREATE") {
+			return fmt.Errorf("PUBLIC still has CREATE on public schema of database %s", dbName)
+		}
+
+		var granted bool
+		if err := txn.QueryRow(publicHardeningFunctionExecuteQuery, owner).Scan(&granted); err != nil {
+			return err
+		}
+		if granted {
+			return fmt.Errorf("PUBLIC can still execute future functions in database %s", dbName)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,255 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	rangeSubtypeAttr        = "subtype"
+	rangeSubtypeOpclassAttr = "subtype_opclass"
+	rangeCollationAttr      = "collation"
+	rangeCanonicalAttr      = "canonical"
+	rangeSubtypeDiffAttr    = "subtype_diff"
+)
+
+func resourcePostgreSQLRangeType() *schema.Resource {
+	s := typeCommonSchema("range type")
+	s[rangeSubtypeAttr] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "The data type of the range bounds",
+
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			return normalizeColumnType(old) == normalizeColumnType(new)
+		},
+	}
+	s[rangeSubtypeOpclassAttr] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "The b-tree operator class of the subtype. If not specified, the default operator class of the subtype is used",
+	}
+	s[rangeCollationAttr] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "The collation used to order the range bounds. If not specified, the collation of the subtype is used",
+	}
+	s[rangeCanonicalAttr] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "The function converting range values to a canonical form",
+
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			return normalizeTriggerFunction(old) == normalizeTriggerFunction(new)
+		},
+	}
+	s[rangeSubtypeDiffAttr] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "The function returning the difference between two subtype values as a double precision",
+
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			return normalizeTriggerFunction(old) == normalizeTriggerFunction(new)
+		},
+	}
+
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLRangeTypeCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLRangeTypeRead),
+		Update: PGResourceFunc(resourcePostgreSQLRangeTypeUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLRangeTypeDelete),
+		Exists: PGResourceExistsFunc(resourcePostgreSQLRangeTypeExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: s,
+	}
+}
+
+func resourcePostgreSQLRangeTypeCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getTypeSchema(d)
+	typeName := d.Get(typeNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	owner := d.Get(typeOwnerAttr).(string)
+	var rolesToGrant []string
+	if owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		if _, err := txn.Exec(createRangeTypeQuery(d, pgSchema, typeName)); err != nil {
+			return fmt.Errorf("could not create range type %s: %w", typeName, err)
+		}
+
+		return setTypeOwner(txn, "TYPE", pgSchema, typeName, owner)
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateTypeID(database, pgSchema, typeName))
+
+	return resourcePostgreSQLRangeTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLRangeTypeExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	return typeExists(db, d, typTypeRange)
+}
+
+func resourcePostgreSQLRangeTypeRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLRangeTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLRangeTypeReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, pgSchema, typeName, err := getDBTypeName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var typeOwner, subtype, opclass, collation, canonical, subtypeDiff, qualifiedCanonical, qualifiedSubtypeDiff string
+	// The collation is only returned if it's not the default one of the subtype.
+	err = txn.QueryRow(`
+SELECT pg_catalog.pg_get_userbyid(t.typowner),
+       pg_catalog.format_type(r.rngsubtype, NULL),
+       opc.opcname,
+       COALESCE((
+           SELECT coll.collname FROM pg_catalog.pg_collation coll
+            WHERE coll.oid = r.rngcollation AND r.rngcollation <> st.typcollation
+       ), ''),
+       CASE WHEN r.rngcanonical = 0 THEN '' ELSE r.rngcanonical::regproc::text END,
+       CASE WHEN r.rngsubdiff = 0 THEN '' ELSE r.rngsubdiff::regproc::text END,
+       COALESCE((
+           SELECT format('%I.%I', pn.nspname, p.proname) FROM pg_catalog.pg_proc p
+             JOIN pg_catalog.pg_namespace pn ON pn.oid = p.pronamespace
+            WHERE p.oid = r.rngcanonical
+       ), ''),
+       COALESCE((
+           SELECT format('%I.%I', pn.nspname, p.proname) FROM pg_catalog.pg_proc p
+             JOIN pg_catalog.pg_namespace pn ON pn.oid = p.pronamespace
+            WHERE p.oid = r.rngsubdiff
+       ), '')
+  FROM pg_catalog.pg_type t
+  JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+  JOIN pg_catalog.pg_range r ON r.rngtypid = t.oid
+  JOIN pg_catalog.pg_type st ON st.oid = r.rngsubtype
+  JOIN pg_catalog.pg_opclass opc ON opc.oid = r.rngsubopc
+ WHERE n.nspname = $1 AND t.typname = $2 AND t.typtype = 'r'
+`, pgSchema, typeName).Scan(
+		&typeOwner, &subtype, &opclass, &collation, &canonical, &subtypeDiff, &qualifiedCanonical, &qualifiedSubtypeDiff,
+	)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL range type (%s) not found in database %s", typeName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading range type: %w", err)
+	}
+
+	d.Set(typeNameAttr, typeName)
+	d.Set(typeSchemaAttr, pgSchema)
+	d.Set(typeDatabaseAttr, database)
+	d.Set(typeOwnerAttr, typeOwner)
+	d.Set(rangeSubtypeAttr, subtype)
+	d.Set(rangeSubtypeOpclassAttr, opclass)
+	d.Set(rangeCollationAttr, collation)
+	// regproc only qualifies the functions which are not in the search_path, so the
+	// functions are kept as configured if they match with or without their schema.
+	d.Set(rangeCanonicalAttr, rangeFunctionName(d.Get(rangeCanonicalAttr).(string), canonical, qualifiedCanonical))
+	d.Set(rangeSubtypeDiffAttr, rangeFunctionName(d.Get(rangeSubtypeDiffAttr).(string), subtypeDiff, qualifiedSubtypeDiff))
+	d.SetId(generateTypeID(database, pgSchema, typeName))
+
+	return nil
+}
+
+// Only the name and the owner of a range type can be changed, other attributes force a new resource.
+func resourcePostgreSQLRangeTypeUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getTypeSchema(d)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := withRolesGranted(txn, typeOwnerRoles(d), func() error {
+		if err := setTypeName(txn, d, "TYPE", pgSchema); err != nil {
+			return err
+		}
+
+		if d.HasChange(typeOwnerAttr) {
+			return setTypeOwner(txn, "TYPE", pgSchema, d.Get(typeNameAttr).(string), d.Get(typeOwnerAttr).(string))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourcePostgreSQLRangeTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLRangeTypeDelete(db *DBConnection, d *schema.ResourceData) error {
+	return dropType(db, d, "TYPE")
+}
+
+func createRangeTypeQuery(d *schema.ResourceData, pgSchema, typeName string) string {
+	options := []string{"SUBTYPE = " + d.Get(rangeSubtypeAttr).(string)}
+
+	if v, ok := d.GetOk(rangeSubtypeOpclassAttr); ok {
+		options = append(options, "SUBTYPE_OPCLASS = "+v.(string))
+	}
+	if v, ok := d.GetOk(rangeCollationAttr); ok {
+		options = append(options, "COLLATION = "+pq.QuoteIdentifier(v.(string)))
+	}
+	if v, ok := d.GetOk(rangeCanonicalAttr); ok {
+		options = append(options, "CANONICAL = "+v.(string))
+	}
+	if v, ok := d.GetOk(rangeSubtypeDiffAttr); ok {
+		options = append(options, "SUBTYPE_DIFF = "+v.(string))
+	}
+
+	return fmt.Sprintf("CREATE TYPE %s AS RANGE (%s)", tableIdentifier(pgSchema, typeName), strings.Join(options, ", "))
+}
+
+// rangeFunctionName returns the configured function name if it designates the function read,
+// either by its name as returned by regproc or by its schema-qualified name.
+func rangeFunctionName(configured, name, qualifiedName string) string {
+	configuredName := normalizeTriggerFunction(configured)
+	if configuredName == normalizeTriggerFunction(name) || configuredName == normalizeTriggerFunction(qualifiedName) {
+		return configured
+	}
+	return name
+}
@@ -0,0 +1,101 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRangeTypeQuery(t *testing.T) {
+	cases := []struct {
+		resource map[string]interface{}
+		expected string
+	}{
+		{
+			resource: map[string]interface{}{
+				"name":    "floatrange",
+				"subtype": "float8",
+			},
+			expected: `CREATE TYPE "public"."floatrange" AS RANGE (SUBTYPE = float8)`,
+		},
+		{
+			resource: map[string]interface{}{
+				"name":            "floatrange",
+				"subtype":         "float8",
+				"subtype_opclass": "float8_ops",
+				"subtype_diff":    "float8mi",
+			},
+			expected: `CREATE TYPE "public"."floatrange" AS RANGE (SUBTYPE = float8, SUBTYPE_OPCLASS = float8_ops, SUBTYPE_DIFF = float8mi)`,
+		},
+		{
+			resource: map[string]interface{}{
+				"name":      "textrange",
+				"subtype":   "text",
+				"collation": "C",
+			},
+			expected: `CREATE TYPE "public"."floatrange" AS RANGE (SUBTYPE = text, COLLATION = "C")`,
+		},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourcePostgreSQLRangeType().Schema, c.resource)
+		assert.Equal(t, c.expected, createRangeTypeQuery(d, "public", "floatrange"))
+	}
+}
+
+func TestRangeFunctionName(t *testing.T) {
+	assert.Equal(t, "public.float8mi", rangeFunctionName("public.float8mi", "float8mi", "public.float8mi"))
+	assert.Equal(t, "app.time_diff", rangeFunctionName("app.time_diff", "time_diff", "app.time_diff"))
+	assert.Equal(t, `"app"."time_diff"`, rangeFunctionName(`"app"."time_diff"`, "app.time_diff", "app.time_diff"))
+	assert.Equal(t, "other.time_diff", rangeFunctionName("app.time_diff", "other.time_diff", "other.time_diff"))
+	assert.Equal(t, "", rangeFunctionName("", "", ""))
+}
+
+func TestAccPostgresqlRangeType_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+
+	config := fmt.Sprintf(`
+resource "postgresql_range_type" "test" {
+  database     = "%s"
+  schema       = "test_schema"
+  name         = "floatrange"
+  owner        = "%s"
+  subtype      = "float8"
+  subtype_diff = "float8mi"
+}
+`, dbName, roleName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlTypeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTypeExists("postgresql_range_type.test"),
+					resource.TestCheckResourceAttr("postgresql_range_type.test", "owner", roleName),
+					resource.TestCheckResourceAttr("postgresql_range_type.test", "subtype", "double precision"),
+					resource.TestCheckResourceAttr("postgresql_range_type.test", "subtype_opclass", "float8_ops"),
+					resource.TestCheckResourceAttr("postgresql_range_type.test", "subtype_diff", "float8mi"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_range_type.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"drop_cascade"},
+			},
+		},
+	})
+}
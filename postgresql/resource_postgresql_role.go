@@ -1,18 +1,24 @@
 package postgresql
 
 import (
+	"context"
 	"crypto/md5"
+	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/lib/pq"
+	"golang.org/x/crypto/pbkdf2"
 )
 
 const (
@@ -21,32 +27,46 @@ const (
 	roleCreateDBAttr                        = "create_database"
 	roleCreateRoleAttr                      = "create_role"
 	roleEncryptedPassAttr                   = "encrypted_password"
+	roleHashPasswordAttr                    = "hash_password"
+	roleHashPasswordIterationsAttr          = "hash_password_iterations"
 	roleIdleInTransactionSessionTimeoutAttr = "idle_in_transaction_session_timeout"
 	roleInheritAttr                         = "inherit"
 	roleLoginAttr                           = "login"
 	roleNameAttr                            = "name"
 	rolePasswordAttr                        = "password"
+	rolePasswordWOAttr                      = "password_wo"
+	rolePasswordVersionAttr                 = "password_version"
+	rolePasswordWOFingerprintAttr           = "password_wo_fingerprint"
 	roleReplicationAttr                     = "replication"
 	roleSkipDropRoleAttr                    = "skip_drop_role"
 	roleSkipReassignOwnedAttr               = "skip_reassign_owned"
+	roleReassignOwnedToAttr                 = "reassign_owned_to"
+	roleDependentDatabasesAttr              = "dependent_databases"
 	roleSuperuserAttr                       = "superuser"
 	roleValidUntilAttr                      = "valid_until"
+	roleValidForAttr                        = "valid_for"
+	roleExpiryWarningWindowAttr             = "expiry_warning_window"
 	roleRolesAttr                           = "roles"
 	roleSearchPathAttr                      = "search_path"
 	roleStatementTimeoutAttr                = "statement_timeout"
 	roleAssumeRoleAttr                      = "assume_role"
+	roleParametersAttr                      = "parameters"
 
 	// Deprecated options
 	roleDepEncryptedAttr = "encrypted"
+
+	passwordFingerprintPrefix = "pbkdf2-sha256:"
 )
 
 func resourcePostgreSQLRole() *schema.Resource {
 	return &schema.Resource{
-		Create: PGResourceFunc(resourcePostgreSQLRoleCreate),
-		Read:   PGResourceFunc(resourcePostgreSQLRoleRead),
-		Update: PGResourceFunc(resourcePostgreSQLRoleUpdate),
-		Delete: PGResourceFunc(resourcePostgreSQLRoleDelete),
-		Exists: PGResourceExistsFunc(resourcePostgreSQLRoleExists),
+		Create:      PGResourceFunc(resourcePostgreSQLRoleCreate),
+		ReadContext: PGResourceContextFunc(resourcePostgreSQLRoleRead),
+		Update:      PGResourceFunc(resourcePostgreSQLRoleUpdate),
+		Delete:      PGResourceFunc(resourcePostgreSQLRoleDelete),
+		Exists:      PGResourceExistsFunc(resourcePostgreSQLRoleExists),
+
+		CustomizeDiff: customizeRolePasswordWODiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -63,6 +83,24 @@ func resourcePostgreSQLRole() *schema.Resource {
 				Sensitive:   true,
 				Description: "Sets the role's password",
 			},
+			rolePasswordWOAttr: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{rolePasswordAttr},
+				StateFunc:     discardPasswordWO,
+				Description:   "Sets the role's password without storing it in the state",
+			},
+			rolePasswordVersionAttr: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Changing this value sets the role's password again",
+			},
+			rolePasswordWOFingerprintAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Fingerprint of password_wo, salted with the role name and password_version, used to detect a change of password_wo",
+			},
 			roleDepEncryptedAttr: {
 				Type:       schema.TypeString,
 				Optional:   true,
@@ -89,12 +127,42 @@ func resourcePostgreSQLRole() *schema.Resource {
 				Default:     true,
 				Description: "Control whether the password is stored encrypted in the system catalogs",
 			},
-			roleValidUntilAttr: {
-				Type:        schema.TypeString,
+			roleHashPasswordAttr: {
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Default:     "infinity",
+				Default:     false,
+				Description: "Compute the SCRAM-SHA-256 verifier of the password in the provider so the plaintext password is never sent to the server",
+			},
+			roleHashPasswordIterationsAttr: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      scramDefaultIterations,
+				Description:  "Number of iterations used to compute the SCRAM-SHA-256 verifier when hash_password is set",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			roleValidUntilAttr: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "infinity",
+				ConflictsWith: []string{roleValidForAttr},
+				// When valid_for is set, valid_until holds the computed expiration date.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Get(roleValidForAttr).(string) != ""
+				},
 				Description: "Sets a date and time after which the role's password is no longer valid",
 			},
+			roleValidForAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRoleDuration,
+				Description:  "Sets valid_until to the given duration (e.g.: 90d or 12h) after the password is set",
+			},
+			roleExpiryWarningWindowAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRoleDuration,
+				Description:  "Shows a warning in the plan when the role expires within the given duration (e.g.: 14d)",
+			},
 			roleConnLimitAttr: {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -162,6 +230,18 @@ func resourcePostgreSQLRole() *schema.Resource {
 				Default:     false,
 				Description: "Skip actually running the REASSIGN OWNED command when removing a role from PostgreSQL",
 			},
+			roleReassignOwnedToAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Role to which objects owned by this role are reassigned when it is removed, defaults to the connected user",
+			},
+			roleDependentDatabasesAttr: {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Databases holding objects which depend on this role (owned objects, privileges, default privileges or policies)",
+			},
 			roleStatementTimeoutAttr: {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -173,6 +253,14 @@ func resourcePostgreSQLRole() *schema.Resource {
 				Optional:    true,
 				Description: "Role to switch to at login",
 			},
+			roleParametersAttr: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Configuration parameters of the role, all the other parameters (except the ones managed by dedicated attributes) are reset. If not set, the parameters are not managed",
+
+				DiffSuppressFunc: suppressUnmanagedRoleParametersDiff,
+			},
 		},
 	}
 }
@@ -184,13 +272,6 @@ func resourcePostgreSQLRoleCreate(db *DBConnection, d *schema.ResourceData) erro
 	}
 	defer deferredRollback(txn)
 
-	stringOpts := []struct {
-		hclKey string
-		sqlKey string
-	}{
-		{rolePasswordAttr, "PASSWORD"},
-		{roleValidUntilAttr, "VALID UNTIL"},
-	}
 	intOpts := []struct {
 		hclKey string
 		sqlKey string
@@ -221,41 +302,32 @@ func resourcePostgreSQLRoleCreate(db *DBConnection, d *schema.ResourceData) erro
 		boolOpts = append(boolOpts, boolOptType{roleReplicationAttr, "REPLICATION", "NOREPLICATION"})
 	}
 
-	createOpts := make([]string, 0, len(stringOpts)+len(intOpts)+len(boolOpts))
+	createOpts := make([]string, 0, len(intOpts)+len(boolOpts)+3)
 
-	for _, opt := range stringOpts {
-		v, ok := d.GetOk(opt.hclKey)
-		if !ok {
-			continue
-		}
-
-		val := v.(string)
-		if val != "" {
-			switch {
-			case opt.hclKey == rolePasswordAttr:
-				if strings.ToUpper(v.(string)) == "NULL" {
-					createOpts = append(createOpts, "PASSWORD NULL")
-				} else {
-					if d.Get(roleEncryptedPassAttr).(bool) {
-						createOpts = append(createOpts, "ENCRYPTED")
-					} else {
-						createOpts = append(createOpts, "UNENCRYPTED")
-					}
-					createOpts = append(createOpts, fmt.Sprintf("%s '%s'", opt.sqlKey, pqQuoteLiteral(val)))
-				}
-			case opt.hclKey == roleValidUntilAttr:
-				switch {
-				case v.(string) == "", strings.ToLower(v.(string)) == "infinity":
-					createOpts = append(createOpts, fmt.Sprintf("%s '%s'", opt.sqlKey, "infinity"))
-				default:
-					createOpts = append(createOpts, fmt.Sprintf("%s '%s'", opt.sqlKey, pqQuoteLiteral(val)))
-				}
-			default:
-				createOpts = append(createOpts, fmt.Sprintf("%s %s", opt.sqlKey, pq.QuoteIdentifier(val)))
+	if password := rolePassword(d); password != "" {
+		if strings.ToUpper(password) == "NULL" {
+			createOpts = append(createOpts, "PASSWORD NULL")
+		} else {
+			if d.Get(roleEncryptedPassAttr).(bool) {
+				createOpts = append(createOpts, "ENCRYPTED")
+			} else {
+				createOpts = append(createOpts, "UNENCRYPTED")
+			}
+			password, err := rolePasswordToSend(d)
+			if err != nil {
+				return err
 			}
+			createOpts = append(createOpts, fmt.Sprintf("PASSWORD '%s'", pqQuoteLiteral(password)))
 		}
 	}
 
+	// valid_until is computed from valid_for when it's set.
+	validUntil, err := roleValidUntil(d, time.Now())
+	if err != nil {
+		return err
+	}
+	createOpts = append(createOpts, fmt.Sprintf("VALID UNTIL '%s'", pqQuoteLiteral(validUntil)))
+
 	for _, opt := range intOpts {
 		val := d.Get(opt.hclKey).(int)
 		createOpts = append(createOpts, fmt.Sprintf("%s %d", opt.sqlKey, val))
@@ -263,8 +335,7 @@ func resourcePostgreSQLRoleCreate(db *DBConnection, d *schema.ResourceData) erro
 
 	for _, opt := range boolOpts {
 		if opt.hclKey == roleEncryptedPassAttr {
-			// This attribute is handled above in the stringOpts
-			// loop.
+			// This attribute is handled above with the password.
 			continue
 		}
 		val := d.Get(opt.hclKey).(bool)
@@ -311,6 +382,10 @@ func resourcePostgreSQLRoleCreate(db *DBConnection, d *schema.ResourceData) erro
 		return err
 	}
 
+	if err = setRoleParameters(txn, d); err != nil {
+		return err
+	}
+
 	if err = txn.Commit(); err != nil {
 		return fmt.Errorf("could not commit transaction: %w", err)
 	}
@@ -334,17 +409,37 @@ func resourcePostgreSQLRoleDelete(db *DBConnection, d *schema.ResourceData) erro
 	}
 
 	if !d.Get(roleSkipReassignOwnedAttr).(bool) {
-		if err := withRolesGranted(txn, []string{roleName}, func() error {
-			currentUser := db.client.config.getDatabaseUsername()
-			if _, err := txn.Exec(fmt.Sprintf("REASSIGN OWNED BY %s TO %s", pq.QuoteIdentifier(roleName), pq.QuoteIdentifier(currentUser))); err != nil {
-				return fmt.Errorf("could not reassign owned by role %s to %s: %w", roleName, currentUser, err)
-			}
+		reassignTo := d.Get(roleReassignOwnedToAttr).(string)
+		if reassignTo == "" {
+			reassignTo = db.client.config.getDatabaseUsername()
+		}
 
-			if _, err := txn.Exec(fmt.Sprintf("DROP OWNED BY %s", pq.QuoteIdentifier(roleName))); err != nil {
-				return fmt.Errorf("could not drop owned by role %s: %w", roleName, err)
+		// REASSIGN OWNED and DROP OWNED only act on objects of the current database (and shared objects),
+		// so they need to run in each database. The default one is done last in the same transaction as DROP ROLE.
+		// As each database is committed on its own, this is not atomic: all the databases are checked first
+		// so a failure (e.g.: permission denied) in one of them does not leave the others modified.
+		databases, err := getConnectableDatabases(txn)
+		if err != nil {
+			return err
+		}
+		if err := checkRoleDependentDatabases(txn, roleName, databases); err != nil {
+			return err
+		}
+		for _, database := range databases {
+			if err := reassignAndDropOwnedInDatabase(db, database, roleName, reassignTo, false); err != nil {
+				return err
 			}
-			return nil
-		}); err != nil {
+		}
+		for _, database := range databases {
+			if database == db.client.databaseName {
+				continue
+			}
+			if err := reassignAndDropOwnedInDatabase(db, database, roleName, reassignTo, true); err != nil {
+				return err
+			}
+		}
+
+		if err := reassignAndDropOwned(txn, roleName, reassignTo); err != nil {
 			return err
 		}
 	}
@@ -363,6 +458,79 @@ func resourcePostgreSQLRoleDelete(db *DBConnection, d *schema.ResourceData) erro
 	return nil
 }
 
+// reassignAndDropOwnedInDatabase reassigns and drops the objects owned by the role in a database.
+// If commit is false, the transaction is rolled back: it only checks that it can be done.
+func reassignAndDropOwnedInDatabase(db *DBConnection, database, roleName, reassignTo string, commit bool) error {
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := reassignAndDropOwned(txn, roleName, reassignTo); err != nil {
+		return fmt.Errorf("in database %s: %w", database, err)
+	}
+
+	if !commit {
+		return nil
+	}
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+	return nil
+}
+
+// checkRoleDependentDatabases returns an error if objects depending on the role are in a database
+// which does not accept connections, as they cannot be reassigned or dropped.
+func checkRoleDependentDatabases(db QueryAble, roleName string, connectableDatabases []string) error {
+	dependentDatabases, err := getRoleDependentDatabases(db, roleName)
+	if err != nil {
+		return err
+	}
+	for _, database := range dependentDatabases {
+		if !sliceContainsStr(connectableDatabases, database) {
+			return fmt.Errorf(
+				"could not reassign and drop objects owned by role %s in database %s as it does not accept connections",
+				roleName, database,
+			)
+		}
+	}
+	return nil
+}
+
+func reassignAndDropOwned(txn *sql.Tx, roleName, reassignTo string) error {
+	return withRolesGranted(txn, []string{roleName, reassignTo}, func() error {
+		if _, err := txn.Exec(fmt.Sprintf("REASSIGN OWNED BY %s TO %s", pq.QuoteIdentifier(roleName), pq.QuoteIdentifier(reassignTo))); err != nil {
+			return fmt.Errorf("could not reassign owned by role %s to %s: %w", roleName, reassignTo, err)
+		}
+
+		if _, err := txn.Exec(fmt.Sprintf("DROP OWNED BY %s", pq.QuoteIdentifier(roleName))); err != nil {
+			return fmt.Errorf("could not drop owned by role %s: %w", roleName, err)
+		}
+		return nil
+	})
+}
+
+// getRoleDependentDatabases returns the databases holding objects which depend on the role,
+// according to pg_shdepend. Shared objects (e.g.: databases or tablespaces) are not attached to a database.
+func getRoleDependentDatabases(db QueryAble, roleName string) ([]string, error) {
+	var databases []string
+	if err := db.QueryRow(`
+SELECT ARRAY(
+    SELECT DISTINCT d.datname
+      FROM pg_catalog.pg_shdepend s
+      JOIN pg_catalog.pg_database d ON d.oid = s.dbid
+     WHERE s.refclassid = 'pg_catalog.pg_authid'::regclass
+       AND s.refobjid = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $1)
+     ORDER BY d.datname
+)::TEXT[]
+`, roleName).Scan(pq.Array(&databases)); err != nil {
+		return nil, fmt.Errorf("could not read dependent databases of role %s: %w", roleName, err)
+	}
+
+	return databases, nil
+}
+
 func resourcePostgreSQLRoleExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
 	var roleName string
 	err := db.QueryRow("SELECT rolname FROM pg_catalog.pg_roles WHERE rolname=$1", d.Id()).Scan(&roleName)
@@ -376,8 +544,70 @@ func resourcePostgreSQLRoleExists(db *DBConnection, d *schema.ResourceData) (boo
 	return true, nil
 }
 
-func resourcePostgreSQLRoleRead(db *DBConnection, d *schema.ResourceData) error {
-	return resourcePostgreSQLRoleReadImpl(db, d)
+func resourcePostgreSQLRoleRead(ctx context.Context, db *DBConnection, d *schema.ResourceData) diag.Diagnostics {
+	if err := resourcePostgreSQLRoleReadImpl(db, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	window := d.Get(roleExpiryWarningWindowAttr).(string)
+	if d.Id() == "" || window == "" {
+		return nil
+	}
+
+	windowDuration, err := parseRoleDuration(window)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	expiry, expires, err := getRoleExpiry(db, d.Get(roleNameAttr).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !expires {
+		return nil
+	}
+
+	return roleExpiryWarning(d.Get(roleNameAttr).(string), expiry, time.Now(), windowDuration)
+}
+
+// getRoleExpiry returns the expiration date of the role (rolvaliduntil)
+// and false if the role never expires.
+func getRoleExpiry(db QueryAble, roleName string) (time.Time, bool, error) {
+	var validUntil sql.NullFloat64
+	if err := db.QueryRow(
+		"SELECT EXTRACT(EPOCH FROM rolvaliduntil) FROM pg_catalog.pg_roles WHERE rolname = $1", roleName,
+	).Scan(&validUntil); err != nil {
+		return time.Time{}, false, fmt.Errorf("could not read expiration date of role %s: %w", roleName, err)
+	}
+
+	if !validUntil.Valid || math.IsInf(validUntil.Float64, 0) {
+		return time.Time{}, false, nil
+	}
+
+	sec, frac := math.Modf(validUntil.Float64)
+	return time.Unix(int64(sec), int64(frac*1e9)).UTC(), true, nil
+}
+
+// roleExpiryWarning returns a warning if the role is expired or expires within the window.
+func roleExpiryWarning(roleName string, expiry, now time.Time, window time.Duration) diag.Diagnostics {
+	switch {
+	case !expiry.After(now):
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Role %s has expired", roleName),
+			Detail:   fmt.Sprintf("The password of role %s is no longer valid since %s.", roleName, expiry.Format(time.RFC3339)),
+		}}
+	case expiry.Before(now.Add(window)):
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Role %s expires soon", roleName),
+			Detail: fmt.Sprintf(
+				"The password of role %s will no longer be valid after %s (in %s).",
+				roleName, expiry.Format(time.RFC3339), expiry.Sub(now).Round(time.Minute),
+			),
+		}}
+	}
+	return nil
 }
 
 func resourcePostgreSQLRoleReadImpl(db *DBConnection, d *schema.ResourceData) error {
@@ -472,6 +702,13 @@ func resourcePostgreSQLRoleReadImpl(db *DBConnection, d *schema.ResourceData) er
 
 	d.Set(roleIdleInTransactionSessionTimeoutAttr, idleInTransactionSessionTimeout)
 
+	parameters, err := readRoleParameters(db, roleConfig, d.Get(roleParametersAttr).(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	d.Set(roleParametersAttr, parameters)
+
 	d.SetId(roleName)
 
 	password, err := readRolePassword(db, d, roleCanLogin)
@@ -480,6 +717,13 @@ func resourcePostgreSQLRoleReadImpl(db *DBConnection, d *schema.ResourceData) er
 	}
 
 	d.Set(rolePasswordAttr, password)
+
+	dependentDatabases, err := getRoleDependentDatabases(db, roleName)
+	if err != nil {
+		return err
+	}
+	d.Set(roleDependentDatabasesAttr, stringSliceToSet(dependentDatabases))
+
 	return nil
 }
 
@@ -547,11 +791,95 @@ func readAssumeRole(roleConfig pq.ByteaArray) string {
 	return res
 }
 
+// roleDedicatedParameters are the parameters of rolconfig managed by a dedicated attribute
+// instead of the parameters map.
+var roleDedicatedParameters = map[string]string{
+	"search_path":                         roleSearchPathAttr,
+	"statement_timeout":                   roleStatementTimeoutAttr,
+	"idle_in_transaction_session_timeout": roleIdleInTransactionSessionTimeoutAttr,
+	"role":                                roleAssumeRoleAttr,
+}
+
+// readRoleParameters parses the rolconfig array, except the parameters managed by dedicated attributes.
+// If a configured value is equivalent to the stored one (e.g.: 1min and 60000 for a parameter in ms),
+// the configured value is kept.
+func readRoleParameters(db QueryAble, roleConfig pq.ByteaArray, configured map[string]interface{}) (map[string]interface{}, error) {
+	settings := make([]string, 0, len(roleConfig))
+	for _, v := range roleConfig {
+		settings = append(settings, string(v))
+	}
+
+	parameters := map[string]interface{}{}
+	for name, value := range parseRelOptions(settings) {
+		if _, ok := roleDedicatedParameters[name]; ok {
+			continue
+		}
+		parameters[name] = value
+
+		c, ok := configured[name]
+		if !ok || c.(string) == value {
+			continue
+		}
+
+		setting, err := getSetting(db, name)
+		switch {
+		case err == sql.ErrNoRows:
+			// Custom parameter (e.g.: pg_stat_statements.track) not loaded by the server.
+			setting = pgSetting{Name: name, Vartype: "string"}
+		case err != nil:
+			return nil, err
+		}
+		if systemParameterValuesEqual(setting, c.(string), value) {
+			parameters[name] = c.(string)
+		}
+	}
+
+	return parameters, nil
+}
+
+// suppressUnmanagedRoleParametersDiff ignores the parameters of the role if the parameters attribute
+// is not set in the configuration, so they can be managed by postgresql_alter_role.
+func suppressUnmanagedRoleParametersDiff(k, old, new string, d *schema.ResourceData) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+	return config.GetAttr(roleParametersAttr).IsNull()
+}
+
+func setRoleParameters(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(roleParametersAttr) {
+		return nil
+	}
+
+	roleName := d.Get(roleNameAttr).(string)
+	oldParameters, newParameters := d.GetChange(roleParametersAttr)
+
+	for name := range newParameters.(map[string]interface{}) {
+		if attr, ok := roleDedicatedParameters[name]; ok {
+			return fmt.Errorf("parameter %s of role %s must be set with the %s attribute", name, roleName, attr)
+		}
+	}
+
+	for _, query := range dbParametersQueries("", roleName, oldParameters.(map[string]interface{}), newParameters.(map[string]interface{})) {
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not set parameters of role %s: %w", roleName, err)
+		}
+	}
+	return nil
+}
+
 // readRolePassword reads password either from Postgres if admin user is a superuser
 // or only from Terraform state.
 func readRolePassword(db *DBConnection, d *schema.ResourceData, roleCanLogin bool) (string, error) {
 	statePassword := d.Get(rolePasswordAttr).(string)
 
+	// password_wo cannot be compared with the password stored by Postgres
+	// as only its fingerprint is known.
+	if d.Get(rolePasswordWOFingerprintAttr).(string) != "" {
+		return statePassword, nil
+	}
+
 	// Role which cannot login does not have password in pg_shadow.
 	// Also, if user specifies that admin is not a superuser we don't try to read pg_shadow
 	// (only superuser can read pg_shadow)
@@ -600,9 +928,16 @@ func readRolePassword(db *DBConnection, d *schema.ResourceData, roleCanLogin boo
 				return statePassword, nil
 			}
 		}
-		if strings.HasPrefix(rolePassword, "SCRAM-SHA-256") {
-			return statePassword, nil
-			// TODO : implement scram-sha-256 challenge request to the server
+		if strings.HasPrefix(rolePassword, scramSHA256Prefix) {
+			// Recompute the verifier with the stored salt and iteration count,
+			// if it matches the password has not been changed outside of Terraform.
+			verifier, err := parseSCRAMVerifier(rolePassword)
+			if err != nil {
+				return "", fmt.Errorf("could not parse password of role %s: %w", d.Id(), err)
+			}
+			if verifier.matches(statePassword) {
+				return statePassword, nil
+			}
 		}
 	}
 	return rolePassword, nil
@@ -665,7 +1000,7 @@ func resourcePostgreSQLRoleUpdate(db *DBConnection, d *schema.ResourceData) erro
 	}
 
 	// applying roles: let's revoke all / grant the right ones
-	if err = revokeRoles(txn, d); err != nil {
+	if err = revokeRoles(db, txn, d); err != nil {
 		return err
 	}
 
@@ -689,6 +1024,10 @@ func resourcePostgreSQLRoleUpdate(db *DBConnection, d *schema.ResourceData) erro
 		return err
 	}
 
+	if err = setRoleParameters(txn, d); err != nil {
+		return err
+	}
+
 	if err = txn.Commit(); err != nil {
 		return fmt.Errorf("could not commit transaction: %w", err)
 	}
@@ -718,15 +1057,24 @@ func setRoleName(txn *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
+// rolePasswordChangeAttrs are the attributes whose change sets the role's password again.
+// If role is renamed, password is reset (as the md5 sum is also base on the role name)
+// so we need to update it
+var rolePasswordChangeAttrs = []string{
+	rolePasswordAttr, rolePasswordWOFingerprintAttr, rolePasswordVersionAttr,
+	roleNameAttr, roleHashPasswordAttr, roleHashPasswordIterationsAttr,
+}
+
 func setRolePassword(txn *sql.Tx, d *schema.ResourceData) error {
-	// If role is renamed, password is reset (as the md5 sum is also base on the role name)
-	// so we need to update it
-	if !d.HasChange(rolePasswordAttr) && !d.HasChange(roleNameAttr) {
+	if !d.HasChanges(rolePasswordChangeAttrs...) {
 		return nil
 	}
 
 	roleName := d.Get(roleNameAttr).(string)
-	password := d.Get(rolePasswordAttr).(string)
+	password, err := rolePasswordToSend(d)
+	if err != nil {
+		return err
+	}
 
 	sql := fmt.Sprintf("ALTER ROLE %s PASSWORD '%s'", pq.QuoteIdentifier(roleName), pqQuoteLiteral(password))
 	if _, err := txn.Exec(sql); err != nil {
@@ -735,6 +1083,88 @@ func setRolePassword(txn *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
+// rolePassword returns the configured password of the role, either from password or password_wo.
+func rolePassword(d *schema.ResourceData) string {
+	if password := d.Get(rolePasswordAttr).(string); password != "" {
+		return password
+	}
+	return rolePasswordWO(d)
+}
+
+// rolePasswordWO returns the configured password_wo.
+// It only holds a fingerprint in the state and the plan,
+// the password itself is only available in the configuration.
+func rolePasswordWO(d *schema.ResourceData) string {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return ""
+	}
+	password := config.GetAttr(rolePasswordWOAttr)
+	if password.IsNull() || !password.IsKnown() {
+		return ""
+	}
+	return password.AsString()
+}
+
+// discardPasswordWO is the StateFunc of password_wo: the password is never stored in the state,
+// its fingerprint is stored in password_wo_fingerprint instead.
+func discardPasswordWO(interface{}) string {
+	return ""
+}
+
+// customizeRolePasswordWODiff plans the fingerprint of password_wo, as the password itself is not in the state.
+// A change of the fingerprint sets the password again.
+func customizeRolePasswordWODiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	password := config.GetAttr(rolePasswordWOAttr)
+	if !password.IsKnown() || !d.NewValueKnown(roleNameAttr) || !d.NewValueKnown(rolePasswordVersionAttr) {
+		return d.SetNewComputed(rolePasswordWOFingerprintAttr)
+	}
+
+	fingerprint := ""
+	if !password.IsNull() {
+		fingerprint = passwordFingerprint(password.AsString(), d.Get(roleNameAttr).(string), d.Get(rolePasswordVersionAttr).(int))
+	}
+	if fingerprint == d.Get(rolePasswordWOFingerprintAttr).(string) {
+		return nil
+	}
+	return d.SetNew(rolePasswordWOFingerprintAttr, fingerprint)
+}
+
+// passwordFingerprint returns a PBKDF2-SHA256 hash of the password as pbkdf2-sha256:<hash>,
+// so a change can be detected without storing the password itself.
+// The salt is derived from the role name and the password version so the fingerprint is stable
+// between the plan and the apply, while the same password has different fingerprints for different roles.
+func passwordFingerprint(password, roleName string, version int) string {
+	if password == "" {
+		return ""
+	}
+	salt := []byte(fmt.Sprintf("%s:%d", roleName, version))
+	key := pbkdf2.Key([]byte(password), salt, scramDefaultIterations, sha256.Size, sha256.New)
+	return passwordFingerprintPrefix + hex.EncodeToString(key)
+}
+
+// rolePasswordToSend returns the password to send to Postgres.
+// If hash_password is set, the plaintext password is replaced by its SCRAM-SHA-256 verifier
+// computed with a random salt. Passwords already hashed are sent as is.
+func rolePasswordToSend(d *schema.ResourceData) (string, error) {
+	password := rolePassword(d)
+
+	if !d.Get(roleHashPasswordAttr).(bool) || password == "" ||
+		strings.HasPrefix(password, "md5") || strings.HasPrefix(password, scramSHA256Prefix) {
+		return password, nil
+	}
+
+	verifier, err := newRandomSCRAMVerifier(password, d.Get(roleHashPasswordIterationsAttr).(int))
+	if err != nil {
+		return "", err
+	}
+	return verifier.String(), nil
+}
+
 func setRoleBypassRLS(db *DBConnection, txn *sql.Tx, d *schema.ResourceData) error {
 	if !d.HasChange(roleBypassRLSAttr) {
 		return nil
@@ -888,15 +1318,22 @@ func setRoleSuperuser(txn *sql.Tx, d *schema.ResourceData) error {
 }
 
 func setRoleValidUntil(txn *sql.Tx, d *schema.ResourceData) error {
-	if !d.HasChange(roleValidUntilAttr) {
+	if d.Get(roleValidForAttr).(string) != "" {
+		// The expiration date is computed again each time the password is set.
+		if !d.HasChanges(append([]string{roleValidForAttr}, rolePasswordChangeAttrs...)...) {
+			return nil
+		}
+	} else if !d.HasChanges(roleValidUntilAttr, roleValidForAttr) {
 		return nil
 	}
 
-	validUntil := d.Get(roleValidUntilAttr).(string)
-	if validUntil == "" {
+	if d.Get(roleValidUntilAttr).(string) == "" && d.Get(roleValidForAttr).(string) == "" {
 		return nil
-	} else if strings.ToLower(validUntil) == "infinity" {
-		validUntil = "infinity"
+	}
+
+	validUntil, err := roleValidUntil(d, time.Now())
+	if err != nil {
+		return err
 	}
 
 	roleName := d.Get(roleNameAttr).(string)
@@ -908,10 +1345,58 @@ func setRoleValidUntil(txn *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
-func revokeRoles(txn *sql.Tx, d *schema.ResourceData) error {
+// roleValidUntil returns the VALID UNTIL value of the role,
+// computed from valid_for if it's set.
+func roleValidUntil(d *schema.ResourceData, now time.Time) (string, error) {
+	if validFor := d.Get(roleValidForAttr).(string); validFor != "" {
+		duration, err := parseRoleDuration(validFor)
+		if err != nil {
+			return "", err
+		}
+		return now.Add(duration).UTC().Format(time.RFC3339), nil
+	}
+
+	validUntil := d.Get(roleValidUntilAttr).(string)
+	if validUntil == "" || strings.ToLower(validUntil) == "infinity" {
+		return "infinity", nil
+	}
+	return validUntil, nil
+}
+
+// parseRoleDuration parses a duration as accepted by time.ParseDuration
+// or a number of days (e.g.: 90d).
+func parseRoleDuration(value string) (time.Duration, error) {
+	var duration time.Duration
+	if days := strings.TrimSuffix(value, "d"); days != value {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+		}
+		duration = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if duration, err = time.ParseDuration(value); err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+		}
+	}
+
+	if duration <= 0 {
+		return 0, fmt.Errorf("invalid duration %q: must be positive", value)
+	}
+	return duration, nil
+}
+
+func validateRoleDuration(v interface{}, key string) (warnings []string, errors []error) {
+	if _, err := parseRoleDuration(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%s: %w", key, err))
+	}
+	return
+}
+
+func revokeRoles(db *DBConnection, txn *sql.Tx, d *schema.ResourceData) error {
 	role := d.Get(roleNameAttr).(string)
 
-	query := `SELECT pg_get_userbyid(roleid)
+	query := `SELECT pg_get_userbyid(roleid), pg_get_userbyid(grantor)
 		FROM pg_catalog.pg_auth_members members
 		JOIN pg_catalog.pg_roles ON members.member = pg_roles.oid
 		WHERE rolname = $1`
@@ -922,21 +1407,31 @@ func revokeRoles(txn *sql.Tx, d *schema.ResourceData) error {
 	}
 	defer rows.Close()
 
-	grantedRoles := []string{}
+	type membership struct {
+		grantedRole string
+		grantor     string
+	}
+	grantedRoles := []membership{}
 	for rows.Next() {
-		var grantedRole string
+		var m membership
 
-		if err = rows.Scan(&grantedRole); err != nil {
+		if err = rows.Scan(&m.grantedRole, &m.grantor); err != nil {
 			return fmt.Errorf("could not scan role name for role %s: %w", role, err)
 		}
 		// We cannot revoke directly here as it shares the same cursor (with Tx)
 		// and rows.Next seems to retrieve result row by row.
 		// see: https://github.com/lib/pq/issues/81
-		grantedRoles = append(grantedRoles, grantedRole)
+		grantedRoles = append(grantedRoles, m)
 	}
 
-	for _, grantedRole := range grantedRoles {
+	for _, m := range grantedRoles {
+		grantedRole := m.grantedRole
 		query = fmt.Sprintf("REVOKE %s FROM %s", pq.QuoteIdentifier(grantedRole), pq.QuoteIdentifier(role))
+		// Since PostgreSQL 16, a REVOKE only removes the membership granted by the current user
+		// so the grantor of each membership needs to be specified.
+		if db.featureSupported(featureGrantRoleGrantedBy) {
+			query += " GRANTED BY " + pq.QuoteIdentifier(m.grantor)
+		}
 
 		log.Printf("[DEBUG] revoking role %s from %s", grantedRole, role)
 		if _, err := txn.Exec(query); err != nil {
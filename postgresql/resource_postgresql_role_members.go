@@ -0,0 +1,313 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	roleMembersRoleAttr            = "role"
+	roleMembersMembersAttr         = "members"
+	roleMembersExcludedMembersAttr = "excluded_members"
+)
+
+func resourcePostgreSQLRoleMembers() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLRoleMembersCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLRoleMembersRead),
+		Update: PGResourceFunc(resourcePostgreSQLRoleMembersUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLRoleMembersDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			roleMembersRoleAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the group role",
+			},
+			roleMembersMembersAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "The complete list of members of the group role, any other member is revoked",
+			},
+			roleMembersExcludedMembersAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Members which are not managed by this resource and never revoked (e.g.: rds_superuser or azure_pg_admin)",
+			},
+		},
+	}
+}
+
+type roleMembership struct {
+	member  string
+	grantor string
+	// grantedByBootstrap is true when the grantor is the bootstrap superuser,
+	// e.g. for the membership PostgreSQL 16 grants to the creator of a role.
+	grantedByBootstrap bool
+}
+
+func resourcePostgreSQLRoleMembersCreate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePrivileges) {
+		return fmt.Errorf(
+			"postgresql_role_members resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	if err := setRoleMembers(db, d); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get(roleMembersRoleAttr).(string))
+
+	return readRoleMembers(db, d)
+}
+
+func resourcePostgreSQLRoleMembersRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePrivileges) {
+		return fmt.Errorf(
+			"postgresql_role_members resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return readRoleMembers(db, d)
+}
+
+func resourcePostgreSQLRoleMembersUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePrivileges) {
+		return fmt.Errorf(
+			"postgresql_role_members resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	if err := setRoleMembers(db, d); err != nil {
+		return err
+	}
+
+	return readRoleMembers(db, d)
+}
+
+func resourcePostgreSQLRoleMembersDelete(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePrivileges) {
+		return fmt.Errorf(
+			"postgresql_role_members resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	role := d.Get(roleMembersRoleAttr).(string)
+	managed := d.Get(roleMembersMembersAttr).(*schema.Set)
+
+	txn, err := startTransaction(db.client, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := pgLockRole(txn, role); err != nil {
+		return err
+	}
+
+	memberships, err := getRoleMemberships(txn, role)
+	if err != nil {
+		return err
+	}
+
+	// Only the members declared in the resource are revoked, as we cannot know
+	// which of the other ones existed before it was created.
+	providerUser := db.client.config.getDatabaseUsername()
+	for _, m := range memberships {
+		if !managed.Contains(m.member) || isProviderRoleMembership(m, providerUser) {
+			continue
+		}
+		if err := revokeRoleMemberGrant(db, txn, role, m); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func readRoleMembers(db *DBConnection, d *schema.ResourceData) error {
+	role := d.Id()
+
+	exists, err := roleExists(db, role)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Printf("[WARN] PostgreSQL role (%s) not found", role)
+		d.SetId("")
+		return nil
+	}
+
+	memberships, err := getRoleMemberships(db, role)
+	if err != nil {
+		return err
+	}
+
+	excluded := d.Get(roleMembersExcludedMembersAttr).(*schema.Set)
+	managed := d.Get(roleMembersMembersAttr).(*schema.Set)
+	providerUser := db.client.config.getDatabaseUsername()
+	members := []string{}
+	for _, m := range memberships {
+		if excluded.Contains(m.member) || sliceContainsStr(members, m.member) {
+			continue
+		}
+		if isProviderRoleMembership(m, providerUser) && !managed.Contains(m.member) {
+			continue
+		}
+		members = append(members, m.member)
+	}
+
+	d.Set(roleMembersRoleAttr, role)
+	d.Set(roleMembersMembersAttr, stringSliceToSet(members))
+
+	return nil
+}
+
+// setRoleMembers grants the role to the declared members and revokes it from all the others,
+// except the excluded ones.
+func setRoleMembers(db *DBConnection, d *schema.ResourceData) error {
+	role := d.Get(roleMembersRoleAttr).(string)
+	members := d.Get(roleMembersMembersAttr).(*schema.Set)
+	excluded := d.Get(roleMembersExcludedMembersAttr).(*schema.Set)
+
+	txn, err := startTransaction(db.client, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := pgLockRole(txn, role); err != nil {
+		return err
+	}
+
+	memberships, err := getRoleMemberships(txn, role)
+	if err != nil {
+		return err
+	}
+
+	toRevoke, toGrant := roleMembersChanges(
+		memberships,
+		interfaceSliceToStrings(members.List()),
+		interfaceSliceToStrings(excluded.List()),
+		db.client.config.getDatabaseUsername(),
+	)
+
+	for _, m := range toRevoke {
+		if err := revokeRoleMemberGrant(db, txn, role, m); err != nil {
+			return err
+		}
+	}
+
+	for _, member := range toGrant {
+		log.Printf("[DEBUG] granting role %s to %s", role, member)
+		if _, err := txn.Exec(fmt.Sprintf("GRANT %s TO %s", pq.QuoteIdentifier(role), pq.QuoteIdentifier(member))); err != nil {
+			return fmt.Errorf("could not grant role %s to %s: %w", role, member, err)
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// roleMembersChanges returns the memberships to revoke, i.e. the ones of members neither declared nor excluded,
+// and the declared members to which the role needs to be granted.
+func roleMembersChanges(memberships []roleMembership, members, excluded []string, providerUser string) ([]roleMembership, []string) {
+	toRevoke := []roleMembership{}
+	currentMembers := []string{}
+	for _, m := range memberships {
+		currentMembers = append(currentMembers, m.member)
+		if sliceContainsStr(members, m.member) || sliceContainsStr(excluded, m.member) {
+			continue
+		}
+		if isProviderRoleMembership(m, providerUser) {
+			continue
+		}
+		toRevoke = append(toRevoke, m)
+	}
+
+	toGrant := []string{}
+	for _, member := range members {
+		if !sliceContainsStr(currentMembers, member) {
+			toGrant = append(toGrant, member)
+		}
+	}
+	sort.Strings(toGrant)
+
+	return toRevoke, toGrant
+}
+
+// isProviderRoleMembership returns true for the membership PostgreSQL 16 automatically grants to the provider user,
+// when it's not a superuser, on the roles it creates. Revoking it would prevent the provider from managing the role.
+func isProviderRoleMembership(m roleMembership, providerUser string) bool {
+	return m.grantedByBootstrap && m.member == providerUser
+}
+
+func revokeRoleMemberGrant(db *DBConnection, txn *sql.Tx, role string, m roleMembership) error {
+	query := fmt.Sprintf("REVOKE %s FROM %s", pq.QuoteIdentifier(role), pq.QuoteIdentifier(m.member))
+	// Since PostgreSQL 16, a REVOKE only removes the membership granted by the current user
+	// so the grantor of each membership needs to be specified.
+	if db.featureSupported(featureGrantRoleGrantedBy) {
+		query += " GRANTED BY " + pq.QuoteIdentifier(m.grantor)
+	}
+
+	log.Printf("[DEBUG] revoking role %s from %s", role, m.member)
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("could not revoke role %s from %s: %w", role, m.member, err)
+	}
+	return nil
+}
+
+// getRoleMemberships returns the members of a role with the grantor of each membership.
+// The same member can appear several times with different grantors since PostgreSQL 16.
+func getRoleMemberships(db QueryAble, role string) ([]roleMembership, error) {
+	rows, err := db.Query(`
+SELECT pg_get_userbyid(m.member), pg_get_userbyid(m.grantor), m.grantor = 10
+  FROM pg_catalog.pg_auth_members m
+  JOIN pg_catalog.pg_roles r ON r.oid = m.roleid
+ WHERE r.rolname = $1
+ ORDER BY 1, 2
+`, role)
+	if err != nil {
+		return nil, fmt.Errorf("could not read members of role %s: %w", role, err)
+	}
+	defer rows.Close()
+
+	var memberships []roleMembership
+	for rows.Next() {
+		var m roleMembership
+		if err := rows.Scan(&m.member, &m.grantor, &m.grantedByBootstrap); err != nil {
+			return nil, fmt.Errorf("could not scan member of role %s: %w", role, err)
+		}
+		memberships = append(memberships, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not read members of role %s: %w", role, err)
+	}
+
+	return memberships, nil
+}
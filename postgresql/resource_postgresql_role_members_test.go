@@ -0,0 +1,140 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleMembersChanges(t *testing.T) {
+	memberships := []roleMembership{
+		{member: "alice", grantor: "postgres"},
+		{member: "bob", grantor: "postgres"},
+		{member: "bob", grantor: "admin"},
+		{member: "rds_superuser", grantor: "rdsadmin"},
+	}
+
+	toRevoke, toGrant := roleMembersChanges(memberships, []string{"alice", "carol"}, []string{"rds_superuser"}, "")
+	assert.Equal(t, []roleMembership{
+		{member: "bob", grantor: "postgres"},
+		{member: "bob", grantor: "admin"},
+	}, toRevoke)
+	assert.Equal(t, []string{"carol"}, toGrant)
+
+	toRevoke, toGrant = roleMembersChanges(nil, []string{"bob", "alice"}, nil, "")
+	assert.Empty(t, toRevoke)
+	assert.Equal(t, []string{"alice", "bob"}, toGrant)
+
+	// The membership granted to the provider user on the roles it creates is never revoked,
+	// unlike the ones granted by other roles.
+	memberships = []roleMembership{
+		{member: "terraform", grantor: "postgres", grantedByBootstrap: true},
+		{member: "terraform", grantor: "admin"},
+		{member: "alice", grantor: "postgres", grantedByBootstrap: true},
+	}
+	toRevoke, toGrant = roleMembersChanges(memberships, nil, nil, "terraform")
+	assert.Equal(t, []roleMembership{
+		{member: "terraform", grantor: "admin"},
+		{member: "alice", grantor: "postgres", grantedByBootstrap: true},
+	}, toRevoke)
+	assert.Empty(t, toGrant)
+}
+
+func TestAccPostgresqlRoleMembers_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	config := getTestConfig(t)
+	dsn := config.connStr("postgres")
+
+	dbSuffix, teardown := setupTestDatabase(t, false, true)
+	defer teardown()
+
+	_, roleName := getTestDBNames(dbSuffix)
+
+	resourceConfig := `
+resource "postgresql_role" "group" {
+  name = "test_group"
+}
+
+resource "postgresql_role" "member" {
+  name = "test_member"
+}
+
+resource "postgresql_role_members" "group" {
+  role             = postgresql_role.group.name
+  members          = [%s]
+  excluded_members = ["%s"]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(resourceConfig, "postgresql_role.member.name", roleName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role_members.group", "members.#", "1"),
+					resource.TestCheckTypeSetElemAttr("postgresql_role_members.group", "members.*", "test_member"),
+					checkGrantRole(t, dsn, "test_member", "test_group", false),
+				),
+			},
+			{
+				// A member added outside of Terraform is revoked, except the excluded ones.
+				PreConfig: func() {
+					dbExecute(t, dsn, "CREATE ROLE test_intruder")
+					dbExecute(t, dsn, "GRANT test_group TO test_intruder")
+					dbExecute(t, dsn, fmt.Sprintf("GRANT test_group TO %s", roleName))
+				},
+				Config: fmt.Sprintf(resourceConfig, "postgresql_role.member.name", roleName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role_members.group", "members.#", "1"),
+					checkGrantRole(t, dsn, roleName, "test_group", false),
+					testAccCheckRoleNotMember(dsn, "test_intruder", "test_group"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(resourceConfig, "", roleName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role_members.group", "members.#", "0"),
+					testAccCheckRoleNotMember(dsn, "test_member", "test_group"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_role_members.group",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"excluded_members", "members"},
+			},
+		},
+	})
+
+	dbExecute(t, dsn, "DROP ROLE IF EXISTS test_intruder")
+}
+
+func testAccCheckRoleNotMember(dsn, role, grantRole string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		db, err := sql.Open("postgres", dsn)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		var isMember bool
+		if err := db.QueryRow("SELECT pg_has_role($1, $2, 'MEMBER')", role, grantRole).Scan(&isMember); err != nil {
+			return fmt.Errorf("could not check membership of %s in %s: %w", role, grantRole, err)
+		}
+		if isMember {
+			return fmt.Errorf("role %s is still a member of %s", role, grantRole)
+		}
+		return nil
+	}
+}
@@ -5,14 +5,150 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"regexp"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
 )
 
+func TestRolePasswordToSend(t *testing.T) {
+	newResourceData := func(raw map[string]interface{}) *schema.ResourceData {
+		return schema.TestResourceDataRaw(t, resourcePostgreSQLRole().Schema, raw)
+	}
+
+	password, err := rolePasswordToSend(newResourceData(map[string]interface{}{
+		"name":     "role",
+		"password": "toto",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "toto", password)
+
+	password, err = rolePasswordToSend(newResourceData(map[string]interface{}{
+		"name":                     "role",
+		"password":                 "toto",
+		"hash_password":            true,
+		"hash_password_iterations": 8192,
+	}))
+	assert.NoError(t, err)
+	verifier, err := parseSCRAMVerifier(password)
+	assert.NoError(t, err)
+	assert.Equal(t, 8192, verifier.Iterations)
+	assert.Len(t, verifier.Salt, scramSaltLength)
+	assert.True(t, verifier.matches("toto"))
+
+	// Each call uses a new salt
+	other, err := rolePasswordToSend(newResourceData(map[string]interface{}{
+		"name":          "role",
+		"password":      "toto",
+		"hash_password": true,
+	}))
+	assert.NoError(t, err)
+	assert.NotEqual(t, password, other)
+
+	password, err = rolePasswordToSend(newResourceData(map[string]interface{}{
+		"name":          "role",
+		"password":      "md5c98cbfeb6a347a47eb8e96cfb4c4b890",
+		"hash_password": true,
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "md5c98cbfeb6a347a47eb8e96cfb4c4b890", password)
+}
+
+func TestPasswordFingerprint(t *testing.T) {
+	fingerprint := passwordFingerprint("toto", "role", 1)
+	assert.True(t, strings.HasPrefix(fingerprint, passwordFingerprintPrefix))
+	assert.NotContains(t, fingerprint, "toto")
+	assert.Equal(t, "", passwordFingerprint("", "role", 1))
+
+	// The fingerprint is stable, and salted with the role name and the password version.
+	assert.Equal(t, fingerprint, passwordFingerprint("toto", "role", 1))
+	assert.NotEqual(t, fingerprint, passwordFingerprint("titi", "role", 1))
+	assert.NotEqual(t, fingerprint, passwordFingerprint("toto", "other_role", 1))
+	assert.NotEqual(t, fingerprint, passwordFingerprint("toto", "role", 2))
+}
+
+func TestReadRoleParameters(t *testing.T) {
+	roleConfig := pq.ByteaArray{
+		[]byte(`search_path="$user", public`),
+		[]byte("statement_timeout=30000"),
+		[]byte("role=admin"),
+		[]byte("lock_timeout=5s"),
+		[]byte("log_statement=ddl"),
+	}
+
+	// No configured value differs from the stored one, so pg_settings is not needed.
+	parameters, err := readRoleParameters(nil, roleConfig, map[string]interface{}{"lock_timeout": "5s"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"lock_timeout":  "5s",
+		"log_statement": "ddl",
+	}, parameters)
+}
+
+func TestParseRoleDuration(t *testing.T) {
+	duration, err := parseRoleDuration("90d")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*24*time.Hour, duration)
+
+	duration, err = parseRoleDuration("36h30m")
+	assert.NoError(t, err)
+	assert.Equal(t, 36*time.Hour+30*time.Minute, duration)
+
+	for _, value := range []string{"", "d", "1.5d", "-1d", "0h", "ninety days"} {
+		_, err := parseRoleDuration(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestRoleValidUntil(t *testing.T) {
+	newResourceData := func(raw map[string]interface{}) *schema.ResourceData {
+		return schema.TestResourceDataRaw(t, resourcePostgreSQLRole().Schema, raw)
+	}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	validUntil, err := roleValidUntil(newResourceData(map[string]interface{}{"name": "foo"}), now)
+	assert.NoError(t, err)
+	assert.Equal(t, "infinity", validUntil)
+
+	validUntil, err = roleValidUntil(newResourceData(map[string]interface{}{
+		"name":        "foo",
+		"valid_until": "2025-01-01 00:00:00+00",
+	}), now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-01-01 00:00:00+00", validUntil)
+
+	validUntil, err = roleValidUntil(newResourceData(map[string]interface{}{
+		"name":      "foo",
+		"valid_for": "90d",
+	}), now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-31T12:00:00Z", validUntil)
+}
+
+func TestRoleExpiryWarning(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	window := 14 * 24 * time.Hour
+
+	assert.Nil(t, roleExpiryWarning("foo", now.Add(30*24*time.Hour), now, window))
+
+	diags := roleExpiryWarning("foo", now.Add(7*24*time.Hour), now, window)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Role foo expires soon", diags[0].Summary)
+
+	diags = roleExpiryWarning("foo", now.Add(-time.Hour), now, window)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "Role foo has expired", diags[0].Summary)
+}
+
 func TestAccPostgresqlRole_Basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -192,6 +328,384 @@ resource "postgresql_role" "update_role" {
 	})
 }
 
+// Test that a password changed outside of Terraform is detected when
+// Postgres stores it as a SCRAM-SHA-256 verifier.
+func TestAccPostgresqlRole_SCRAMPasswordDrift(t *testing.T) {
+	skipIfNotAcc(t)
+	testSuperuserPreCheck(t)
+
+	var roleConfig = `
+resource "postgresql_role" "scram_role" {
+  name     = "scram_role"
+  login    = true
+  password = "toto"
+}
+`
+
+	alterPassword := func(password string) {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			t.Fatalf("could not connect: %v", err)
+		}
+		txn, err := db.Begin()
+		if err != nil {
+			t.Fatalf("could not start transaction: %v", err)
+		}
+		defer deferredRollback(txn)
+		if _, err := txn.Exec("SET LOCAL password_encryption = 'scram-sha-256'"); err != nil {
+			t.Fatalf("could not set password_encryption: %v", err)
+		}
+		if _, err := txn.Exec(fmt.Sprintf("ALTER ROLE scram_role PASSWORD '%s'", password)); err != nil {
+			t.Fatalf("could not alter role password: %v", err)
+		}
+		if err := txn.Commit(); err != nil {
+			t.Fatalf("could not commit: %v", err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: roleConfig,
+			},
+			{
+				// Same password hashed with SCRAM-SHA-256: no drift.
+				PreConfig: func() { alterPassword("toto") },
+				Config:    roleConfig,
+				PlanOnly:  true,
+			},
+			{
+				PreConfig:          func() { alterPassword("titi") },
+				Config:             roleConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlRole_HashPassword(t *testing.T) {
+	skipIfNotAcc(t)
+
+	var roleConfig = `
+resource "postgresql_role" "hashed_role" {
+  name                     = "hashed_role"
+  login                    = true
+  password                 = "%s"
+  hash_password            = true
+  hash_password_iterations = 8192
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(roleConfig, "toto"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.hashed_role", "password", "toto"),
+					testAccCheckRoleCanLogin(t, "hashed_role", "toto"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(roleConfig, "titi"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.hashed_role", "password", "titi"),
+					testAccCheckRoleCanLogin(t, "hashed_role", "titi"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlRole_PasswordWO(t *testing.T) {
+	skipIfNotAcc(t)
+
+	var roleConfig = `
+resource "postgresql_role" "wo_role" {
+  name             = "wo_role"
+  login            = true
+  password_wo      = "%s"
+  password_version = %d
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(roleConfig, "toto", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.wo_role", "password", ""),
+					testAccCheckPasswordFingerprint("postgresql_role.wo_role", "toto", 1),
+					testAccCheckRoleCanLogin(t, "wo_role", "toto"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(roleConfig, "titi", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPasswordFingerprint("postgresql_role.wo_role", "titi", 2),
+					resource.TestCheckResourceAttr("postgresql_role.wo_role", "password_version", "2"),
+					testAccCheckRoleCanLogin(t, "wo_role", "titi"),
+				),
+			},
+			{
+				// A change of password_wo alone is detected through its fingerprint.
+				Config: fmt.Sprintf(roleConfig, "tata", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPasswordFingerprint("postgresql_role.wo_role", "tata", 2),
+					testAccCheckRoleCanLogin(t, "wo_role", "tata"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPasswordFingerprint(n, password string, version int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+		if value := rs.Primary.Attributes[rolePasswordWOAttr]; value != "" {
+			return fmt.Errorf("password_wo is stored in the state: %s", value)
+		}
+		expected := passwordFingerprint(password, rs.Primary.Attributes[roleNameAttr], version)
+		if fingerprint := rs.Primary.Attributes[rolePasswordWOFingerprintAttr]; fingerprint != expected {
+			return fmt.Errorf("password_wo fingerprint %s does not match the password", fingerprint)
+		}
+		return nil
+	}
+}
+
+func TestAccPostgresqlRole_ValidFor(t *testing.T) {
+	skipIfNotAcc(t)
+
+	var roleConfig = `
+resource "postgresql_role" "contractor" {
+  name     = "contractor"
+  login    = true
+  password = "%s"
+  %s
+}
+`
+
+	validFor := `
+  valid_for             = "90d"
+  expiry_warning_window = "14d"
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(roleConfig, "toto", validFor),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.contractor", "valid_for", "90d"),
+					resource.TestCheckResourceAttrWith("postgresql_role.contractor", "valid_until", checkRoleValidFor(90*24*time.Hour)),
+				),
+			},
+			{
+				Config: fmt.Sprintf(roleConfig, "titi", validFor),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("postgresql_role.contractor", "valid_until", checkRoleValidFor(90*24*time.Hour)),
+				),
+			},
+			{
+				Config: fmt.Sprintf(roleConfig, "titi", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.contractor", "valid_for", ""),
+					resource.TestCheckResourceAttr("postgresql_role.contractor", "valid_until", "infinity"),
+				),
+			},
+		},
+	})
+}
+
+// checkRoleValidFor checks that the role expires around validFor from now.
+func checkRoleValidFor(validFor time.Duration) resource.CheckResourceAttrWithFunc {
+	return func(value string) error {
+		validUntil, err := time.Parse("2006-01-02 15:04:05-07", value)
+		if err != nil {
+			return fmt.Errorf("could not parse valid_until %q: %w", value, err)
+		}
+		if delta := time.Until(validUntil) - validFor; delta > 0 || delta < -time.Hour {
+			return fmt.Errorf("expected valid_until %q to be %s from now", value, validFor)
+		}
+		return nil
+	}
+}
+
+// Test that objects owned by a role in another database than the provider's one
+// are reassigned when the role is removed.
+func TestAccPostgresqlRole_ReassignOwnedTo(t *testing.T) {
+	skipIfNotAcc(t)
+	testSuperuserPreCheck(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+
+	roleConfig := fmt.Sprintf(`
+resource "postgresql_role" "owner_role" {
+  name              = "owner_role"
+  reassign_owned_to = "%s"
+}
+`, roleName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckPostgresqlRoleDestroy,
+			func(*terraform.State) error {
+				db, err := sql.Open("postgres", config.connStr(dbName))
+				if err != nil {
+					return err
+				}
+				defer db.Close()
+
+				var owner string
+				if err := db.QueryRow(
+					"SELECT tableowner FROM pg_catalog.pg_tables WHERE schemaname = 'test_schema' AND tablename = 'owned_table'",
+				).Scan(&owner); err != nil {
+					return fmt.Errorf("could not read owner of test_schema.owned_table: %w", err)
+				}
+				if owner != roleName {
+					return fmt.Errorf("expected test_schema.owned_table to be owned by %s, got %s", roleName, owner)
+				}
+				return nil
+			},
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: roleConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.owner_role", "dependent_databases.#", "0"),
+				),
+			},
+			{
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.owned_table (id int)")
+					dbExecute(t, config.connStr(dbName), "ALTER TABLE test_schema.owned_table OWNER TO owner_role")
+				},
+				Config: roleConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.owner_role", "dependent_databases.#", "1"),
+					resource.TestCheckTypeSetElemAttr("postgresql_role.owner_role", "dependent_databases.*", dbName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlRole_Parameters(t *testing.T) {
+	skipIfNotAcc(t)
+
+	var roleConfig = `
+resource "postgresql_role" "params_role" {
+  name              = "params_role"
+  statement_timeout = 30000
+  parameters = {
+    %s
+  }
+}
+`
+	config := getTestConfig(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(roleConfig, `statement_timeout = "1min"`),
+				ExpectError: regexp.MustCompile("must be set with the statement_timeout attribute"),
+			},
+			{
+				Config: fmt.Sprintf(roleConfig, `
+    lock_timeout  = "1min"
+    log_statement = "ddl"
+    work_mem      = "64MB"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.params_role", "parameters.%", "3"),
+					resource.TestCheckResourceAttr("postgresql_role.params_role", "parameters.lock_timeout", "1min"),
+					resource.TestCheckResourceAttr("postgresql_role.params_role", "statement_timeout", "30000"),
+				),
+			},
+			{
+				// Same value in another unit: no drift.
+				PreConfig: func() {
+					dbExecute(t, config.connStr("postgres"), "ALTER ROLE params_role SET lock_timeout = 60000")
+				},
+				Config: fmt.Sprintf(roleConfig, `
+    lock_timeout  = "1min"
+    log_statement = "ddl"
+    work_mem      = "64MB"
+`),
+				PlanOnly: true,
+			},
+			{
+				// Parameters set outside of Terraform are reset.
+				PreConfig: func() {
+					dbExecute(t, config.connStr("postgres"), "ALTER ROLE params_role SET maintenance_work_mem = '1GB'")
+				},
+				Config: fmt.Sprintf(roleConfig, `
+    lock_timeout = "1min"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.params_role", "parameters.%", "1"),
+					resource.TestCheckResourceAttr("postgresql_role.params_role", "statement_timeout", "30000"),
+				),
+			},
+			{
+				// Without parameters, the parameters set outside of Terraform are kept.
+				PreConfig: func() {
+					dbExecute(t, config.connStr("postgres"), "ALTER ROLE params_role SET work_mem = '32MB'")
+				},
+				Config: `
+resource "postgresql_role" "params_role" {
+  name              = "params_role"
+  statement_timeout = 30000
+}
+`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 // Test to create a role with admin user (usually postgres) granted to it
 // There were a bug on RDS like setup (with a non-superuser postgres role)
 // where it couldn't delete the role in this case.
@@ -0,0 +1,596 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	seqNameAttr            = "name"
+	seqSchemaAttr          = "schema"
+	seqDatabaseAttr        = "database"
+	seqDataTypeAttr        = "data_type"
+	seqIncrementAttr       = "increment"
+	seqMinValueAttr        = "min_value"
+	seqMaxValueAttr        = "max_value"
+	seqStartAttr           = "start"
+	seqCacheAttr           = "cache"
+	seqCycleAttr           = "cycle"
+	seqOwnedByAttr         = "owned_by"
+	seqOwnerAttr           = "owner"
+	seqRestartOnChangeAttr = "restart_on_change"
+	seqCurrentValueAttr    = "current_value"
+	seqDropCascadeAttr     = "drop_cascade"
+)
+
+var seqDataTypes = []string{"smallint", "integer", "bigint"}
+
+func resourcePostgreSQLSequence() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLSequenceCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLSequenceRead),
+		Update: PGResourceFunc(resourcePostgreSQLSequenceUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLSequenceDelete),
+		Exists: PGResourceExistsFunc(resourcePostgreSQLSequenceExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			seqNameAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the sequence",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			seqSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The schema where the sequence is located. If not specified, the public schema is used",
+			},
+			seqDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the sequence is located. If not specified, the provider default database is used",
+			},
+			seqDataTypeAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The data type of the sequence. One of: " + strings.Join(seqDataTypes, ", "),
+				ValidateFunc: validation.StringInSlice(seqDataTypes, false),
+			},
+			seqIncrementAttr: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "The value added to the current sequence value to create a new value",
+				ValidateFunc: validation.IntNotInSlice([]int{0}),
+			},
+			seqMinValueAttr: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The minimum value the sequence can generate",
+			},
+			seqMaxValueAttr: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum value the sequence can generate",
+			},
+			seqStartAttr: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The starting value of the sequence",
+			},
+			seqCacheAttr: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "How many sequence numbers are preallocated and stored in memory for faster access",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			seqCycleAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the sequence wraps around when the max value (or min value for descending sequences) is reached",
+			},
+			seqOwnedByAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The table column the sequence is associated with, in the form table.column or schema.table.column",
+
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					pgSchema := getSequenceSchema(d)
+					return normalizeSequenceOwnedBy(pgSchema, old) == normalizeSequenceOwnedBy(pgSchema, new)
+				},
+			},
+			seqOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ROLE which owns the sequence",
+			},
+			seqRestartOnChangeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, the sequence is restarted at its start value (with setval) when the start value changes",
+			},
+			seqCurrentValueAttr: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The last value returned by the sequence",
+			},
+			seqDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the sequence (such as column defaults)",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLSequenceCreate(db *DBConnection, d *schema.ResourceData) error {
+	if _, ok := d.GetOk(seqDataTypeAttr); ok && !db.featureSupported(featureSequenceDataType) {
+		return fmt.Errorf(
+			"data_type for sequences is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getSequenceSchema(d)
+	seqName := d.Get(seqNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	owner := d.Get(seqOwnerAttr).(string)
+	var rolesToGrant []string
+	if owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		if _, err := txn.Exec(createSequenceQuery(d, pgSchema, seqName)); err != nil {
+			return fmt.Errorf("could not create sequence %s: %w", seqName, err)
+		}
+
+		return setSequenceOwner(txn, pgSchema, seqName, owner)
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateSequenceID(database, pgSchema, seqName))
+
+	return resourcePostgreSQLSequenceReadImpl(db, d)
+}
+
+func resourcePostgreSQLSequenceExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, pgSchema, seqName, err := getDBSequenceName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var oid uint32
+	err = txn.QueryRow(sequenceOIDQuery, pgSchema, seqName).Scan(&oid)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading sequence: %w", err)
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLSequenceRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLSequenceReadImpl(db, d)
+}
+
+const sequenceOIDQuery = `
+SELECT c.oid
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind = 'S'
+`
+
+func resourcePostgreSQLSequenceReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, pgSchema, seqName, err := getDBSequenceName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var seqOID uint32
+	var seqOwner, ownedBy string
+	err = txn.QueryRow(`
+SELECT c.oid, pg_catalog.pg_get_userbyid(c.relowner),
+       COALESCE((
+           SELECT concat_ws('.', tn.nspname, t.relname, a.attname)
+             FROM pg_catalog.pg_depend dep
+             JOIN pg_catalog.pg_class t ON t.oid = dep.refobjid
+             JOIN pg_catalog.pg_namespace tn ON tn.oid = t.relnamespace
+             JOIN pg_catalog.pg_attribute a ON a.attrelid = dep.refobjid AND a.attnum = dep.refobjsubid
+            WHERE dep.classid = 'pg_catalog.pg_class'::regclass AND dep.objid = c.oid
+              AND dep.refclassid = 'pg_catalog.pg_class'::regclass AND dep.deptype IN ('a', 'i')
+            LIMIT 1
+       ), '')
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind = 'S'
+`, pgSchema, seqName).Scan(&seqOID, &seqOwner, &ownedBy)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL sequence (%s) not found in database %s", seqName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading sequence: %w", err)
+	}
+
+	var dataType string
+	var increment, minValue, maxValue, start, cache int64
+	var cycle bool
+	if db.featureSupported(featureSequenceDataType) {
+		err = txn.QueryRow(`
+SELECT pg_catalog.format_type(seqtypid, NULL), seqincrement, seqmin, seqmax, seqstart, seqcache, seqcycle
+  FROM pg_catalog.pg_sequence
+ WHERE seqrelid = $1
+`, seqOID).Scan(&dataType, &increment, &minValue, &maxValue, &start, &cache, &cycle)
+	} else {
+		// Before Postgres 10, the parameters are stored in the sequence relation itself.
+		dataType = "bigint"
+		err = txn.QueryRow(fmt.Sprintf(
+			"SELECT increment_by, min_value, max_value, start_value, cache_value, is_cycled FROM %s",
+			tableIdentifier(pgSchema, seqName),
+		)).Scan(&increment, &minValue, &maxValue, &start, &cache, &cycle)
+	}
+	if err != nil {
+		return fmt.Errorf("could not read parameters of sequence %s: %w", seqName, err)
+	}
+
+	var currentValue int64
+	if err := txn.QueryRow(
+		fmt.Sprintf("SELECT last_value FROM %s", tableIdentifier(pgSchema, seqName)),
+	).Scan(&currentValue); err != nil {
+		return fmt.Errorf("could not read current value of sequence %s: %w", seqName, err)
+	}
+
+	// owned_by is returned relative to the sequence schema if the table is in the same schema.
+	ownedBy = strings.TrimPrefix(ownedBy, pgSchema+".")
+	if d.Get(seqOwnedByAttr).(string) != "" &&
+		normalizeSequenceOwnedBy(pgSchema, d.Get(seqOwnedByAttr).(string)) == normalizeSequenceOwnedBy(pgSchema, ownedBy) {
+		ownedBy = d.Get(seqOwnedByAttr).(string)
+	}
+
+	d.Set(seqNameAttr, seqName)
+	d.Set(seqSchemaAttr, pgSchema)
+	d.Set(seqDatabaseAttr, database)
+	d.Set(seqDataTypeAttr, dataType)
+	d.Set(seqIncrementAttr, increment)
+	d.Set(seqMinValueAttr, minValue)
+	d.Set(seqMaxValueAttr, maxValue)
+	d.Set(seqStartAttr, start)
+	d.Set(seqCacheAttr, cache)
+	d.Set(seqCycleAttr, cycle)
+	d.Set(seqOwnedByAttr, ownedBy)
+	d.Set(seqOwnerAttr, seqOwner)
+	d.Set(seqCurrentValueAttr, currentValue)
+	d.SetId(generateSequenceID(database, pgSchema, seqName))
+
+	return nil
+}
+
+func resourcePostgreSQLSequenceUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if d.HasChange(seqDataTypeAttr) && !db.featureSupported(featureSequenceDataType) {
+		return fmt.Errorf(
+			"data_type for sequences is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getSequenceSchema(d)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	owner := d.Get(seqOwnerAttr).(string)
+	if d.HasChange(seqOwnerAttr) {
+		oldOwner, _ := d.GetChange(seqOwnerAttr)
+		owner = oldOwner.(string)
+	}
+	var rolesToGrant []string
+	if owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		if err := setSequenceName(txn, d, pgSchema); err != nil {
+			return err
+		}
+
+		seqName := d.Get(seqNameAttr).(string)
+
+		if query := alterSequenceQuery(d, pgSchema, seqName); query != "" {
+			if _, err := txn.Exec(query); err != nil {
+				return fmt.Errorf("could not alter sequence %s: %w", seqName, err)
+			}
+		}
+
+		if d.Get(seqRestartOnChangeAttr).(bool) && d.HasChange(seqStartAttr) {
+			if _, err := txn.Exec(
+				"SELECT pg_catalog.setval($1::regclass, $2, false)",
+				tableIdentifier(pgSchema, seqName), d.Get(seqStartAttr).(int),
+			); err != nil {
+				return fmt.Errorf("could not restart sequence %s: %w", seqName, err)
+			}
+		}
+
+		if d.HasChange(seqOwnerAttr) {
+			return setSequenceOwner(txn, pgSchema, seqName, d.Get(seqOwnerAttr).(string))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourcePostgreSQLSequenceReadImpl(db, d)
+}
+
+func resourcePostgreSQLSequenceDelete(db *DBConnection, d *schema.ResourceData) error {
+	database, pgSchema, seqName, err := getDBSequenceName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var rolesToGrant []string
+	if owner := d.Get(seqOwnerAttr).(string); owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		dropMode := "RESTRICT"
+		if d.Get(seqDropCascadeAttr).(bool) {
+			dropMode = "CASCADE"
+		}
+
+		query := fmt.Sprintf("DROP SEQUENCE IF EXISTS %s %s", tableIdentifier(pgSchema, seqName), dropMode)
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not drop sequence %s: %w", seqName, err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func createSequenceQuery(d *schema.ResourceData, pgSchema, seqName string) string {
+	b := bytes.NewBufferString("CREATE SEQUENCE ")
+	fmt.Fprint(b, tableIdentifier(pgSchema, seqName))
+
+	if v, ok := d.GetOk(seqDataTypeAttr); ok {
+		fmt.Fprint(b, " AS ", v.(string))
+	}
+
+	fmt.Fprint(b, " INCREMENT BY ", d.Get(seqIncrementAttr).(int))
+
+	// min_value, max_value and start are computed by Postgres if not specified
+	// so we cannot rely on GetOk which ignores zero values.
+	if isAttrConfigured(d, seqMinValueAttr) {
+		fmt.Fprint(b, " MINVALUE ", d.Get(seqMinValueAttr).(int))
+	}
+	if isAttrConfigured(d, seqMaxValueAttr) {
+		fmt.Fprint(b, " MAXVALUE ", d.Get(seqMaxValueAttr).(int))
+	}
+	if isAttrConfigured(d, seqStartAttr) {
+		fmt.Fprint(b, " START WITH ", d.Get(seqStartAttr).(int))
+	}
+
+	fmt.Fprint(b, " CACHE ", d.Get(seqCacheAttr).(int))
+
+	if d.Get(seqCycleAttr).(bool) {
+		b.WriteString(" CYCLE")
+	} else {
+		b.WriteString(" NO CYCLE")
+	}
+
+	if ownedBy := d.Get(seqOwnedByAttr).(string); ownedBy != "" {
+		fmt.Fprint(b, " OWNED BY ", quoteSequenceOwnedBy(pgSchema, ownedBy))
+	}
+
+	return b.String()
+}
+
+// alterSequenceQuery returns the ALTER SEQUENCE statement for the changed parameters
+// or an empty string if there's nothing to change.
+func alterSequenceQuery(d *schema.ResourceData, pgSchema, seqName string) string {
+	var clauses []string
+
+	if d.HasChange(seqDataTypeAttr) {
+		if v := d.Get(seqDataTypeAttr).(string); v != "" {
+			clauses = append(clauses, "AS "+v)
+		}
+	}
+	if d.HasChange(seqIncrementAttr) {
+		clauses = append(clauses, fmt.Sprintf("INCREMENT BY %d", d.Get(seqIncrementAttr).(int)))
+	}
+	if d.HasChange(seqMinValueAttr) {
+		clauses = append(clauses, fmt.Sprintf("MINVALUE %d", d.Get(seqMinValueAttr).(int)))
+	}
+	if d.HasChange(seqMaxValueAttr) {
+		clauses = append(clauses, fmt.Sprintf("MAXVALUE %d", d.Get(seqMaxValueAttr).(int)))
+	}
+	if d.HasChange(seqStartAttr) {
+		clauses = append(clauses, fmt.Sprintf("START WITH %d", d.Get(seqStartAttr).(int)))
+	}
+	if d.HasChange(seqCacheAttr) {
+		clauses = append(clauses, fmt.Sprintf("CACHE %d", d.Get(seqCacheAttr).(int)))
+	}
+	if d.HasChange(seqCycleAttr) {
+		if d.Get(seqCycleAttr).(bool) {
+			clauses = append(clauses, "CYCLE")
+		} else {
+			clauses = append(clauses, "NO CYCLE")
+		}
+	}
+	if d.HasChange(seqOwnedByAttr) {
+		if ownedBy := d.Get(seqOwnedByAttr).(string); ownedBy != "" {
+			clauses = append(clauses, "OWNED BY "+quoteSequenceOwnedBy(pgSchema, ownedBy))
+		} else {
+			clauses = append(clauses, "OWNED BY NONE")
+		}
+	}
+
+	if len(clauses) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("ALTER SEQUENCE %s %s", tableIdentifier(pgSchema, seqName), strings.Join(clauses, " "))
+}
+
+func setSequenceName(txn *sql.Tx, d *schema.ResourceData, pgSchema string) error {
+	if !d.HasChange(seqNameAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(seqNameAttr)
+	o := oraw.(string)
+	n := nraw.(string)
+	if n == "" {
+		return errors.New("Error setting sequence name to an empty string")
+	}
+
+	query := fmt.Sprintf("ALTER SEQUENCE %s RENAME TO %s", tableIdentifier(pgSchema, o), pq.QuoteIdentifier(n))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating sequence name: %w", err)
+	}
+
+	d.SetId(generateSequenceID(getDatabase(d, ""), pgSchema, n))
+
+	return nil
+}
+
+func setSequenceOwner(txn *sql.Tx, pgSchema, seqName, owner string) error {
+	if owner == "" {
+		return nil
+	}
+
+	query := fmt.Sprintf("ALTER SEQUENCE %s OWNER TO %s", tableIdentifier(pgSchema, seqName), pq.QuoteIdentifier(owner))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating sequence owner: %w", err)
+	}
+
+	return nil
+}
+
+// normalizeSequenceOwnedBy returns the owned_by value in the form schema.table.column.
+func normalizeSequenceOwnedBy(pgSchema, ownedBy string) string {
+	if ownedBy == "" || strings.Count(ownedBy, ".") != 1 {
+		return ownedBy
+	}
+	return pgSchema + "." + ownedBy
+}
+
+func quoteSequenceOwnedBy(pgSchema, ownedBy string) string {
+	parts := strings.Split(normalizeSequenceOwnedBy(pgSchema, ownedBy), ".")
+	for i, part := range parts {
+		parts[i] = pq.QuoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+func getSequenceSchema(d *schema.ResourceData) string {
+	if v, ok := d.GetOk(seqSchemaAttr); ok {
+		return v.(string)
+	}
+	return "public"
+}
+
+func generateSequenceID(database, pgSchema, seqName string) string {
+	return strings.Join([]string{database, pgSchema, seqName}, ".")
+}
+
+// getDBSequenceName returns database, schema and sequence name. If we are importing this resource,
+// they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBSequenceName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	pgSchema := getSequenceSchema(d)
+	seqName := d.Get(seqNameAttr).(string)
+
+	// When importing, we have to parse the ID to find database, schema and sequence names.
+	if seqName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("sequence ID %s has not the expected format 'database.schema.sequence': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		pgSchema = parsed[1]
+		seqName = parsed[2]
+	}
+	return database, pgSchema, seqName, nil
+}
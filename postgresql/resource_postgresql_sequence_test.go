@@ -0,0 +1,178 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSequenceQuery(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLSequence().Schema, map[string]interface{}{
+		"name":      "seq",
+		"data_type": "integer",
+		"increment": 2,
+		"min_value": 5,
+		"start":     10,
+		"cache":     5,
+		"cycle":     true,
+		"owned_by":  "orders.id",
+	})
+
+	assert.Equal(t,
+		`CREATE SEQUENCE "public"."seq" AS integer INCREMENT BY 2 MINVALUE 5 START WITH 10 CACHE 5 CYCLE OWNED BY "public"."orders"."id"`,
+		createSequenceQuery(d, "public", "seq"),
+	)
+}
+
+func TestAlterSequenceQuery(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLSequence().Schema, map[string]interface{}{
+		"name":      "seq",
+		"max_value": 1000,
+		"owned_by":  "other.orders.id",
+	})
+
+	assert.Equal(t,
+		`ALTER SEQUENCE "public"."seq" INCREMENT BY 1 MAXVALUE 1000 CACHE 1 OWNED BY "other"."orders"."id"`,
+		alterSequenceQuery(d, "public", "seq"),
+	)
+}
+
+func TestNormalizeSequenceOwnedBy(t *testing.T) {
+	assert.Equal(t, "public.orders.id", normalizeSequenceOwnedBy("public", "orders.id"))
+	assert.Equal(t, "other.orders.id", normalizeSequenceOwnedBy("public", "other.orders.id"))
+	assert.Equal(t, "", normalizeSequenceOwnedBy("public", ""))
+}
+
+func TestAccPostgresqlSequence_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+
+	config := `
+resource "postgresql_sequence" "test" {
+  database          = "%s"
+  schema            = "test_schema"
+  name              = "test_seq"
+  owner             = "%s"
+  increment         = %d
+  min_value         = 0
+  start             = %d
+  cache             = 1
+  restart_on_change = true
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlSequenceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, dbName, roleName, 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlSequenceExists("postgresql_sequence.test"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "owner", roleName),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "min_value", "0"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "start", "0"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "current_value", "0"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, dbName, roleName, 5, 100),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlSequenceExists("postgresql_sequence.test"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "increment", "5"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "start", "100"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "current_value", "100"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_sequence.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"restart_on_change", "drop_cascade"},
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlSequenceExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkSequenceExists(txn, rs.Primary.Attributes["schema"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking sequence %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Sequence not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPostgresqlSequenceDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_sequence" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkSequenceExists(txn, rs.Primary.Attributes["schema"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking sequence %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Sequence still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func checkSequenceExists(txn *sql.Tx, pgSchema, seqName string) (bool, error) {
+	var oid uint32
+	err := txn.QueryRow(sequenceOIDQuery, pgSchema, seqName).Scan(&oid)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about sequence: %s", err)
+	}
+
+	return true, nil
+}
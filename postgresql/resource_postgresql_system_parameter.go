@@ -0,0 +1,405 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	systemParameterNameAttr           = "name"
+	systemParameterValueAttr          = "value"
+	systemParameterPendingRestartAttr = "pending_restart"
+)
+
+var (
+	// Units accepted by PostgreSQL for memory parameters, in bytes.
+	parameterMemoryUnits = map[string]float64{
+		"B":  1,
+		"kB": 1 << 10,
+		"MB": 1 << 20,
+		"GB": 1 << 30,
+		"TB": 1 << 40,
+	}
+
+	// Units accepted by PostgreSQL for time parameters, in milliseconds.
+	parameterTimeUnits = map[string]float64{
+		"us":  0.001,
+		"ms":  1,
+		"s":   1000,
+		"min": 60 * 1000,
+		"h":   60 * 60 * 1000,
+		"d":   24 * 60 * 60 * 1000,
+	}
+
+	parameterBoolValues = []string{"on", "off", "true", "false", "yes", "no", "1", "0"}
+
+	parameterQuantityRegexp = regexp.MustCompile(`^\s*([-+]?[0-9]*\.?[0-9]+(?:[eE][-+]?[0-9]+)?)\s*([a-zA-Z]*)\s*$`)
+	parameterUnitRegexp     = regexp.MustCompile(`^([0-9]*)([a-zA-Z]+)$`)
+)
+
+// pgSetting is the definition of a configuration parameter as described by pg_settings.
+type pgSetting struct {
+	Name     string
+	Vartype  string
+	Unit     string
+	MinVal   string
+	MaxVal   string
+	EnumVals []string
+	Context  string
+}
+
+func resourcePostgreSQLSystemParameter() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLSystemParameterCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLSystemParameterRead),
+		Update: PGResourceFunc(resourcePostgreSQLSystemParameterUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLSystemParameterDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: PGResourceDiffFunc(resourcePostgreSQLSystemParameterCustomizeDiff),
+
+		Schema: map[string]*schema.Schema{
+			systemParameterNameAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the configuration parameter",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			systemParameterValueAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The value of the configuration parameter, it is written in postgresql.auto.conf",
+			},
+			systemParameterPendingRestartAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if the value has been changed but the server must be restarted to apply it",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLSystemParameterCustomizeDiff(_ context.Context, db *DBConnection, d *schema.ResourceDiff) error {
+	if !d.HasChange(systemParameterValueAttr) || !d.NewValueKnown(systemParameterValueAttr) {
+		return nil
+	}
+	if !db.featureSupported(featureAlterSystem) {
+		return fmt.Errorf(
+			"postgresql_system_parameter resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	name := d.Get(systemParameterNameAttr).(string)
+	setting, err := getSetting(db, name)
+	switch {
+	case err == sql.ErrNoRows:
+		// Custom parameters (e.g.: myapp.setting) are not known until they are set.
+		if strings.Contains(name, ".") {
+			return nil
+		}
+		return fmt.Errorf("unrecognized configuration parameter %q", name)
+	case err != nil:
+		return err
+	}
+
+	return validateSystemParameterValue(setting, d.Get(systemParameterValueAttr).(string))
+}
+
+func resourcePostgreSQLSystemParameterCreate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureAlterSystem) {
+		return fmt.Errorf(
+			"postgresql_system_parameter resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	if err := setSystemParameter(db, d); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get(systemParameterNameAttr).(string))
+
+	return resourcePostgreSQLSystemParameterReadImpl(db, d)
+}
+
+func resourcePostgreSQLSystemParameterRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureAlterSystem) {
+		return fmt.Errorf(
+			"postgresql_system_parameter resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return resourcePostgreSQLSystemParameterReadImpl(db, d)
+}
+
+func resourcePostgreSQLSystemParameterReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	name := d.Get(systemParameterNameAttr).(string)
+	if name == "" {
+		name = d.Id()
+	}
+
+	// pg_file_settings parses the configuration files when it's queried,
+	// so the value written by ALTER SYSTEM is returned even if it's not applied yet.
+	var value string
+	var applied bool
+	err := db.QueryRow(`
+SELECT setting, applied
+  FROM pg_catalog.pg_file_settings
+ WHERE name = $1 AND sourcefile LIKE '%postgresql.auto.conf'
+ ORDER BY seqno DESC
+ LIMIT 1
+`, name).Scan(&value, &applied)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL system parameter (%s) not found in postgresql.auto.conf", name)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading system parameter: %w", err)
+	}
+
+	pendingRestart := false
+	setting, err := getSetting(db, name)
+	switch {
+	case err == sql.ErrNoRows:
+		setting = pgSetting{Name: name, Vartype: "string"}
+	case err != nil:
+		return err
+	default:
+		if err := db.QueryRow(
+			"SELECT pending_restart FROM pg_catalog.pg_settings WHERE name = $1", name,
+		).Scan(&pendingRestart); err != nil {
+			return fmt.Errorf("Error reading system parameter: %w", err)
+		}
+		// A parameter which can only be set at server start is not applied by a reload.
+		pendingRestart = pendingRestart || (setting.Context == "postmaster" && !applied)
+	}
+
+	// Keep the value as configured if it's equivalent (e.g.: 1GB and 1024MB).
+	if configured := d.Get(systemParameterValueAttr).(string); systemParameterValuesEqual(setting, configured, value) {
+		value = configured
+	}
+
+	d.Set(systemParameterNameAttr, name)
+	d.Set(systemParameterValueAttr, value)
+	d.Set(systemParameterPendingRestartAttr, pendingRestart)
+	d.SetId(name)
+
+	return nil
+}
+
+func resourcePostgreSQLSystemParameterUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureAlterSystem) {
+		return fmt.Errorf(
+			"postgresql_system_parameter resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	if d.HasChange(systemParameterValueAttr) {
+		if err := setSystemParameter(db, d); err != nil {
+			return err
+		}
+	}
+
+	return resourcePostgreSQLSystemParameterReadImpl(db, d)
+}
+
+func resourcePostgreSQLSystemParameterDelete(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureAlterSystem) {
+		return fmt.Errorf(
+			"postgresql_system_parameter resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	name := d.Get(systemParameterNameAttr).(string)
+
+	// ALTER SYSTEM cannot run inside a transaction block.
+	if _, err := db.Exec(fmt.Sprintf("ALTER SYSTEM RESET %s", quoteParameterName(name))); err != nil {
+		return fmt.Errorf("could not reset system parameter %s: %w", name, err)
+	}
+
+	if err := reloadConfiguration(db); err != nil {
+		return err
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setSystemParameter(db *DBConnection, d *schema.ResourceData) error {
+	name := d.Get(systemParameterNameAttr).(string)
+	value := d.Get(systemParameterValueAttr).(string)
+
+	// ALTER SYSTEM cannot run inside a transaction block.
+	query := fmt.Sprintf("ALTER SYSTEM SET %s TO %s", quoteParameterName(name), parameterValueSQL(name, value))
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("could not set system parameter %s: %w", name, err)
+	}
+
+	return reloadConfiguration(db)
+}
+
+func reloadConfiguration(db *DBConnection) error {
+	if _, err := db.Exec("SELECT pg_catalog.pg_reload_conf()"); err != nil {
+		return fmt.Errorf("could not reload configuration: %w", err)
+	}
+	return nil
+}
+
+func getSetting(db QueryAble, name string) (pgSetting, error) {
+	setting := pgSetting{Name: name}
+	err := db.QueryRow(`
+SELECT vartype, COALESCE(unit, ''), COALESCE(min_val, ''), COALESCE(max_val, ''), COALESCE(enumvals, '{}'), context
+  FROM pg_catalog.pg_settings
+ WHERE name = $1
+`, name).Scan(
+		&setting.Vartype, &setting.Unit, &setting.MinVal, &setting.MaxVal, pq.Array(&setting.EnumVals), &setting.Context,
+	)
+	if err != nil && err != sql.ErrNoRows {
+		return setting, fmt.Errorf("Error reading definition of parameter %s: %w", name, err)
+	}
+	return setting, err
+}
+
+// validateSystemParameterValue checks a value against the type, the range
+// and the allowed values of a parameter as PostgreSQL would do.
+func validateSystemParameterValue(setting pgSetting, value string) error {
+	if setting.Context == "internal" {
+		return fmt.Errorf("parameter %q cannot be changed", setting.Name)
+	}
+
+	switch setting.Vartype {
+	case "bool":
+		if !sliceContainsStr(parameterBoolValues, strings.ToLower(strings.TrimSpace(value))) {
+			return fmt.Errorf("parameter %q requires a boolean value, got %q", setting.Name, value)
+		}
+	case "integer", "real":
+		quantity, err := parseParameterQuantity(value, setting.Unit)
+		if err != nil {
+			return fmt.Errorf("invalid value for parameter %q: %w", setting.Name, err)
+		}
+		if setting.MinVal != "" && setting.MaxVal != "" {
+			minVal, minErr := strconv.ParseFloat(setting.MinVal, 64)
+			maxVal, maxErr := strconv.ParseFloat(setting.MaxVal, 64)
+			if minErr == nil && maxErr == nil && (quantity < minVal || quantity > maxVal) {
+				return fmt.Errorf(
+					"value %q is out of range for parameter %q (%s .. %s%s)",
+					value, setting.Name, setting.MinVal, setting.MaxVal, setting.Unit,
+				)
+			}
+		}
+	case "enum":
+		for _, v := range setting.EnumVals {
+			if strings.EqualFold(v, strings.TrimSpace(value)) {
+				return nil
+			}
+		}
+		return fmt.Errorf(
+			"invalid value %q for parameter %q, available values: %s",
+			value, setting.Name, strings.Join(setting.EnumVals, ", "),
+		)
+	}
+
+	return nil
+}
+
+// systemParameterValuesEqual returns true if both values are equivalent for the parameter.
+func systemParameterValuesEqual(setting pgSetting, a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	switch setting.Vartype {
+	case "bool":
+		return sliceContainsStr(parameterBoolValues, strings.ToLower(a)) &&
+			sliceContainsStr(parameterBoolValues, strings.ToLower(b)) &&
+			relOptionBool(a) == relOptionBool(b)
+	case "integer", "real":
+		qa, errA := parseParameterQuantity(a, setting.Unit)
+		qb, errB := parseParameterQuantity(b, setting.Unit)
+		return errA == nil && errB == nil && qa == qb
+	case "enum":
+		return strings.EqualFold(a, b)
+	}
+
+	return normalizeParameterValue(setting.Name, a) == normalizeParameterValue(setting.Name, b)
+}
+
+// parseParameterQuantity parses a numeric parameter value with an optional unit and converts it
+// to the unit of the parameter (e.g.: 1GB for a parameter in 8kB is 131072, 1min for a parameter in ms is 60000).
+func parseParameterQuantity(value, unit string) (float64, error) {
+	matches := parameterQuantityRegexp.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, fmt.Errorf("%q is not a numeric value", value)
+	}
+
+	quantity, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a numeric value: %w", value, err)
+	}
+
+	valueUnit := matches[2]
+	if valueUnit == "" {
+		return quantity, nil
+	}
+
+	units, unitFactor, err := parameterUnitFactor(unit)
+	if err != nil {
+		return 0, err
+	}
+
+	valueFactor, ok := units[valueUnit]
+	if !ok {
+		validUnits := make([]string, 0, len(units))
+		for u := range units {
+			validUnits = append(validUnits, u)
+		}
+		sort.Strings(validUnits)
+		return 0, fmt.Errorf("invalid unit %q in %q, valid units are: %s", valueUnit, value, strings.Join(validUnits, ", "))
+	}
+
+	return quantity * valueFactor / unitFactor, nil
+}
+
+// parameterUnitFactor returns the units compatible with the unit of a parameter
+// and the size of this unit (e.g.: 8kB is 8192 bytes).
+func parameterUnitFactor(unit string) (map[string]float64, float64, error) {
+	matches := parameterUnitRegexp.FindStringSubmatch(unit)
+	if matches == nil {
+		return nil, 0, fmt.Errorf("parameter does not accept units")
+	}
+
+	multiplier := 1.0
+	if matches[1] != "" {
+		m, err := strconv.ParseFloat(matches[1], 64)
+		if err != nil {
+			return nil, 0, err
+		}
+		multiplier = m
+	}
+
+	for _, units := range []map[string]float64{parameterMemoryUnits, parameterTimeUnits} {
+		if factor, ok := units[matches[2]]; ok {
+			return units, multiplier * factor, nil
+		}
+	}
+
+	return nil, 0, fmt.Errorf("unknown parameter unit %q", unit)
+}
@@ -0,0 +1,163 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseParameterQuantity(t *testing.T) {
+	cases := []struct {
+		value    string
+		unit     string
+		expected float64
+		err      bool
+	}{
+		{value: "60000", unit: "ms", expected: 60000},
+		{value: "1min", unit: "ms", expected: 60000},
+		{value: "1.5s", unit: "ms", expected: 1500},
+		{value: "30s", unit: "s", expected: 30},
+		{value: "64MB", unit: "kB", expected: 65536},
+		{value: "1GB", unit: "8kB", expected: 131072},
+		{value: " 128 MB ", unit: "8kB", expected: 16384},
+		{value: "10", unit: "", expected: 10},
+		{value: "0.5", unit: "", expected: 0.5},
+		{value: "1min", unit: "kB", err: true},
+		{value: "64mb", unit: "kB", err: true},
+		{value: "1MB", unit: "", err: true},
+		{value: "abc", unit: "ms", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.value+"/"+c.unit, func(t *testing.T) {
+			quantity, err := parseParameterQuantity(c.value, c.unit)
+			if c.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, quantity)
+		})
+	}
+}
+
+func TestValidateSystemParameterValue(t *testing.T) {
+	workMem := pgSetting{Name: "work_mem", Vartype: "integer", Unit: "kB", MinVal: "64", MaxVal: "2147483647", Context: "user"}
+	walLevel := pgSetting{Name: "wal_level", Vartype: "enum", EnumVals: []string{"minimal", "replica", "logical"}, Context: "postmaster"}
+	fsync := pgSetting{Name: "fsync", Vartype: "bool", Context: "sighup"}
+	blockSize := pgSetting{Name: "block_size", Vartype: "integer", MinVal: "8192", MaxVal: "8192", Context: "internal"}
+	appName := pgSetting{Name: "application_name", Vartype: "string", Context: "user"}
+
+	assert.NoError(t, validateSystemParameterValue(workMem, "64MB"))
+	assert.NoError(t, validateSystemParameterValue(workMem, "1024"))
+	assert.Error(t, validateSystemParameterValue(workMem, "32kB"))
+	assert.Error(t, validateSystemParameterValue(workMem, "1s"))
+	assert.Error(t, validateSystemParameterValue(workMem, "lots"))
+
+	assert.NoError(t, validateSystemParameterValue(walLevel, "logical"))
+	assert.NoError(t, validateSystemParameterValue(walLevel, "Replica"))
+	assert.Error(t, validateSystemParameterValue(walLevel, "archive"))
+
+	assert.NoError(t, validateSystemParameterValue(fsync, "on"))
+	assert.NoError(t, validateSystemParameterValue(fsync, "FALSE"))
+	assert.Error(t, validateSystemParameterValue(fsync, "maybe"))
+
+	assert.Error(t, validateSystemParameterValue(blockSize, "8192"))
+
+	assert.NoError(t, validateSystemParameterValue(appName, "anything"))
+}
+
+func TestSystemParameterValuesEqual(t *testing.T) {
+	sharedBuffers := pgSetting{Name: "shared_buffers", Vartype: "integer", Unit: "8kB"}
+	assert.True(t, systemParameterValuesEqual(sharedBuffers, "1GB", "1024MB"))
+	assert.True(t, systemParameterValuesEqual(sharedBuffers, "131072", "1GB"))
+	assert.False(t, systemParameterValuesEqual(sharedBuffers, "1GB", "2GB"))
+
+	fsync := pgSetting{Name: "fsync", Vartype: "bool"}
+	assert.True(t, systemParameterValuesEqual(fsync, "on", "true"))
+	assert.False(t, systemParameterValuesEqual(fsync, "on", "off"))
+
+	searchPath := pgSetting{Name: "search_path", Vartype: "string"}
+	assert.True(t, systemParameterValuesEqual(searchPath, "$user, public", `"$user", public`))
+}
+
+func TestAccPostgresqlSystemParameter_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+	testSuperuserPreCheck(t)
+
+	config := `
+resource "postgresql_system_parameter" "test" {
+  name  = "%s"
+  value = "%s"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureAlterSystem)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlSystemParameterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(config, "log_min_duration_statement", "-5"),
+				ExpectError: regexp.MustCompile("out of range"),
+			},
+			{
+				Config:      fmt.Sprintf(config, "log_min_messages", "verbose"),
+				ExpectError: regexp.MustCompile("available values"),
+			},
+			{
+				Config: fmt.Sprintf(config, "log_min_duration_statement", "1min"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_system_parameter.test", "value", "1min"),
+					resource.TestCheckResourceAttr("postgresql_system_parameter.test", "pending_restart", "false"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, "log_min_duration_statement", "2s"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_system_parameter.test", "value", "2s"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_system_parameter.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlSystemParameterDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+	db, err := client.Connect()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_system_parameter" {
+			continue
+		}
+
+		var count int
+		if err := db.QueryRow(
+			"SELECT count(*) FROM pg_catalog.pg_file_settings WHERE name = $1 AND sourcefile LIKE '%postgresql.auto.conf'",
+			rs.Primary.Attributes["name"],
+		).Scan(&count); err != nil {
+			return fmt.Errorf("Error checking system parameter %s", err)
+		}
+
+		if count > 0 {
+			return fmt.Errorf("System parameter still set after destroy")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,1224 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	tableNameAttr        = "name"
+	tableSchemaAttr      = "schema"
+	tableDatabaseAttr    = "database"
+	tableOwnerAttr       = "owner"
+	tableCommentAttr     = "comment"
+	tableColumnAttr      = "column"
+	tablePrimaryKeyAttr  = "primary_key"
+	tableUniqueAttr      = "unique"
+	tableCheckAttr       = "check"
+	tableForeignKeyAttr  = "foreign_key"
+	tableDropCascadeAttr = "drop_cascade"
+
+	tableColumnNameAttr     = "name"
+	tableColumnTypeAttr     = "type"
+	tableColumnNullableAttr = "nullable"
+	tableColumnDefaultAttr  = "default"
+	tableColumnIdentityAttr = "identity"
+	tableColumnPreviousAttr = "previous_name"
+
+	tableConstraintNameAttr       = "name"
+	tableConstraintColumnsAttr    = "columns"
+	tableConstraintExpressionAttr = "expression"
+	tableFKReferencesSchemaAttr   = "references_schema"
+	tableFKReferencesTableAttr    = "references_table"
+	tableFKReferencesColumnsAttr  = "references_columns"
+	tableFKOnDeleteAttr           = "on_delete"
+	tableFKOnUpdateAttr           = "on_update"
+)
+
+var (
+	tableIdentityModes = []string{"", "ALWAYS", "BY DEFAULT"}
+	tableFKActions     = []string{"NO ACTION", "RESTRICT", "CASCADE", "SET NULL", "SET DEFAULT"}
+
+	// Mapping of pg_constraint.confupdtype/confdeltype to their SQL keyword.
+	tableFKActionCodes = map[string]string{
+		"a": "NO ACTION",
+		"r": "RESTRICT",
+		"c": "CASCADE",
+		"n": "SET NULL",
+		"d": "SET DEFAULT",
+	}
+
+	// Aliases accepted by PostgreSQL which are returned under their canonical
+	// name by format_type().
+	tableTypeAliases = map[string]string{
+		"int":         "integer",
+		"int4":        "integer",
+		"int8":        "bigint",
+		"int2":        "smallint",
+		"bool":        "boolean",
+		"float4":      "real",
+		"float8":      "double precision",
+		"float":       "double precision",
+		"decimal":     "numeric",
+		"varchar":     "character varying",
+		"char":        "character",
+		"varbit":      "bit varying",
+		"timestamp":   "timestamp without time zone",
+		"timestamptz": "timestamp with time zone",
+		"time":        "time without time zone",
+		"timetz":      "time with time zone",
+	}
+
+	tableTypeRegexp = regexp.MustCompile(`^(?P<Base>[a-z0-9_ ]+?)\s*(?P<Modifier>\([^)]*\))?\s*(?P<Array>(\[\])*)$`)
+)
+
+// pgTableColumn is the model of a column in a postgresql_table resource.
+type pgTableColumn struct {
+	Name     string
+	Type     string
+	Nullable bool
+	Default  string
+	Identity string
+
+	// PreviousName is only set in the configuration to rename the column.
+	PreviousName string
+}
+
+// pgTableConstraint is the model of a table constraint (primary key, unique,
+// check or foreign key) in a postgresql_table resource.
+type pgTableConstraint struct {
+	Name       string
+	Type       string
+	Columns    []string
+	Expression string
+	RefSchema  string
+	RefTable   string
+	RefColumns []string
+	OnDelete   string
+	OnUpdate   string
+}
+
+func resourcePostgreSQLTable() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLTableCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLTableRead),
+		Update: PGResourceFunc(resourcePostgreSQLTableUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLTableDelete),
+		Exists: PGResourceExistsFunc(resourcePostgreSQLTableExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			tableNameAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the table",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			tableSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The schema where the table is located. If not specified, the public schema is used",
+			},
+			tableDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the table is located. If not specified, the provider default database is used",
+			},
+			tableOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ROLE which owns the table",
+			},
+			tableCommentAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The comment of the table",
+			},
+			tableColumnAttr: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The columns of the table",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tableColumnNameAttr: {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The name of the column",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						tableColumnTypeAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The data type of the column",
+
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return normalizeColumnType(old) == normalizeColumnType(new)
+							},
+						},
+						tableColumnNullableAttr: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "If false, the column is declared NOT NULL",
+
+							DiffSuppressFunc: suppressImplicitNotNullDiff,
+						},
+						tableColumnDefaultAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The default expression of the column",
+
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return normalizeTableExpression(old) == normalizeTableExpression(new)
+							},
+						},
+						tableColumnIdentityAttr: {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Creates the column as an identity column. One of: ALWAYS, BY DEFAULT",
+							ValidateFunc: validation.StringInSlice(tableIdentityModes, false),
+						},
+						tableColumnPreviousAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The former name of the column. If set, the column is renamed instead of being dropped and added again",
+						},
+					},
+				},
+			},
+			tablePrimaryKeyAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The primary key of the table",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tableConstraintNameAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The name of the primary key constraint",
+						},
+						tableConstraintColumnsAttr: {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The columns of the primary key",
+						},
+					},
+				},
+			},
+			tableUniqueAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The unique constraints of the table",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tableConstraintNameAttr: {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The name of the unique constraint",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						tableConstraintColumnsAttr: {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The columns of the unique constraint",
+						},
+					},
+				},
+			},
+			tableCheckAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The check constraints of the table",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tableConstraintNameAttr: {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The name of the check constraint",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						tableConstraintExpressionAttr: {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The boolean expression of the check constraint",
+							ValidateFunc: validation.StringIsNotEmpty,
+
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return normalizeTableExpression(old) == normalizeTableExpression(new)
+							},
+						},
+					},
+				},
+			},
+			tableForeignKeyAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The foreign key constraints of the table",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tableConstraintNameAttr: {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The name of the foreign key constraint",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						tableConstraintColumnsAttr: {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The referencing columns",
+						},
+						tableFKReferencesSchemaAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The schema of the referenced table. If not specified, the schema of the table is used",
+						},
+						tableFKReferencesTableAttr: {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The referenced table",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						tableFKReferencesColumnsAttr: {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The referenced columns",
+						},
+						tableFKOnDeleteAttr: {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "NO ACTION",
+							Description:  "The action to perform when a referenced row is deleted. One of: " + strings.Join(tableFKActions, ", "),
+							ValidateFunc: validation.StringInSlice(tableFKActions, false),
+						},
+						tableFKOnUpdateAttr: {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "NO ACTION",
+							Description:  "The action to perform when a referenced column is updated. One of: " + strings.Join(tableFKActions, ", "),
+							ValidateFunc: validation.StringInSlice(tableFKActions, false),
+						},
+					},
+				},
+			},
+			tableDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the table (such as views or foreign keys of other tables)",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLTableCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getTableSchema(d)
+	tableName := d.Get(tableNameAttr).(string)
+
+	columns := tableColumnsFromResourceData(d.Get(tableColumnAttr).([]interface{}))
+	if err := validateTableColumns(db, columns); err != nil {
+		return err
+	}
+	constraints := tableConstraintsFromResourceData(d, pgSchema)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var rolesToGrant []string
+	if owner, ok := d.GetOk(tableOwnerAttr); ok {
+		rolesToGrant = append(rolesToGrant, owner.(string))
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		query := createTableQuery(pgSchema, tableName, columns, constraints)
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not create table %s: %w", tableName, err)
+		}
+
+		if owner, ok := d.GetOk(tableOwnerAttr); ok {
+			if _, err := txn.Exec(fmt.Sprintf(
+				"ALTER TABLE %s OWNER TO %s", tableIdentifier(pgSchema, tableName), pq.QuoteIdentifier(owner.(string)),
+			)); err != nil {
+				return fmt.Errorf("could not set owner of table %s: %w", tableName, err)
+			}
+		}
+
+		return setTableComment(txn, pgSchema, tableName, d.Get(tableCommentAttr).(string))
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateTableID(database, pgSchema, tableName))
+
+	return resourcePostgreSQLTableReadImpl(db, d)
+}
+
+func resourcePostgreSQLTableExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, pgSchema, tableName, err := getDBTableName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var oid uint32
+	err = txn.QueryRow(tableOIDQuery, pgSchema, tableName).Scan(&oid)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading table: %w", err)
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLTableRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLTableReadImpl(db, d)
+}
+
+const tableOIDQuery = `
+SELECT c.oid
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind IN ('r', 'p')
+`
+
+func resourcePostgreSQLTableReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, pgSchema, tableName, err := getDBTableName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var tableOID uint32
+	var tableOwner, tableComment string
+	err = txn.QueryRow(`
+SELECT c.oid, pg_catalog.pg_get_userbyid(c.relowner), COALESCE(pg_catalog.obj_description(c.oid, 'pg_class'), '')
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind IN ('r', 'p')
+`, pgSchema, tableName).Scan(&tableOID, &tableOwner, &tableComment)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL table (%s) not found in database %s", tableName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading table: %w", err)
+	}
+
+	columns, err := readTableColumns(db, txn, tableOID)
+	if err != nil {
+		return err
+	}
+
+	constraints, err := readTableConstraints(txn, tableOID)
+	if err != nil {
+		return err
+	}
+
+	var primaryKey, uniques, checks, foreignKeys []interface{}
+	for _, c := range constraints {
+		switch c.Type {
+		case "p":
+			primaryKey = append(primaryKey, map[string]interface{}{
+				tableConstraintNameAttr:    c.Name,
+				tableConstraintColumnsAttr: c.Columns,
+			})
+		case "u":
+			uniques = append(uniques, map[string]interface{}{
+				tableConstraintNameAttr:    c.Name,
+				tableConstraintColumnsAttr: c.Columns,
+			})
+		case "c":
+			checks = append(checks, map[string]interface{}{
+				tableConstraintNameAttr:       c.Name,
+				tableConstraintExpressionAttr: c.Expression,
+			})
+		case "f":
+			foreignKeys = append(foreignKeys, map[string]interface{}{
+				tableConstraintNameAttr:      c.Name,
+				tableConstraintColumnsAttr:   c.Columns,
+				tableFKReferencesSchemaAttr:  c.RefSchema,
+				tableFKReferencesTableAttr:   c.RefTable,
+				tableFKReferencesColumnsAttr: c.RefColumns,
+				tableFKOnDeleteAttr:          c.OnDelete,
+				tableFKOnUpdateAttr:          c.OnUpdate,
+			})
+		}
+	}
+
+	// The former names of the columns only exist in the configuration.
+	previousNames := make(map[string]string)
+	for _, c := range tableColumnsFromResourceData(d.Get(tableColumnAttr).([]interface{})) {
+		previousNames[c.Name] = c.PreviousName
+	}
+
+	var columnsList []interface{}
+	for _, c := range columns {
+		columnsList = append(columnsList, map[string]interface{}{
+			tableColumnNameAttr:     c.Name,
+			tableColumnTypeAttr:     c.Type,
+			tableColumnNullableAttr: c.Nullable,
+			tableColumnDefaultAttr:  c.Default,
+			tableColumnIdentityAttr: c.Identity,
+			tableColumnPreviousAttr: previousNames[c.Name],
+		})
+	}
+
+	d.Set(tableNameAttr, tableName)
+	d.Set(tableSchemaAttr, pgSchema)
+	d.Set(tableDatabaseAttr, database)
+	d.Set(tableOwnerAttr, tableOwner)
+	d.Set(tableCommentAttr, tableComment)
+	// Columns and constraints are returned in the order of the configuration
+	// so adding a column or a constraint in the middle of a list does not
+	// generate a perpetual diff.
+	d.Set(tableColumnAttr, sortByName(columnsList, d.Get(tableColumnAttr).([]interface{})))
+	d.Set(tablePrimaryKeyAttr, primaryKey)
+	d.Set(tableUniqueAttr, sortByName(uniques, d.Get(tableUniqueAttr).([]interface{})))
+	d.Set(tableCheckAttr, sortByName(checks, d.Get(tableCheckAttr).([]interface{})))
+	d.Set(tableForeignKeyAttr, sortByName(foreignKeys, d.Get(tableForeignKeyAttr).([]interface{})))
+	d.SetId(generateTableID(database, pgSchema, tableName))
+
+	return nil
+}
+
+func readTableColumns(db *DBConnection, txn *sql.Tx, tableOID uint32) ([]pgTableColumn, error) {
+	identityColumn := "''"
+	if db.featureSupported(featureIdentityColumn) {
+		identityColumn = "a.attidentity::TEXT"
+	}
+
+	rows, err := txn.Query(fmt.Sprintf(`
+SELECT a.attname, pg_catalog.format_type(a.atttypid, a.atttypmod), a.attnotnull,
+       COALESCE(pg_catalog.pg_get_expr(ad.adbin, ad.adrelid), ''), %s
+  FROM pg_catalog.pg_attribute a
+  LEFT JOIN pg_catalog.pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum
+ WHERE a.attrelid = $1 AND a.attnum > 0 AND NOT a.attisdropped
+ ORDER BY a.attnum
+`, identityColumn), tableOID)
+	if err != nil {
+		return nil, fmt.Errorf("could not read columns of table: %w", err)
+	}
+	defer rows.Close()
+
+	columns := []pgTableColumn{}
+	for rows.Next() {
+		var column pgTableColumn
+		var notNull bool
+		var identity string
+		if err := rows.Scan(&column.Name, &column.Type, &notNull, &column.Default, &identity); err != nil {
+			return nil, fmt.Errorf("could not scan column of table: %w", err)
+		}
+		column.Nullable = !notNull
+		switch identity {
+		case "a":
+			column.Identity = "ALWAYS"
+		case "d":
+			column.Identity = "BY DEFAULT"
+		}
+		columns = append(columns, column)
+	}
+
+	return columns, rows.Err()
+}
+
+func readTableConstraints(txn *sql.Tx, tableOID uint32) ([]pgTableConstraint, error) {
+	rows, err := txn.Query(`
+SELECT con.conname, con.contype,
+       ARRAY(
+           SELECT a.attname FROM unnest(con.conkey) WITH ORDINALITY AS k(attnum, ord)
+             JOIN pg_catalog.pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+            ORDER BY k.ord
+       )::TEXT[],
+       COALESCE(fn.nspname, ''), COALESCE(fc.relname, ''),
+       ARRAY(
+           SELECT a.attname FROM unnest(con.confkey) WITH ORDINALITY AS k(attnum, ord)
+             JOIN pg_catalog.pg_attribute a ON a.attrelid = con.confrelid AND a.attnum = k.attnum
+            ORDER BY k.ord
+       )::TEXT[],
+       con.confdeltype::TEXT, con.confupdtype::TEXT,
+       pg_catalog.pg_get_constraintdef(con.oid)
+  FROM pg_catalog.pg_constraint con
+  LEFT JOIN pg_catalog.pg_class fc ON fc.oid = con.confrelid
+  LEFT JOIN pg_catalog.pg_namespace fn ON fn.oid = fc.relnamespace
+ WHERE con.conrelid = $1 AND con.contype IN ('p', 'u', 'c', 'f')
+ ORDER BY con.conname
+`, tableOID)
+	if err != nil {
+		return nil, fmt.Errorf("could not read constraints of table: %w", err)
+	}
+	defer rows.Close()
+
+	constraints := []pgTableConstraint{}
+	for rows.Next() {
+		var c pgTableConstraint
+		var onDelete, onUpdate, definition string
+		if err := rows.Scan(
+			&c.Name, &c.Type, pq.Array(&c.Columns), &c.RefSchema, &c.RefTable, pq.Array(&c.RefColumns),
+			&onDelete, &onUpdate, &definition,
+		); err != nil {
+			return nil, fmt.Errorf("could not scan constraint of table: %w", err)
+		}
+		if c.Type == "f" {
+			c.OnDelete = tableFKActionCodes[onDelete]
+			c.OnUpdate = tableFKActionCodes[onUpdate]
+		}
+		if c.Type == "c" {
+			c.Expression = parseCheckConstraintDef(definition)
+		}
+		constraints = append(constraints, c)
+	}
+
+	return constraints, rows.Err()
+}
+
+func resourcePostgreSQLTableUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getTableSchema(d)
+
+	columns := tableColumnsFromResourceData(d.Get(tableColumnAttr).([]interface{}))
+	if err := validateTableColumns(db, columns); err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	owner := d.Get(tableOwnerAttr).(string)
+	if d.HasChange(tableOwnerAttr) {
+		oldOwner, _ := d.GetChange(tableOwnerAttr)
+		owner = oldOwner.(string)
+	}
+	var rolesToGrant []string
+	if owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		if err := setTableName(txn, d, pgSchema); err != nil {
+			return err
+		}
+
+		tableName := d.Get(tableNameAttr).(string)
+
+		oldConstraintsRaw, newConstraintsRaw := getTableConstraintsChange(d, pgSchema)
+		droppedConstraints, addedConstraints := diffTableConstraints(oldConstraintsRaw, newConstraintsRaw)
+
+		for _, c := range droppedConstraints {
+			query := fmt.Sprintf(
+				"ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s",
+				tableIdentifier(pgSchema, tableName), pq.QuoteIdentifier(c.Name),
+			)
+			if _, err := txn.Exec(query); err != nil {
+				return fmt.Errorf("could not drop constraint %s on table %s: %w", c.Name, tableName, err)
+			}
+		}
+
+		if d.HasChange(tableColumnAttr) {
+			oldColumns, newColumns := d.GetChange(tableColumnAttr)
+			queries := alterTableColumnsQueries(
+				pgSchema, tableName,
+				tableColumnsFromResourceData(oldColumns.([]interface{})),
+				tableColumnsFromResourceData(newColumns.([]interface{})),
+			)
+			for _, query := range queries {
+				if _, err := txn.Exec(query); err != nil {
+					return fmt.Errorf("could not alter columns of table %s: %w", tableName, err)
+				}
+			}
+		}
+
+		for _, c := range addedConstraints {
+			query := fmt.Sprintf(
+				"ALTER TABLE %s ADD %s", tableIdentifier(pgSchema, tableName), tableConstraintDefinition(c),
+			)
+			if _, err := txn.Exec(query); err != nil {
+				return fmt.Errorf("could not add constraint %s on table %s: %w", c.Name, tableName, err)
+			}
+		}
+
+		if d.HasChange(tableCommentAttr) {
+			if err := setTableComment(txn, pgSchema, tableName, d.Get(tableCommentAttr).(string)); err != nil {
+				return err
+			}
+		}
+
+		return setTableOwner(txn, d, pgSchema)
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourcePostgreSQLTableReadImpl(db, d)
+}
+
+func resourcePostgreSQLTableDelete(db *DBConnection, d *schema.ResourceData) error {
+	database, pgSchema, tableName, err := getDBTableName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var rolesToGrant []string
+	if owner := d.Get(tableOwnerAttr).(string); owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		dropMode := "RESTRICT"
+		if d.Get(tableDropCascadeAttr).(bool) {
+			dropMode = "CASCADE"
+		}
+
+		query := fmt.Sprintf("DROP TABLE IF EXISTS %s %s", tableIdentifier(pgSchema, tableName), dropMode)
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not drop table %s: %w", tableName, err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setTableName(txn *sql.Tx, d *schema.ResourceData, pgSchema string) error {
+	if !d.HasChange(tableNameAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(tableNameAttr)
+	o := oraw.(string)
+	n := nraw.(string)
+	if n == "" {
+		return errors.New("Error setting table name to an empty string")
+	}
+
+	query := fmt.Sprintf("ALTER TABLE %s RENAME TO %s", tableIdentifier(pgSchema, o), pq.QuoteIdentifier(n))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating table name: %w", err)
+	}
+
+	d.SetId(generateTableID(getDatabase(d, ""), pgSchema, n))
+
+	return nil
+}
+
+func setTableOwner(txn *sql.Tx, d *schema.ResourceData, pgSchema string) error {
+	if !d.HasChange(tableOwnerAttr) {
+		return nil
+	}
+
+	owner := d.Get(tableOwnerAttr).(string)
+	if owner == "" {
+		return nil
+	}
+
+	query := fmt.Sprintf(
+		"ALTER TABLE %s OWNER TO %s",
+		tableIdentifier(pgSchema, d.Get(tableNameAttr).(string)), pq.QuoteIdentifier(owner),
+	)
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating table owner: %w", err)
+	}
+
+	return nil
+}
+
+func setTableComment(txn *sql.Tx, pgSchema, tableName, comment string) error {
+	value := "NULL"
+	if comment != "" {
+		value = pq.QuoteLiteral(comment)
+	}
+
+	query := fmt.Sprintf("COMMENT ON TABLE %s IS %s", tableIdentifier(pgSchema, tableName), value)
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("could not set comment on table %s: %w", tableName, err)
+	}
+
+	return nil
+}
+
+func validateTableColumns(db *DBConnection, columns []pgTableColumn) error {
+	for _, c := range columns {
+		if c.Identity != "" && !db.featureSupported(featureIdentityColumn) {
+			return fmt.Errorf(
+				"identity columns are not supported for this Postgres version (%s)",
+				db.version,
+			)
+		}
+		if c.Identity != "" && c.Default != "" {
+			return fmt.Errorf("column %s cannot have both `identity` and `default`", c.Name)
+		}
+	}
+	return nil
+}
+
+func createTableQuery(pgSchema, tableName string, columns []pgTableColumn, constraints []pgTableConstraint) string {
+	b := bytes.NewBufferString("CREATE TABLE ")
+	fmt.Fprint(b, tableIdentifier(pgSchema, tableName), " (")
+
+	definitions := []string{}
+	for _, c := range columns {
+		definitions = append(definitions, tableColumnDefinition(c))
+	}
+	for _, c := range constraints {
+		definitions = append(definitions, tableConstraintDefinition(c))
+	}
+
+	for i, definition := range definitions {
+		if i > 0 {
+			b.WriteRune(',')
+		}
+		fmt.Fprint(b, "\n    ", definition)
+	}
+	b.WriteString("\n)")
+
+	return b.String()
+}
+
+func tableColumnDefinition(c pgTableColumn) string {
+	b := bytes.NewBufferString(pq.QuoteIdentifier(c.Name))
+	fmt.Fprint(b, " ", c.Type)
+
+	if !c.Nullable {
+		b.WriteString(" NOT NULL")
+	}
+	if c.Default != "" {
+		fmt.Fprint(b, " DEFAULT ", c.Default)
+	}
+	if c.Identity != "" {
+		fmt.Fprintf(b, " GENERATED %s AS IDENTITY", c.Identity)
+	}
+
+	return b.String()
+}
+
+func tableConstraintDefinition(c pgTableConstraint) string {
+	b := bytes.NewBufferString("")
+	if c.Name != "" {
+		fmt.Fprint(b, "CONSTRAINT ", pq.QuoteIdentifier(c.Name), " ")
+	}
+
+	switch c.Type {
+	case "p":
+		fmt.Fprintf(b, "PRIMARY KEY (%s)", quoteIdentifierList(c.Columns))
+	case "u":
+		fmt.Fprintf(b, "UNIQUE (%s)", quoteIdentifierList(c.Columns))
+	case "c":
+		fmt.Fprintf(b, "CHECK (%s)", c.Expression)
+	case "f":
+		fmt.Fprintf(
+			b, "FOREIGN KEY (%s) REFERENCES %s (%s) ON DELETE %s ON UPDATE %s",
+			quoteIdentifierList(c.Columns),
+			tableIdentifier(c.RefSchema, c.RefTable),
+			quoteIdentifierList(c.RefColumns),
+			c.OnDelete,
+			c.OnUpdate,
+		)
+	}
+
+	return b.String()
+}
+
+// alterTableColumnsQueries returns the ALTER TABLE statements needed to go from the old
+// columns definition to the new one. Columns are matched by name, or by their previous name
+// if they are renamed, so the data of a renamed column is kept.
+func alterTableColumnsQueries(pgSchema, tableName string, oldColumns, newColumns []pgTableColumn) []string {
+	table := tableIdentifier(pgSchema, tableName)
+	queries := []string{}
+
+	oldByName := make(map[string]pgTableColumn, len(oldColumns))
+	for _, c := range oldColumns {
+		oldByName[c.Name] = c
+	}
+	newByName := make(map[string]pgTableColumn, len(newColumns))
+	for _, c := range newColumns {
+		newByName[c.Name] = c
+	}
+
+	renamed := make(map[string]string)
+	for _, n := range newColumns {
+		if _, ok := oldByName[n.Name]; ok || n.PreviousName == "" {
+			continue
+		}
+		if _, ok := oldByName[n.PreviousName]; !ok {
+			continue
+		}
+		if _, ok := newByName[n.PreviousName]; ok {
+			continue
+		}
+		renamed[n.PreviousName] = n.Name
+	}
+
+	for _, c := range oldColumns {
+		if _, ok := newByName[c.Name]; !ok && renamed[c.Name] == "" {
+			queries = append(queries, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", table, pq.QuoteIdentifier(c.Name)))
+		}
+	}
+
+	for _, c := range oldColumns {
+		if newName := renamed[c.Name]; newName != "" {
+			queries = append(queries, fmt.Sprintf(
+				"ALTER TABLE %s RENAME COLUMN %s TO %s", table, pq.QuoteIdentifier(c.Name), pq.QuoteIdentifier(newName),
+			))
+		}
+	}
+
+	for _, n := range newColumns {
+		o, ok := oldByName[n.Name]
+		if !ok && renamed[n.PreviousName] == n.Name {
+			o, ok = oldByName[n.PreviousName]
+		}
+		if !ok {
+			queries = append(queries, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, tableColumnDefinition(n)))
+			continue
+		}
+
+		column := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", table, pq.QuoteIdentifier(n.Name))
+
+		if o.Identity != "" && n.Identity == "" {
+			queries = append(queries, column+" DROP IDENTITY IF EXISTS")
+		}
+		if o.Default != n.Default && normalizeTableExpression(o.Default) != normalizeTableExpression(n.Default) && n.Default == "" {
+			queries = append(queries, column+" DROP DEFAULT")
+		}
+		if normalizeColumnType(o.Type) != normalizeColumnType(n.Type) {
+			queries = append(queries, fmt.Sprintf("%s TYPE %s", column, n.Type))
+		}
+		if normalizeTableExpression(o.Default) != normalizeTableExpression(n.Default) && n.Default != "" {
+			queries = append(queries, fmt.Sprintf("%s SET DEFAULT %s", column, n.Default))
+		}
+		if o.Nullable != n.Nullable {
+			if n.Nullable {
+				queries = append(queries, column+" DROP NOT NULL")
+			} else {
+				queries = append(queries, column+" SET NOT NULL")
+			}
+		}
+		if n.Identity != "" && o.Identity == "" {
+			queries = append(queries, fmt.Sprintf("%s ADD GENERATED %s AS IDENTITY", column, n.Identity))
+		} else if n.Identity != "" && o.Identity != n.Identity {
+			queries = append(queries, fmt.Sprintf("%s SET GENERATED %s", column, n.Identity))
+		}
+	}
+
+	return queries
+}
+
+// diffTableConstraints returns the constraints to drop and the constraints to add
+// to go from old constraints to new ones. A modified constraint is dropped then added again.
+func diffTableConstraints(oldConstraints, newConstraints []pgTableConstraint) (dropped, added []pgTableConstraint) {
+	oldByKey := make(map[string]pgTableConstraint, len(oldConstraints))
+	for _, c := range oldConstraints {
+		oldByKey[tableConstraintKey(c)] = c
+	}
+	newByKey := make(map[string]pgTableConstraint, len(newConstraints))
+	for _, c := range newConstraints {
+		newByKey[tableConstraintKey(c)] = c
+	}
+
+	for _, o := range oldConstraints {
+		n, ok := newByKey[tableConstraintKey(o)]
+		if !ok || !tableConstraintEqual(o, n) {
+			dropped = append(dropped, o)
+		}
+	}
+	for _, n := range newConstraints {
+		o, ok := oldByKey[tableConstraintKey(n)]
+		if !ok || !tableConstraintEqual(o, n) {
+			added = append(added, n)
+		}
+	}
+
+	// Foreign keys are dropped first and added last as they can depend
+	// on primary keys or unique constraints.
+	sortTableConstraints(dropped, "fcup")
+	sortTableConstraints(added, "pucf")
+
+	return dropped, added
+}
+
+// tableConstraintKey identifies a constraint by its type and name.
+// A table has at most one primary key, whose name may be generated by Postgres.
+func tableConstraintKey(c pgTableConstraint) string {
+	if c.Type == "p" {
+		return c.Type
+	}
+	return c.Type + c.Name
+}
+
+func tableConstraintEqual(a, b pgTableConstraint) bool {
+	a.Expression = normalizeTableExpression(a.Expression)
+	b.Expression = normalizeTableExpression(b.Expression)
+	// An unnamed primary key gets its name from Postgres.
+	if a.Type == "p" && (a.Name == "" || b.Name == "") {
+		a.Name, b.Name = "", ""
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func sortTableConstraints(constraints []pgTableConstraint, order string) {
+	sorted := make([]pgTableConstraint, 0, len(constraints))
+	for _, t := range order {
+		for _, c := range constraints {
+			if c.Type == string(t) {
+				sorted = append(sorted, c)
+			}
+		}
+	}
+	copy(constraints, sorted)
+}
+
+func getTableConstraintsChange(d *schema.ResourceData, pgSchema string) ([]pgTableConstraint, []pgTableConstraint) {
+	var oldConstraints, newConstraints []pgTableConstraint
+
+	for _, attr := range []string{tablePrimaryKeyAttr, tableUniqueAttr, tableCheckAttr, tableForeignKeyAttr} {
+		o, n := d.GetChange(attr)
+		oldConstraints = append(oldConstraints, tableConstraintsFromList(attr, o.([]interface{}), pgSchema)...)
+		newConstraints = append(newConstraints, tableConstraintsFromList(attr, n.([]interface{}), pgSchema)...)
+	}
+
+	return oldConstraints, newConstraints
+}
+
+func tableConstraintsFromResourceData(d *schema.ResourceData, pgSchema string) []pgTableConstraint {
+	var constraints []pgTableConstraint
+	for _, attr := range []string{tablePrimaryKeyAttr, tableUniqueAttr, tableCheckAttr, tableForeignKeyAttr} {
+		constraints = append(constraints, tableConstraintsFromList(attr, d.Get(attr).([]interface{}), pgSchema)...)
+	}
+	return constraints
+}
+
+func tableConstraintsFromList(attr string, list []interface{}, pgSchema string) []pgTableConstraint {
+	constraints := []pgTableConstraint{}
+
+	for _, raw := range list {
+		if raw == nil {
+			continue
+		}
+		m := raw.(map[string]interface{})
+		c := pgTableConstraint{
+			Name: m[tableConstraintNameAttr].(string),
+		}
+
+		switch attr {
+		case tablePrimaryKeyAttr:
+			c.Type = "p"
+			c.Columns = interfaceSliceToStrings(m[tableConstraintColumnsAttr].([]interface{}))
+		case tableUniqueAttr:
+			c.Type = "u"
+			c.Columns = interfaceSliceToStrings(m[tableConstraintColumnsAttr].([]interface{}))
+		case tableCheckAttr:
+			c.Type = "c"
+			c.Expression = m[tableConstraintExpressionAttr].(string)
+		case tableForeignKeyAttr:
+			c.Type = "f"
+			c.Columns = interfaceSliceToStrings(m[tableConstraintColumnsAttr].([]interface{}))
+			c.RefSchema = m[tableFKReferencesSchemaAttr].(string)
+			if c.RefSchema == "" {
+				c.RefSchema = pgSchema
+			}
+			c.RefTable = m[tableFKReferencesTableAttr].(string)
+			c.RefColumns = interfaceSliceToStrings(m[tableFKReferencesColumnsAttr].([]interface{}))
+			c.OnDelete = m[tableFKOnDeleteAttr].(string)
+			c.OnUpdate = m[tableFKOnUpdateAttr].(string)
+		}
+
+		constraints = append(constraints, c)
+	}
+
+	return constraints
+}
+
+func tableColumnsFromResourceData(list []interface{}) []pgTableColumn {
+	columns := []pgTableColumn{}
+
+	for _, raw := range list {
+		m := raw.(map[string]interface{})
+		columns = append(columns, pgTableColumn{
+			Name:         m[tableColumnNameAttr].(string),
+			Type:         m[tableColumnTypeAttr].(string),
+			Nullable:     m[tableColumnNullableAttr].(bool),
+			Default:      m[tableColumnDefaultAttr].(string),
+			Identity:     m[tableColumnIdentityAttr].(string),
+			PreviousName: m[tableColumnPreviousAttr].(string),
+		})
+	}
+
+	return columns
+}
+
+// suppressImplicitNotNullDiff ignores the nullable attribute of columns which are NOT NULL
+// because they are identity columns or part of the primary key.
+func suppressImplicitNotNullDiff(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+
+	prefix := strings.TrimSuffix(k, tableColumnNullableAttr)
+	if d.Get(prefix+tableColumnIdentityAttr).(string) != "" {
+		return true
+	}
+
+	columnName := d.Get(prefix + tableColumnNameAttr).(string)
+	for _, pk := range d.Get(tablePrimaryKeyAttr).([]interface{}) {
+		if pk == nil {
+			continue
+		}
+		for _, column := range pk.(map[string]interface{})[tableConstraintColumnsAttr].([]interface{}) {
+			if column.(string) == columnName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// normalizeColumnType returns the canonical name of a type as returned by format_type(),
+// e.g.: varchar(10) => character varying(10)
+func normalizeColumnType(columnType string) string {
+	columnType = strings.Join(strings.Fields(strings.ToLower(columnType)), " ")
+
+	parts := findStringSubmatchMap(tableTypeRegexp.String(), columnType)
+	base, ok := parts["Base"]
+	if !ok || base == "" {
+		return columnType
+	}
+
+	if canonical, ok := tableTypeAliases[base]; ok {
+		base = canonical
+	}
+
+	modifier := strings.ReplaceAll(parts["Modifier"], " ", "")
+	// Time types have their precision between the type name and the time zone.
+	for _, timeType := range []string{"timestamp", "time"} {
+		for _, suffix := range []string{" without time zone", " with time zone"} {
+			if modifier != "" && base == timeType+suffix {
+				return timeType + modifier + suffix + parts["Array"]
+			}
+		}
+	}
+
+	return base + modifier + parts["Array"]
+}
+
+// parseCheckConstraintDef extracts the expression of a check constraint from pg_get_constraintdef()
+// e.g.: CHECK ((price > 0)) NOT VALID => (price > 0)
+func parseCheckConstraintDef(definition string) string {
+	definition = strings.TrimSuffix(definition, " NOT VALID")
+	definition = strings.TrimSuffix(definition, " NO INHERIT")
+	definition = strings.TrimPrefix(definition, "CHECK ")
+	if strings.HasPrefix(definition, "(") && strings.HasSuffix(definition, ")") {
+		definition = definition[1 : len(definition)-1]
+	}
+	return definition
+}
+
+func tableIdentifier(pgSchema, tableName string) string {
+	return fmt.Sprintf("%s.%s", pq.QuoteIdentifier(pgSchema), pq.QuoteIdentifier(tableName))
+}
+
+func getTableSchema(d *schema.ResourceData) string {
+	if v, ok := d.GetOk(tableSchemaAttr); ok {
+		return v.(string)
+	}
+	return "public"
+}
+
+func generateTableID(database, pgSchema, tableName string) string {
+	return strings.Join([]string{database, pgSchema, tableName}, ".")
+}
+
+// getDBTableName returns database, schema and table name. If we are importing this resource,
+// they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBTableName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	pgSchema := getTableSchema(d)
+	tableName := d.Get(tableNameAttr).(string)
+
+	// When importing, we have to parse the ID to find database, schema and table names.
+	if tableName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("table ID %s has not the expected format 'database.schema.table': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		pgSchema = parsed[1]
+		tableName = parsed[2]
+	}
+	return database, pgSchema, tableName, nil
+}
@@ -0,0 +1,452 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeColumnType(t *testing.T) {
+	cases := map[string]string{
+		"int":                         "integer",
+		"INT4":                        "integer",
+		"bigint":                      "bigint",
+		"varchar(255)":                "character varying(255)",
+		"varchar ( 255 )":             "character varying(255)",
+		"character varying(255)":      "character varying(255)",
+		"numeric(10, 2)":              "numeric(10,2)",
+		"decimal(10,2)":               "numeric(10,2)",
+		"timestamptz":                 "timestamp with time zone",
+		"timestamptz(3)":              "timestamp(3) with time zone",
+		"timestamp(3) with time zone": "timestamp(3) with time zone",
+		"text[]":                      "text[]",
+		"int[]":                       "integer[]",
+		"bool":                        "boolean",
+		"uuid":                        "uuid",
+	}
+
+	for input, expected := range cases {
+		assert.Equal(t, expected, normalizeColumnType(input), input)
+	}
+}
+
+func TestNormalizeTableExpression(t *testing.T) {
+	cases := []struct {
+		configured string
+		read       string
+	}{
+		{"now()", "now()"},
+		{"'foo'", "'foo'::text"},
+		{"'foo'", "'foo'::character varying"},
+		{"price > 0", "(price > (0)::numeric)"},
+		{"nextval('my_seq')", "nextval('my_seq'::regclass)"},
+		{"ARRAY[]::text[]", "ARRAY[]::text[]"},
+		{"'foo'", "'foo'::character varying(255)"},
+		{"a > 0 AND b > 0", "((a > 0) AND (b > 0))"},
+		{"a OR (b AND c)", "(a OR (b AND c))"},
+		{"(a OR b) AND c", "((a OR b) AND c)"},
+		{"NOT (a = b)", "(NOT (a = b))"},
+		{"CASE WHEN a > 0 THEN 'x' ELSE 'y' END", "CASE WHEN (a > 0) THEN 'x'::text ELSE 'y'::text END"},
+		{"data->>'status' = 'on hold'", "((data ->> 'status'::text) = 'on hold'::text)"},
+		{`"owner" = current_user`, "(owner = CURRENT_USER)"},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, normalizeTableExpression(c.configured), normalizeTableExpression(c.read), c.configured)
+	}
+
+	different := []struct {
+		configured string
+		read       string
+	}{
+		{"'foo'", "'bar'::text"},
+		{"status = 'on hold'", "(status = 'onhold'::text)"},
+		{"(a OR b) AND c", "(a OR (b AND c))"},
+		{"a OR (b AND c)", "((a OR b) AND c)"},
+		{"a - (b - c)", "((a - b) - c)"},
+		{"NOT (a AND b)", "((NOT a) AND b)"},
+		{`"Owner" = current_user`, "(owner = CURRENT_USER)"},
+	}
+
+	for _, c := range different {
+		assert.NotEqual(t, normalizeTableExpression(c.configured), normalizeTableExpression(c.read), c.configured)
+	}
+}
+
+func TestParseCheckConstraintDef(t *testing.T) {
+	assert.Equal(t, "(price > 0)", parseCheckConstraintDef("CHECK ((price > 0))"))
+	assert.Equal(t, "(price > 0)", parseCheckConstraintDef("CHECK ((price > 0)) NOT VALID"))
+}
+
+func TestCreateTableQuery(t *testing.T) {
+	query := createTableQuery("public", "orders",
+		[]pgTableColumn{
+			{Name: "id", Type: "bigint", Identity: "ALWAYS", Nullable: true},
+			{Name: "customer_id", Type: "bigint", Nullable: false},
+			{Name: "status", Type: "text", Nullable: true, Default: "'new'"},
+		},
+		[]pgTableConstraint{
+			{Type: "p", Columns: []string{"id"}},
+			{Type: "c", Name: "status_check", Expression: "status <> ''"},
+			{
+				Type: "f", Name: "orders_customer_fk", Columns: []string{"customer_id"},
+				RefSchema: "public", RefTable: "customers", RefColumns: []string{"id"},
+				OnDelete: "CASCADE", OnUpdate: "NO ACTION",
+			},
+		},
+	)
+
+	expected := `CREATE TABLE "public"."orders" (
+    "id" bigint GENERATED ALWAYS AS IDENTITY,
+    "customer_id" bigint NOT NULL,
+    "status" text DEFAULT 'new',
+    PRIMARY KEY ("id"),
+    CONSTRAINT "status_check" CHECK (status <> ''),
+    CONSTRAINT "orders_customer_fk" FOREIGN KEY ("customer_id") REFERENCES "public"."customers" ("id") ON DELETE CASCADE ON UPDATE NO ACTION
+)`
+
+	assert.Equal(t, expected, query)
+}
+
+func TestAlterTableColumnsQueries(t *testing.T) {
+	oldColumns := []pgTableColumn{
+		{Name: "id", Type: "integer", Nullable: false},
+		{Name: "name", Type: "varchar(10)", Nullable: true},
+		{Name: "status", Type: "text", Nullable: true, Default: "'new'::text"},
+		{Name: "removed", Type: "text", Nullable: true},
+	}
+	newColumns := []pgTableColumn{
+		{Name: "id", Type: "int", Nullable: false},
+		{Name: "name", Type: "character varying(20)", Nullable: false},
+		{Name: "status", Type: "text", Nullable: true, Default: "'new'"},
+		{Name: "created_at", Type: "timestamptz", Nullable: true, Default: "now()"},
+	}
+
+	assert.Equal(t,
+		[]string{
+			`ALTER TABLE "public"."t" DROP COLUMN "removed"`,
+			`ALTER TABLE "public"."t" ALTER COLUMN "name" TYPE character varying(20)`,
+			`ALTER TABLE "public"."t" ALTER COLUMN "name" SET NOT NULL`,
+			`ALTER TABLE "public"."t" ADD COLUMN "created_at" timestamptz DEFAULT now()`,
+		},
+		alterTableColumnsQueries("public", "t", oldColumns, newColumns),
+	)
+}
+
+func TestAlterTableColumnsQueriesRename(t *testing.T) {
+	oldColumns := []pgTableColumn{
+		{Name: "id", Type: "integer", Nullable: false},
+		{Name: "label", Type: "text", Nullable: true},
+	}
+	newColumns := []pgTableColumn{
+		{Name: "id", Type: "integer", Nullable: false},
+		{Name: "title", Type: "text", Nullable: false, PreviousName: "label"},
+	}
+
+	assert.Equal(t,
+		[]string{
+			`ALTER TABLE "public"."t" RENAME COLUMN "label" TO "title"`,
+			`ALTER TABLE "public"."t" ALTER COLUMN "title" SET NOT NULL`,
+		},
+		alterTableColumnsQueries("public", "t", oldColumns, newColumns),
+	)
+
+	// Without previous_name, the column is dropped and added again.
+	newColumns[1].PreviousName = ""
+	assert.Equal(t,
+		[]string{
+			`ALTER TABLE "public"."t" DROP COLUMN "label"`,
+			`ALTER TABLE "public"."t" ADD COLUMN "title" text NOT NULL`,
+		},
+		alterTableColumnsQueries("public", "t", oldColumns, newColumns),
+	)
+}
+
+func TestDiffTableConstraints(t *testing.T) {
+	oldConstraints := []pgTableConstraint{
+		{Type: "p", Name: "t_pkey", Columns: []string{"id"}},
+		{Type: "u", Name: "t_name_key", Columns: []string{"name"}},
+		{Type: "c", Name: "t_check", Expression: "(price > (0)::numeric)"},
+		{Type: "f", Name: "t_fk", Columns: []string{"parent_id"}, RefSchema: "public", RefTable: "t", RefColumns: []string{"id"}, OnDelete: "NO ACTION", OnUpdate: "NO ACTION"},
+	}
+	newConstraints := []pgTableConstraint{
+		{Type: "p", Name: "", Columns: []string{"id"}},
+		{Type: "u", Name: "t_name_key", Columns: []string{"name", "tenant"}},
+		{Type: "c", Name: "t_check", Expression: "price > 0"},
+	}
+
+	dropped, added := diffTableConstraints(oldConstraints, newConstraints)
+
+	assert.Equal(t,
+		[]pgTableConstraint{oldConstraints[3], oldConstraints[1]},
+		dropped,
+	)
+	assert.Equal(t,
+		[]pgTableConstraint{newConstraints[1]},
+		added,
+	)
+}
+
+func TestAccPostgresqlTable_Basic(t *testing.T) {
+	config := `
+resource "postgresql_table" "customers" {
+  name    = "customers"
+  comment = "All the customers"
+
+  column {
+    name     = "id"
+    type     = "bigint"
+    identity = "ALWAYS"
+  }
+  column {
+    name     = "email"
+    type     = "varchar(255)"
+    nullable = false
+  }
+
+  primary_key {
+    columns = ["id"]
+  }
+
+  unique {
+    name    = "customers_email_key"
+    columns = ["email"]
+  }
+}
+
+resource "postgresql_table" "orders" {
+  name = "orders"
+
+  column {
+    name     = "id"
+    type     = "int"
+    nullable = false
+  }
+  column {
+    name     = "customer_id"
+    type     = "bigint"
+    nullable = false
+  }
+  column {
+    name    = "price"
+    type    = "numeric(10,2)"
+    default = "0"
+  }
+  column {
+    name    = "status"
+    type    = "text"
+    default = "'new'"
+  }
+
+  primary_key {
+    name    = "orders_pk"
+    columns = ["id"]
+  }
+
+  check {
+    name       = "orders_price_check"
+    expression = "price >= 0"
+  }
+
+  foreign_key {
+    name               = "orders_customer_fk"
+    columns            = ["customer_id"]
+    references_table   = postgresql_table.customers.name
+    references_columns = ["id"]
+    on_delete          = "CASCADE"
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureIdentityColumn)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTableExists("postgresql_table.customers"),
+					testAccCheckPostgresqlTableExists("postgresql_table.orders"),
+					resource.TestCheckResourceAttr("postgresql_table.customers", "schema", "public"),
+					resource.TestCheckResourceAttr("postgresql_table.customers", "comment", "All the customers"),
+					resource.TestCheckResourceAttr("postgresql_table.customers", "column.0.identity", "ALWAYS"),
+					resource.TestCheckResourceAttr("postgresql_table.customers", "column.1.type", "character varying(255)"),
+					resource.TestCheckResourceAttr("postgresql_table.customers", "primary_key.0.name", "customers_pkey"),
+					resource.TestCheckResourceAttr("postgresql_table.orders", "primary_key.0.name", "orders_pk"),
+					resource.TestCheckResourceAttr("postgresql_table.orders", "foreign_key.0.references_schema", "public"),
+					resource.TestCheckResourceAttr("postgresql_table.orders", "foreign_key.0.on_delete", "CASCADE"),
+					resource.TestCheckResourceAttr("postgresql_table.orders", "foreign_key.0.on_update", "NO ACTION"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_table.orders",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"drop_cascade", "column", "check"},
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlTable_Update(t *testing.T) {
+	configCreate := `
+resource "postgresql_table" "test" {
+  name = "test_table"
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+  column {
+    name = "label"
+    type = "varchar(10)"
+  }
+  column {
+    name = "removed"
+    type = "text"
+  }
+}
+`
+
+	configUpdate := `
+resource "postgresql_table" "test" {
+  name    = "test_table_renamed"
+  comment = "renamed"
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+  column {
+    name     = "label"
+    type     = "varchar(20)"
+    nullable = false
+    default  = "'none'"
+  }
+  column {
+    name = "added"
+    type = "boolean"
+  }
+
+  primary_key {
+    columns = ["id"]
+  }
+
+  check {
+    name       = "label_not_empty"
+    expression = "label <> ''"
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: configCreate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTableExists("postgresql_table.test"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "column.#", "3"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "primary_key.#", "0"),
+				),
+			},
+			{
+				Config: configUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTableExists("postgresql_table.test"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "name", "test_table_renamed"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "comment", "renamed"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "column.#", "3"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "column.1.type", "character varying(20)"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "column.1.nullable", "false"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "column.2.name", "added"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "primary_key.0.name", "test_table_renamed_pkey"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "check.0.name", "label_not_empty"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlTableExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkTableExists(txn, rs.Primary.Attributes["schema"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking table %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Table not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPostgresqlTableDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_table" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkTableExists(txn, rs.Primary.Attributes["schema"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking table %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Table still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func checkTableExists(txn *sql.Tx, pgSchema, tableName string) (bool, error) {
+	var oid uint32
+	err := txn.QueryRow(tableOIDQuery, pgSchema, tableName).Scan(&oid)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about table: %s", err)
+	}
+
+	return true, nil
+}
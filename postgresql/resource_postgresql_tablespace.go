@@ -0,0 +1,369 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	tbspNameAttr                   = "name"
+	tbspLocationAttr               = "location"
+	tbspOwnerAttr                  = "owner"
+	tbspSeqPageCostAttr            = "seq_page_cost"
+	tbspRandomPageCostAttr         = "random_page_cost"
+	tbspEffectiveIOConcurrencyAttr = "effective_io_concurrency"
+)
+
+func resourcePostgreSQLTablespace() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLTablespaceCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLTablespaceRead),
+		Update: PGResourceFunc(resourcePostgreSQLTablespaceUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLTablespaceDelete),
+		Exists: PGResourceExistsFunc(resourcePostgreSQLTablespaceExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			tbspNameAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the tablespace",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			tbspLocationAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The directory that will be used for the tablespace. It must exist, be empty and be owned by the PostgreSQL system user",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			tbspOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ROLE which owns the tablespace",
+			},
+			tbspSeqPageCostAttr: {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      -1,
+				Description:  "The seq_page_cost of the tablespace. -1 means the server setting is used",
+				ValidateFunc: validation.FloatAtLeast(-1),
+			},
+			tbspRandomPageCostAttr: {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      -1,
+				Description:  "The random_page_cost of the tablespace. -1 means the server setting is used",
+				ValidateFunc: validation.FloatAtLeast(-1),
+			},
+			tbspEffectiveIOConcurrencyAttr: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				Description:  "The effective_io_concurrency of the tablespace. -1 means the server setting is used",
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLTablespaceCreate(db *DBConnection, d *schema.ResourceData) error {
+	tbspName := d.Get(tbspNameAttr).(string)
+
+	// CREATE TABLESPACE cannot be executed inside a transaction block.
+	if _, err := db.Exec(createTablespaceQuery(d)); err != nil {
+		return fmt.Errorf("Error creating tablespace %q: %w", tbspName, err)
+	}
+
+	d.SetId(tbspName)
+
+	for _, query := range alterTablespaceOptionsQueries(d, tbspName) {
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("Error setting tablespace options: %w", err)
+		}
+	}
+
+	return resourcePostgreSQLTablespaceReadImpl(db, d)
+}
+
+func resourcePostgreSQLTablespaceExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	var tbspName string
+	err := db.QueryRow("SELECT spcname FROM pg_catalog.pg_tablespace WHERE spcname = $1", d.Id()).Scan(&tbspName)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading tablespace: %w", err)
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLTablespaceRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLTablespaceReadImpl(db, d)
+}
+
+func resourcePostgreSQLTablespaceReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	tbspID := d.Id()
+
+	var tbspName, owner, location string
+	var options []string
+	err := db.QueryRow(`
+SELECT spcname, pg_catalog.pg_get_userbyid(spcowner), pg_catalog.pg_tablespace_location(oid),
+       COALESCE(spcoptions, '{}')
+  FROM pg_catalog.pg_tablespace
+ WHERE spcname = $1
+`, tbspID).Scan(&tbspName, &owner, &location, pq.Array(&options))
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL tablespace (%q) not found", tbspID)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading tablespace: %w", err)
+	}
+
+	parsedOptions := parseRelOptions(options)
+
+	seqPageCost, err := parseTablespaceFloatOption(parsedOptions, tbspSeqPageCostAttr)
+	if err != nil {
+		return err
+	}
+	randomPageCost, err := parseTablespaceFloatOption(parsedOptions, tbspRandomPageCostAttr)
+	if err != nil {
+		return err
+	}
+	effectiveIOConcurrency := -1
+	if v, ok := parsedOptions[tbspEffectiveIOConcurrencyAttr]; ok {
+		if effectiveIOConcurrency, err = strconv.Atoi(v); err != nil {
+			return fmt.Errorf("could not parse %s of tablespace %s: %w", tbspEffectiveIOConcurrencyAttr, tbspName, err)
+		}
+	}
+
+	d.Set(tbspNameAttr, tbspName)
+	d.Set(tbspOwnerAttr, owner)
+	d.Set(tbspLocationAttr, location)
+	d.Set(tbspSeqPageCostAttr, seqPageCost)
+	d.Set(tbspRandomPageCostAttr, randomPageCost)
+	d.Set(tbspEffectiveIOConcurrencyAttr, effectiveIOConcurrency)
+	d.SetId(tbspName)
+
+	return nil
+}
+
+func resourcePostgreSQLTablespaceUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if err := setTablespaceName(db, d); err != nil {
+		return err
+	}
+
+	if err := setTablespaceOwner(db, d); err != nil {
+		return err
+	}
+
+	for _, query := range alterTablespaceOptionsQueries(d, d.Get(tbspNameAttr).(string)) {
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("Error updating tablespace options: %w", err)
+		}
+	}
+
+	return resourcePostgreSQLTablespaceReadImpl(db, d)
+}
+
+func resourcePostgreSQLTablespaceDelete(db *DBConnection, d *schema.ResourceData) error {
+	tbspName := d.Get(tbspNameAttr).(string)
+
+	if err := checkTablespaceEmpty(db, tbspName); err != nil {
+		return err
+	}
+
+	// DROP TABLESPACE cannot be executed inside a transaction block.
+	if _, err := db.Exec(fmt.Sprintf("DROP TABLESPACE %s", pq.QuoteIdentifier(tbspName))); err != nil {
+		var pqErr *pq.Error
+		// 55000: object_not_in_prerequisite_state, raised if some objects were not visible to checkTablespaceEmpty.
+		if errors.As(err, &pqErr) && pqErr.Code == "55000" {
+			return fmt.Errorf("could not drop tablespace %s: it still contains objects, they have to be moved or dropped first: %w", tbspName, err)
+		}
+		return fmt.Errorf("Error dropping tablespace %q: %w", tbspName, err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// checkTablespaceEmpty returns an error listing the databases which still use the tablespace,
+// either as their default tablespace or because they have relations stored in it.
+// Databases which cannot be connected to are skipped (DROP TABLESPACE will fail anyway if they use it).
+func checkTablespaceEmpty(db *DBConnection, tbspName string) error {
+	var tbspOID uint32
+	if err := db.QueryRow("SELECT oid FROM pg_catalog.pg_tablespace WHERE spcname = $1", tbspName).Scan(&tbspOID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return fmt.Errorf("Error reading tablespace: %w", err)
+	}
+
+	var defaultFor []string
+	if err := db.QueryRow(
+		"SELECT ARRAY(SELECT datname FROM pg_catalog.pg_database WHERE dattablespace = $1 ORDER BY datname)::TEXT[]",
+		tbspOID,
+	).Scan(pq.Array(&defaultFor)); err != nil {
+		return fmt.Errorf("could not list databases using tablespace %s: %w", tbspName, err)
+	}
+	if len(defaultFor) > 0 {
+		return fmt.Errorf(
+			"could not drop tablespace %s: it is the default tablespace of database(s) %s",
+			tbspName, strings.Join(defaultFor, ", "),
+		)
+	}
+
+	databases, err := getConnectableDatabases(db)
+	if err != nil {
+		return err
+	}
+
+	objectsByDatabase := map[string]int{}
+	for _, dbName := range databases {
+		count, err := countTablespaceRelations(db.client, dbName, tbspOID)
+		if err != nil {
+			log.Printf("[WARN] could not check the content of tablespace %s in database %s: %v", tbspName, dbName, err)
+			continue
+		}
+		if count > 0 {
+			objectsByDatabase[dbName] = count
+		}
+	}
+
+	if len(objectsByDatabase) > 0 {
+		details := make([]string, 0, len(objectsByDatabase))
+		for dbName, count := range objectsByDatabase {
+			details = append(details, fmt.Sprintf("%s (%d)", dbName, count))
+		}
+		sort.Strings(details)
+		return fmt.Errorf(
+			"could not drop tablespace %s: it still contains objects in database(s) %s, they have to be moved or dropped first",
+			tbspName, strings.Join(details, ", "),
+		)
+	}
+
+	return nil
+}
+
+func countTablespaceRelations(client *Client, database string, tbspOID uint32) (int, error) {
+	txn, err := startTransaction(client, database)
+	if err != nil {
+		return 0, err
+	}
+	defer deferredRollback(txn)
+
+	var count int
+	err = txn.QueryRow("SELECT count(*) FROM pg_catalog.pg_class WHERE reltablespace = $1", tbspOID).Scan(&count)
+	return count, err
+}
+
+func createTablespaceQuery(d *schema.ResourceData) string {
+	query := fmt.Sprintf("CREATE TABLESPACE %s", pq.QuoteIdentifier(d.Get(tbspNameAttr).(string)))
+	if owner, ok := d.GetOk(tbspOwnerAttr); ok {
+		query += " OWNER " + pq.QuoteIdentifier(owner.(string))
+	}
+	return query + " LOCATION " + pq.QuoteLiteral(d.Get(tbspLocationAttr).(string))
+}
+
+// alterTablespaceOptionsQueries returns the ALTER TABLESPACE statements for the changed options.
+// Options set to -1 are reset.
+func alterTablespaceOptionsQueries(d *schema.ResourceData, tbspName string) []string {
+	var set, reset []string
+
+	for _, attr := range []string{tbspSeqPageCostAttr, tbspRandomPageCostAttr, tbspEffectiveIOConcurrencyAttr} {
+		if !d.HasChange(attr) {
+			continue
+		}
+
+		var value string
+		switch v := d.Get(attr).(type) {
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		case int:
+			value = strconv.Itoa(v)
+		}
+
+		if value == "-1" {
+			reset = append(reset, attr)
+		} else {
+			set = append(set, fmt.Sprintf("%s = %s", attr, value))
+		}
+	}
+
+	queries := []string{}
+	if len(set) > 0 {
+		queries = append(queries, fmt.Sprintf("ALTER TABLESPACE %s SET (%s)", pq.QuoteIdentifier(tbspName), strings.Join(set, ", ")))
+	}
+	if len(reset) > 0 {
+		queries = append(queries, fmt.Sprintf("ALTER TABLESPACE %s RESET (%s)", pq.QuoteIdentifier(tbspName), strings.Join(reset, ", ")))
+	}
+
+	return queries
+}
+
+func setTablespaceName(db QueryAble, d *schema.ResourceData) error {
+	if !d.HasChange(tbspNameAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(tbspNameAttr)
+	o := oraw.(string)
+	n := nraw.(string)
+	if n == "" {
+		return errors.New("Error setting tablespace name to an empty string")
+	}
+
+	query := fmt.Sprintf("ALTER TABLESPACE %s RENAME TO %s", pq.QuoteIdentifier(o), pq.QuoteIdentifier(n))
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("Error updating tablespace name: %w", err)
+	}
+	d.SetId(n)
+
+	return nil
+}
+
+func setTablespaceOwner(db QueryAble, d *schema.ResourceData) error {
+	if !d.HasChange(tbspOwnerAttr) {
+		return nil
+	}
+
+	owner := d.Get(tbspOwnerAttr).(string)
+	if owner == "" {
+		return nil
+	}
+
+	query := fmt.Sprintf("ALTER TABLESPACE %s OWNER TO %s", pq.QuoteIdentifier(d.Get(tbspNameAttr).(string)), pq.QuoteIdentifier(owner))
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("Error updating tablespace owner: %w", err)
+	}
+
+	return nil
+}
+
+func parseTablespaceFloatOption(options map[string]string, option string) (float64, error) {
+	v, ok := options[option]
+	if !ok {
+		return -1, nil
+	}
+	value, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse tablespace option %s: %w", option, err)
+	}
+	return value, nil
+}
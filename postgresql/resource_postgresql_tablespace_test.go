@@ -0,0 +1,159 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTablespaceQuery(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLTablespace().Schema, map[string]interface{}{
+		"name":     "fast",
+		"location": "/mnt/it's-fast",
+		"owner":    "admin",
+	})
+
+	assert.Equal(t, `CREATE TABLESPACE "fast" OWNER "admin" LOCATION '/mnt/it''s-fast'`, createTablespaceQuery(d))
+}
+
+func TestAlterTablespaceOptionsQueries(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePostgreSQLTablespace().Schema, map[string]interface{}{
+		"name":                     "fast",
+		"location":                 "/mnt/fast",
+		"random_page_cost":         1.1,
+		"effective_io_concurrency": 200,
+	})
+
+	assert.Equal(t, []string{
+		`ALTER TABLESPACE "fast" SET (random_page_cost = 1.1, effective_io_concurrency = 200)`,
+		`ALTER TABLESPACE "fast" RESET (seq_page_cost)`,
+	}, alterTablespaceOptionsQueries(d, "fast"))
+}
+
+// The tablespace location has to be an empty directory owned by the postgres user on the server,
+// so this test is only run if TF_POSTGRESQL_TABLESPACE_LOCATION is set.
+func TestAccPostgresqlTablespace_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	location := os.Getenv("TF_POSTGRESQL_TABLESPACE_LOCATION")
+	if location == "" {
+		t.Skip("Tablespace tests skipped unless env 'TF_POSTGRESQL_TABLESPACE_LOCATION' set")
+	}
+
+	dbSuffix, teardown := setupTestDatabase(t, false, true)
+	defer teardown()
+
+	_, roleName := getTestDBNames(dbSuffix)
+
+	config := `
+resource "postgresql_tablespace" "test" {
+  name             = "%s"
+  location         = "%s"
+  owner            = "%s"
+  random_page_cost = %s
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testSuperuserPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlTablespaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, "tf_tests_tablespace", location, roleName, "1.1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTablespaceExists("postgresql_tablespace.test"),
+					resource.TestCheckResourceAttr("postgresql_tablespace.test", "owner", roleName),
+					resource.TestCheckResourceAttr("postgresql_tablespace.test", "location", location),
+					resource.TestCheckResourceAttr("postgresql_tablespace.test", "random_page_cost", "1.1"),
+					resource.TestCheckResourceAttr("postgresql_tablespace.test", "seq_page_cost", "-1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, "tf_tests_tablespace_renamed", location, roleName, "-1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTablespaceExists("postgresql_tablespace.test"),
+					resource.TestCheckResourceAttr("postgresql_tablespace.test", "name", "tf_tests_tablespace_renamed"),
+					resource.TestCheckResourceAttr("postgresql_tablespace.test", "random_page_cost", "-1"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_tablespace.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlTablespaceExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		exists, err := checkTablespaceExists(testAccProvider.Meta().(*Client), rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error checking tablespace %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Tablespace not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPostgresqlTablespaceDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_tablespace" {
+			continue
+		}
+
+		exists, err := checkTablespaceExists(client, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error checking tablespace %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Tablespace still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func checkTablespaceExists(client *Client, tbspName string) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+
+	var oid uint32
+	err = db.QueryRow("SELECT oid FROM pg_catalog.pg_tablespace WHERE spcname = $1", tbspName).Scan(&oid)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about tablespace: %s", err)
+	}
+
+	return true, nil
+}
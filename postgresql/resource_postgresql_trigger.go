@@ -0,0 +1,618 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	triggerNameAttr          = "name"
+	triggerTableAttr         = "table"
+	triggerSchemaAttr        = "schema"
+	triggerDatabaseAttr      = "database"
+	triggerTimingAttr        = "timing"
+	triggerEventsAttr        = "events"
+	triggerUpdateColumnsAttr = "update_columns"
+	triggerForEachAttr       = "for_each"
+	triggerWhenAttr          = "when"
+	triggerOldTableAttr      = "referencing_old_table"
+	triggerNewTableAttr      = "referencing_new_table"
+	triggerFunctionAttr      = "function"
+	triggerFunctionArgsAttr  = "function_arguments"
+	triggerEnabledAttr       = "enabled"
+)
+
+// Bits of pg_trigger.tgtype
+const (
+	triggerTypeRow      = 1 << 0
+	triggerTypeBefore   = 1 << 1
+	triggerTypeInsert   = 1 << 2
+	triggerTypeDelete   = 1 << 3
+	triggerTypeUpdate   = 1 << 4
+	triggerTypeTruncate = 1 << 5
+	triggerTypeInstead  = 1 << 6
+)
+
+var (
+	triggerTimings = []string{"BEFORE", "AFTER", "INSTEAD OF"}
+	triggerEvents  = []string{"INSERT", "UPDATE", "DELETE", "TRUNCATE"}
+
+	// Mapping of the trigger modes to the ALTER TABLE/ALTER EVENT TRIGGER keywords.
+	// These modes are used by both postgresql_trigger and postgresql_event_trigger.
+	triggerEnabledModes = map[string]string{
+		"ORIGIN":   "ENABLE",
+		"REPLICA":  "ENABLE REPLICA",
+		"ALWAYS":   "ENABLE ALWAYS",
+		"DISABLED": "DISABLE",
+	}
+
+	// Mapping of pg_trigger.tgenabled (and pg_event_trigger.evtenabled) to the trigger modes.
+	triggerEnabledCodes = map[string]string{
+		"O": "ORIGIN",
+		"R": "REPLICA",
+		"A": "ALWAYS",
+		"D": "DISABLED",
+	}
+
+	triggerWhenRegexp = regexp.MustCompile(`\sWHEN \((.*)\) EXECUTE (?:FUNCTION|PROCEDURE) `)
+
+	triggerRowRefRegexp = regexp.MustCompile(`(?i)\b(new|old)\.`)
+)
+
+func resourcePostgreSQLTrigger() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLTriggerCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLTriggerRead),
+		Update: PGResourceFunc(resourcePostgreSQLTriggerUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLTriggerDelete),
+		Exists: PGResourceExistsFunc(resourcePostgreSQLTriggerExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			triggerNameAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the trigger",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			triggerTableAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The table (or view) on which the trigger is defined",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			triggerSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The schema of the table. If not specified, the public schema is used",
+			},
+			triggerDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database of the table. If not specified, the provider default database is used",
+			},
+			triggerTimingAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "When the function is called. One of: " + strings.Join(triggerTimings, ", "),
+				ValidateFunc: validation.StringInSlice(triggerTimings, false),
+			},
+			triggerEventsAttr: {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice(triggerEvents, false)},
+				Set:         schema.HashString,
+				Description: "The events that fire the trigger. Any of: " + strings.Join(triggerEvents, ", "),
+			},
+			triggerUpdateColumnsAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The columns for UPDATE OF. If specified, the trigger only fires when one of these columns is updated",
+			},
+			triggerForEachAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "STATEMENT",
+				Description:  "Whether the function is called once for every modified row or once per statement. One of: ROW, STATEMENT",
+				ValidateFunc: validation.StringInSlice([]string{"ROW", "STATEMENT"}, false),
+			},
+			triggerWhenAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A boolean expression that determines whether the function will actually be executed",
+
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeTriggerWhen(old) == normalizeTriggerWhen(new)
+				},
+			},
+			triggerOldTableAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the transition relation containing the old rows (AFTER triggers only)",
+			},
+			triggerNewTableAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the transition relation containing the new rows (AFTER triggers only)",
+			},
+			triggerFunctionAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The function called by the trigger, optionally schema-qualified. It must return trigger",
+				ValidateFunc: validation.StringIsNotEmpty,
+
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeTriggerFunction(old) == normalizeTriggerFunction(new)
+				},
+			},
+			triggerFunctionArgsAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The arguments passed to the function (as string literals)",
+			},
+			triggerEnabledAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ORIGIN",
+				Description:  "The firing mode of the trigger. One of: ORIGIN, REPLICA, ALWAYS, DISABLED",
+				ValidateFunc: validation.StringInSlice([]string{"ORIGIN", "REPLICA", "ALWAYS", "DISABLED"}, false),
+			},
+		},
+	}
+}
+
+func validateTriggerFeatures(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureTriggerTransitionTables) {
+		if d.Get(triggerOldTableAttr).(string) != "" || d.Get(triggerNewTableAttr).(string) != "" {
+			return fmt.Errorf(
+				"Transition tables are not supported for this Postgres version (%s)",
+				db.version,
+			)
+		}
+	}
+	return nil
+}
+
+func resourcePostgreSQLTriggerCreate(db *DBConnection, d *schema.ResourceData) error {
+	if err := validateTriggerFeatures(db, d); err != nil {
+		return err
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getTriggerSchema(d)
+	tableName := d.Get(triggerTableAttr).(string)
+	triggerName := d.Get(triggerNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := createTrigger(db, txn, d, pgSchema); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateTriggerID(database, pgSchema, tableName, triggerName))
+
+	return resourcePostgreSQLTriggerReadImpl(db, d)
+}
+
+func resourcePostgreSQLTriggerExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, pgSchema, tableName, triggerName, err := getDBTriggerName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var oid uint32
+	err = txn.QueryRow(triggerOIDQuery, pgSchema, tableName, triggerName).Scan(&oid)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading trigger: %w", err)
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLTriggerRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLTriggerReadImpl(db, d)
+}
+
+const triggerOIDQuery = `
+SELECT t.oid
+  FROM pg_catalog.pg_trigger t
+  JOIN pg_catalog.pg_class c ON c.oid = t.tgrelid
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relname = $2 AND t.tgname = $3 AND NOT t.tgisinternal
+`
+
+func resourcePostgreSQLTriggerReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, pgSchema, tableName, triggerName, err := getDBTriggerName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	transitionColumns := "'', ''"
+	if db.featureSupported(featureTriggerTransitionTables) {
+		transitionColumns = "COALESCE(t.tgoldtable::TEXT, ''), COALESCE(t.tgnewtable::TEXT, '')"
+	}
+
+	var tgType int
+	var enabled, function, definition, oldTable, newTable string
+	var rawArgs []byte
+	var updateColumns []string
+	err = txn.QueryRow(fmt.Sprintf(`
+SELECT t.tgtype, t.tgenabled::TEXT, t.tgfoid::regproc::TEXT, t.tgargs,
+       ARRAY(
+           SELECT a.attname FROM unnest(t.tgattr::INT2[]) WITH ORDINALITY AS k(attnum, ord)
+             JOIN pg_catalog.pg_attribute a ON a.attrelid = t.tgrelid AND a.attnum = k.attnum
+            ORDER BY k.ord
+       )::TEXT[],
+       pg_catalog.pg_get_triggerdef(t.oid), %s
+  FROM pg_catalog.pg_trigger t
+  JOIN pg_catalog.pg_class c ON c.oid = t.tgrelid
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relname = $2 AND t.tgname = $3 AND NOT t.tgisinternal
+`, transitionColumns), pgSchema, tableName, triggerName).Scan(
+		&tgType, &enabled, &function, &rawArgs, pq.Array(&updateColumns), &definition, &oldTable, &newTable,
+	)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL trigger (%s) on table %s not found in database %s", triggerName, tableName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading trigger: %w", err)
+	}
+
+	timing, forEach, events := parseTriggerType(tgType)
+
+	// Keep the function name as configured if it's the same function.
+	if normalizeTriggerFunction(function) == normalizeTriggerFunction(d.Get(triggerFunctionAttr).(string)) {
+		function = d.Get(triggerFunctionAttr).(string)
+	}
+
+	d.Set(triggerNameAttr, triggerName)
+	d.Set(triggerTableAttr, tableName)
+	d.Set(triggerSchemaAttr, pgSchema)
+	d.Set(triggerDatabaseAttr, database)
+	d.Set(triggerTimingAttr, timing)
+	d.Set(triggerEventsAttr, stringSliceToSet(events))
+	d.Set(triggerUpdateColumnsAttr, updateColumns)
+	d.Set(triggerForEachAttr, forEach)
+	d.Set(triggerWhenAttr, parseTriggerWhen(definition))
+	d.Set(triggerOldTableAttr, oldTable)
+	d.Set(triggerNewTableAttr, newTable)
+	d.Set(triggerFunctionAttr, function)
+	d.Set(triggerFunctionArgsAttr, parseTriggerArgs(rawArgs))
+	d.Set(triggerEnabledAttr, triggerEnabledCodes[enabled])
+	d.SetId(generateTriggerID(database, pgSchema, tableName, triggerName))
+
+	return nil
+}
+
+func resourcePostgreSQLTriggerUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if err := validateTriggerFeatures(db, d); err != nil {
+		return err
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getTriggerSchema(d)
+	tableName := d.Get(triggerTableAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := setTriggerName(txn, d, pgSchema); err != nil {
+		return err
+	}
+
+	triggerName := d.Get(triggerNameAttr).(string)
+
+	// A trigger definition cannot be altered, it's dropped and created again in the same transaction.
+	if d.HasChanges(
+		triggerTimingAttr, triggerEventsAttr, triggerUpdateColumnsAttr, triggerForEachAttr, triggerWhenAttr,
+		triggerOldTableAttr, triggerNewTableAttr, triggerFunctionAttr, triggerFunctionArgsAttr,
+	) {
+		query := fmt.Sprintf("DROP TRIGGER %s ON %s", pq.QuoteIdentifier(triggerName), tableIdentifier(pgSchema, tableName))
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not drop trigger %s: %w", triggerName, err)
+		}
+		if err := createTrigger(db, txn, d, pgSchema); err != nil {
+			return err
+		}
+	} else if d.HasChange(triggerEnabledAttr) {
+		if err := setTriggerEnabled(txn, d, pgSchema); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourcePostgreSQLTriggerReadImpl(db, d)
+}
+
+func resourcePostgreSQLTriggerDelete(db *DBConnection, d *schema.ResourceData) error {
+	database, pgSchema, tableName, triggerName, err := getDBTriggerName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	query := fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s", pq.QuoteIdentifier(triggerName), tableIdentifier(pgSchema, tableName))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("could not drop trigger %s: %w", triggerName, err)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// createTrigger creates the trigger and sets its firing mode if it's not the default one.
+func createTrigger(db *DBConnection, txn *sql.Tx, d *schema.ResourceData, pgSchema string) error {
+	triggerName := d.Get(triggerNameAttr).(string)
+
+	query := createTriggerQuery(d, pgSchema, db.featureSupported(featureTriggerExecuteFunction))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("could not create trigger %s: %w", triggerName, err)
+	}
+
+	if d.Get(triggerEnabledAttr).(string) != "ORIGIN" {
+		return setTriggerEnabled(txn, d, pgSchema)
+	}
+	return nil
+}
+
+func createTriggerQuery(d *schema.ResourceData, pgSchema string, executeFunction bool) string {
+	b := bytes.NewBufferString("CREATE TRIGGER ")
+	fmt.Fprint(b, pq.QuoteIdentifier(d.Get(triggerNameAttr).(string)), " ", d.Get(triggerTimingAttr).(string), " ")
+
+	var events []string
+	configuredEvents := d.Get(triggerEventsAttr).(*schema.Set)
+	// Events are written in a stable order.
+	for _, event := range triggerEvents {
+		if !configuredEvents.Contains(event) {
+			continue
+		}
+		if columns := interfaceSliceToStrings(d.Get(triggerUpdateColumnsAttr).([]interface{})); event == "UPDATE" && len(columns) > 0 {
+			event = "UPDATE OF " + quoteIdentifierList(columns)
+		}
+		events = append(events, event)
+	}
+	fmt.Fprint(b, strings.Join(events, " OR "))
+
+	fmt.Fprint(b, " ON ", tableIdentifier(pgSchema, d.Get(triggerTableAttr).(string)))
+
+	oldTable := d.Get(triggerOldTableAttr).(string)
+	newTable := d.Get(triggerNewTableAttr).(string)
+	if oldTable != "" || newTable != "" {
+		b.WriteString(" REFERENCING")
+		if oldTable != "" {
+			fmt.Fprint(b, " OLD TABLE AS ", pq.QuoteIdentifier(oldTable))
+		}
+		if newTable != "" {
+			fmt.Fprint(b, " NEW TABLE AS ", pq.QuoteIdentifier(newTable))
+		}
+	}
+
+	fmt.Fprint(b, " FOR EACH ", d.Get(triggerForEachAttr).(string))
+
+	if when := d.Get(triggerWhenAttr).(string); when != "" {
+		fmt.Fprint(b, " WHEN (", when, ")")
+	}
+
+	if executeFunction {
+		b.WriteString(" EXECUTE FUNCTION ")
+	} else {
+		b.WriteString(" EXECUTE PROCEDURE ")
+	}
+
+	args := interfaceSliceToStrings(d.Get(triggerFunctionArgsAttr).([]interface{}))
+	for i, arg := range args {
+		args[i] = pq.QuoteLiteral(arg)
+	}
+	fmt.Fprint(b, d.Get(triggerFunctionAttr).(string), "(", strings.Join(args, ", "), ")")
+
+	return b.String()
+}
+
+func setTriggerEnabled(txn *sql.Tx, d *schema.ResourceData, pgSchema string) error {
+	triggerName := d.Get(triggerNameAttr).(string)
+
+	query := fmt.Sprintf(
+		"ALTER TABLE %s %s TRIGGER %s",
+		tableIdentifier(pgSchema, d.Get(triggerTableAttr).(string)),
+		triggerEnabledModes[d.Get(triggerEnabledAttr).(string)],
+		pq.QuoteIdentifier(triggerName),
+	)
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("could not set firing mode of trigger %s: %w", triggerName, err)
+	}
+
+	return nil
+}
+
+func setTriggerName(txn *sql.Tx, d *schema.ResourceData, pgSchema string) error {
+	if !d.HasChange(triggerNameAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(triggerNameAttr)
+	o := oraw.(string)
+	n := nraw.(string)
+	if n == "" {
+		return errors.New("Error setting trigger name to an empty string")
+	}
+
+	tableName := d.Get(triggerTableAttr).(string)
+	query := fmt.Sprintf(
+		"ALTER TRIGGER %s ON %s RENAME TO %s",
+		pq.QuoteIdentifier(o), tableIdentifier(pgSchema, tableName), pq.QuoteIdentifier(n),
+	)
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating trigger name: %w", err)
+	}
+
+	d.SetId(generateTriggerID(getDatabase(d, ""), pgSchema, tableName, n))
+
+	return nil
+}
+
+// parseTriggerType decodes pg_trigger.tgtype into the timing, the level and the events of the trigger.
+func parseTriggerType(tgType int) (string, string, []string) {
+	timing := "AFTER"
+	switch {
+	case tgType&triggerTypeInstead != 0:
+		timing = "INSTEAD OF"
+	case tgType&triggerTypeBefore != 0:
+		timing = "BEFORE"
+	}
+
+	forEach := "STATEMENT"
+	if tgType&triggerTypeRow != 0 {
+		forEach = "ROW"
+	}
+
+	events := []string{}
+	for _, event := range []struct {
+		bit  int
+		name string
+	}{
+		{triggerTypeInsert, "INSERT"},
+		{triggerTypeUpdate, "UPDATE"},
+		{triggerTypeDelete, "DELETE"},
+		{triggerTypeTruncate, "TRUNCATE"},
+	} {
+		if tgType&event.bit != 0 {
+			events = append(events, event.name)
+		}
+	}
+
+	return timing, forEach, events
+}
+
+// parseTriggerArgs decodes pg_trigger.tgargs which contains the arguments separated by null bytes.
+func parseTriggerArgs(rawArgs []byte) []string {
+	args := []string{}
+	if len(rawArgs) == 0 {
+		return args
+	}
+	for _, arg := range bytes.Split(bytes.TrimSuffix(rawArgs, []byte{0}), []byte{0}) {
+		args = append(args, string(arg))
+	}
+	return args
+}
+
+// parseTriggerWhen extracts the WHEN condition from pg_get_triggerdef()
+func parseTriggerWhen(definition string) string {
+	if match := triggerWhenRegexp.FindStringSubmatch(definition); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// normalizeTriggerWhen normalizes a WHEN condition like other expressions and
+// lower cases the OLD and NEW row references as Postgres does.
+func normalizeTriggerWhen(when string) string {
+	when = triggerRowRefRegexp.ReplaceAllStringFunc(when, strings.ToLower)
+	return normalizeTableExpression(when)
+}
+
+// normalizeTriggerFunction removes the public schema and the quotes from a function name
+// as Postgres only qualifies the function if it's not in the search_path.
+func normalizeTriggerFunction(function string) string {
+	function = strings.ReplaceAll(strings.TrimSuffix(function, "()"), `"`, "")
+	return strings.TrimPrefix(function, "public.")
+}
+
+func getTriggerSchema(d *schema.ResourceData) string {
+	if v, ok := d.GetOk(triggerSchemaAttr); ok {
+		return v.(string)
+	}
+	return "public"
+}
+
+func generateTriggerID(database, pgSchema, tableName, triggerName string) string {
+	return strings.Join([]string{database, pgSchema, tableName, triggerName}, ".")
+}
+
+// getDBTriggerName returns database, schema, table and trigger name. If we are importing this resource,
+// they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBTriggerName(d *schema.ResourceData, client *Client) (string, string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	pgSchema := getTriggerSchema(d)
+	tableName := d.Get(triggerTableAttr).(string)
+	triggerName := d.Get(triggerNameAttr).(string)
+
+	// When importing, we have to parse the ID to find database, schema, table and trigger names.
+	if triggerName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 4 {
+			return "", "", "", "", fmt.Errorf("trigger ID %s has not the expected format 'database.schema.table.trigger': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		pgSchema = parsed[1]
+		tableName = parsed[2]
+		triggerName = parsed[3]
+	}
+	return database, pgSchema, tableName, triggerName, nil
+}
@@ -0,0 +1,250 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTriggerQuery(t *testing.T) {
+	cases := []struct {
+		resource        map[string]interface{}
+		executeFunction bool
+		expected        string
+	}{
+		{
+			resource: map[string]interface{}{
+				"name":     "audit",
+				"table":    "orders",
+				"timing":   "AFTER",
+				"events":   []interface{}{"DELETE", "INSERT"},
+				"function": "audit_changes",
+			},
+			executeFunction: true,
+			expected:        `CREATE TRIGGER "audit" AFTER INSERT OR DELETE ON "public"."orders" FOR EACH STATEMENT EXECUTE FUNCTION audit_changes()`,
+		},
+		{
+			resource: map[string]interface{}{
+				"name":               "check_price",
+				"table":              "orders",
+				"timing":             "BEFORE",
+				"events":             []interface{}{"UPDATE"},
+				"update_columns":     []interface{}{"price", "quantity"},
+				"for_each":           "ROW",
+				"when":               "OLD.price IS DISTINCT FROM NEW.price",
+				"function":           "audit.check_price",
+				"function_arguments": []interface{}{"strict", "it's"},
+			},
+			expected: `CREATE TRIGGER "check_price" BEFORE UPDATE OF "price", "quantity" ON "public"."orders" FOR EACH ROW WHEN (OLD.price IS DISTINCT FROM NEW.price) EXECUTE PROCEDURE audit.check_price('strict', 'it''s')`,
+		},
+		{
+			resource: map[string]interface{}{
+				"name":                  "summary",
+				"table":                 "orders",
+				"timing":                "AFTER",
+				"events":                []interface{}{"UPDATE"},
+				"referencing_old_table": "old_rows",
+				"referencing_new_table": "new_rows",
+				"function":              "refresh_summary",
+			},
+			executeFunction: true,
+			expected:        `CREATE TRIGGER "summary" AFTER UPDATE ON "public"."orders" REFERENCING OLD TABLE AS "old_rows" NEW TABLE AS "new_rows" FOR EACH STATEMENT EXECUTE FUNCTION refresh_summary()`,
+		},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourcePostgreSQLTrigger().Schema, c.resource)
+		assert.Equal(t, c.expected, createTriggerQuery(d, "public", c.executeFunction))
+	}
+}
+
+func TestParseTriggerType(t *testing.T) {
+	timing, forEach, events := parseTriggerType(triggerTypeRow | triggerTypeBefore | triggerTypeInsert | triggerTypeUpdate)
+	assert.Equal(t, "BEFORE", timing)
+	assert.Equal(t, "ROW", forEach)
+	assert.Equal(t, []string{"INSERT", "UPDATE"}, events)
+
+	timing, forEach, events = parseTriggerType(triggerTypeTruncate)
+	assert.Equal(t, "AFTER", timing)
+	assert.Equal(t, "STATEMENT", forEach)
+	assert.Equal(t, []string{"TRUNCATE"}, events)
+
+	timing, _, _ = parseTriggerType(triggerTypeRow | triggerTypeInstead | triggerTypeDelete)
+	assert.Equal(t, "INSTEAD OF", timing)
+}
+
+func TestParseTriggerArgs(t *testing.T) {
+	assert.Equal(t, []string{}, parseTriggerArgs(nil))
+	assert.Equal(t, []string{"a", "", "b c"}, parseTriggerArgs([]byte("a\x00\x00b c\x00")))
+}
+
+func TestParseTriggerWhen(t *testing.T) {
+	assert.Equal(t,
+		"(old.price IS DISTINCT FROM new.price)",
+		parseTriggerWhen("CREATE TRIGGER t BEFORE UPDATE ON public.orders FOR EACH ROW WHEN ((old.price IS DISTINCT FROM new.price)) EXECUTE FUNCTION f()"),
+	)
+	assert.Equal(t, "", parseTriggerWhen("CREATE TRIGGER t AFTER INSERT ON public.orders FOR EACH STATEMENT EXECUTE FUNCTION f()"))
+}
+
+func TestNormalizeTriggerWhen(t *testing.T) {
+	assert.Equal(t,
+		normalizeTriggerWhen("(new.val IS NOT NULL)"),
+		normalizeTriggerWhen("NEW.val IS NOT NULL"),
+	)
+	assert.NotEqual(t,
+		normalizeTriggerWhen("OLD.val IS NOT NULL"),
+		normalizeTriggerWhen("NEW.val IS NOT NULL"),
+	)
+}
+
+func TestNormalizeTriggerFunction(t *testing.T) {
+	assert.Equal(t, "f", normalizeTriggerFunction("public.f"))
+	assert.Equal(t, "audit.f", normalizeTriggerFunction(`"audit"."f"`))
+	assert.Equal(t, "f", normalizeTriggerFunction("f()"))
+}
+
+func TestAccPostgresqlTrigger_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	defer createTestTables(t, dbSuffix, []string{"test_table"}, "")()
+
+	config := `
+resource "postgresql_function" "trigger_function" {
+  database = "%s"
+  name     = "test_trigger_function"
+  returns  = "trigger"
+  language = "plpgsql"
+  body     = <<-EOF
+    BEGIN
+      RETURN NEW;
+    END;
+  EOF
+}
+
+resource "postgresql_trigger" "test" {
+  database = "%s"
+  name     = "%s"
+  table    = "test_table"
+  timing   = "BEFORE"
+  events   = ["INSERT", "UPDATE"]
+  for_each = "ROW"
+  when     = "%s"
+  function = postgresql_function.trigger_function.name
+  enabled  = "%s"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureFunction)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlTriggerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, dbName, dbName, "test_trigger", "NEW.val IS NOT NULL", "ORIGIN"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTriggerExists("postgresql_trigger.test"),
+					resource.TestCheckResourceAttr("postgresql_trigger.test", "timing", "BEFORE"),
+					resource.TestCheckResourceAttr("postgresql_trigger.test", "events.#", "2"),
+					resource.TestCheckResourceAttr("postgresql_trigger.test", "for_each", "ROW"),
+					resource.TestCheckResourceAttr("postgresql_trigger.test", "enabled", "ORIGIN"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, dbName, dbName, "test_trigger_renamed", "NEW.val <> ''", "DISABLED"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTriggerExists("postgresql_trigger.test"),
+					resource.TestCheckResourceAttr("postgresql_trigger.test", "name", "test_trigger_renamed"),
+					resource.TestCheckResourceAttr("postgresql_trigger.test", "enabled", "DISABLED"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_trigger.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlTriggerExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkTriggerExists(txn, rs.Primary.Attributes["schema"], rs.Primary.Attributes["table"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking trigger %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Trigger not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPostgresqlTriggerDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_trigger" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkTriggerExists(txn, rs.Primary.Attributes["schema"], rs.Primary.Attributes["table"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking trigger %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Trigger still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func checkTriggerExists(txn *sql.Tx, pgSchema, tableName, triggerName string) (bool, error) {
+	var oid uint32
+	err := txn.QueryRow(triggerOIDQuery, pgSchema, tableName, triggerName).Scan(&oid)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about trigger: %s", err)
+	}
+
+	return true, nil
+}
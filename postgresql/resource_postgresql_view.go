@@ -0,0 +1,788 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	viewNameAttr            = "name"
+	viewSchemaAttr          = "schema"
+	viewDatabaseAttr        = "database"
+	viewQueryAttr           = "query"
+	viewDefinitionAttr      = "definition"
+	viewOwnerAttr           = "owner"
+	viewCheckOptionAttr     = "check_option"
+	viewSecurityBarrierAttr = "security_barrier"
+	viewSecurityInvokerAttr = "security_invoker"
+	viewDropCascadeAttr     = "drop_cascade"
+)
+
+// pgDependentView is a view or materialized view depending on another one.
+// It is used to recreate dependent views when a view has to be dropped to be replaced.
+type pgDependentView struct {
+	Schema     string
+	Name       string
+	Kind       string
+	Definition string
+	Options    []string
+	Owner      string
+	Tablespace string
+	Populated  bool
+
+	pgViewProperties
+}
+
+// pgViewProperties are the properties of a view or materialized view which are lost
+// when it's dropped and have to be restored when it's created again.
+type pgViewProperties struct {
+	Grants           []string
+	Comment          string
+	CommentedColumns []string
+	ColumnComments   []string
+	Indexes          []string
+	Triggers         []string
+}
+
+// viewPropertiesColumns selects the pgViewProperties of the relation c.
+// The privileges of the owner are implicit so they are not restored, as the owner may change.
+const viewPropertiesColumns = `
+       ARRAY(
+           SELECT format(
+               'GRANT %s ON %s TO %s%s',
+               a.privilege_type,
+               c.oid::regclass,
+               CASE WHEN a.grantee = 0 THEN 'PUBLIC' ELSE quote_ident(pg_catalog.pg_get_userbyid(a.grantee)) END,
+               CASE WHEN a.is_grantable THEN ' WITH GRANT OPTION' ELSE '' END
+           )
+           FROM aclexplode(c.relacl) a
+          WHERE a.grantee <> c.relowner
+       )::TEXT[],
+       COALESCE(pg_catalog.obj_description(c.oid, 'pg_class'), ''),
+       ARRAY(
+           SELECT a.attname
+             FROM pg_catalog.pg_attribute a
+             JOIN pg_catalog.pg_description ds
+               ON ds.objoid = a.attrelid AND ds.classoid = 'pg_catalog.pg_class'::regclass AND ds.objsubid = a.attnum
+            WHERE a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+            ORDER BY a.attnum
+       )::TEXT[],
+       ARRAY(
+           SELECT ds.description
+             FROM pg_catalog.pg_attribute a
+             JOIN pg_catalog.pg_description ds
+               ON ds.objoid = a.attrelid AND ds.classoid = 'pg_catalog.pg_class'::regclass AND ds.objsubid = a.attnum
+            WHERE a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+            ORDER BY a.attnum
+       )::TEXT[],
+       ARRAY(
+           SELECT pg_catalog.pg_get_indexdef(i.indexrelid)
+             FROM pg_catalog.pg_index i
+            WHERE i.indrelid = c.oid
+            ORDER BY i.indexrelid
+       )::TEXT[],
+       ARRAY(
+           SELECT pg_catalog.pg_get_triggerdef(tg.oid)
+             FROM pg_catalog.pg_trigger tg
+            WHERE tg.tgrelid = c.oid AND NOT tg.tgisinternal
+            ORDER BY tg.oid
+       )::TEXT[]`
+
+func (p *pgViewProperties) scanDest() []interface{} {
+	return []interface{}{
+		pq.Array(&p.Grants), &p.Comment, pq.Array(&p.CommentedColumns), pq.Array(&p.ColumnComments),
+		pq.Array(&p.Indexes), pq.Array(&p.Triggers),
+	}
+}
+
+func resourcePostgreSQLView() *schema.Resource {
+	return &schema.Resource{
+		Create: PGResourceFunc(resourcePostgreSQLViewCreate),
+		Read:   PGResourceFunc(resourcePostgreSQLViewRead),
+		Update: PGResourceFunc(resourcePostgreSQLViewUpdate),
+		Delete: PGResourceFunc(resourcePostgreSQLViewDelete),
+		Exists: PGResourceExistsFunc(resourcePostgreSQLViewExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			viewNameAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the view",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			viewSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The schema where the view is located. If not specified, the public schema is used",
+			},
+			viewDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the view is located. If not specified, the provider default database is used",
+			},
+			viewQueryAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The SELECT query of the view",
+				ValidateFunc: validation.StringIsNotEmpty,
+
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeViewQuery(old) == normalizeViewQuery(new)
+				},
+			},
+			viewDefinitionAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The query of the view as rewritten by PostgreSQL, used to detect changes made outside of Terraform",
+			},
+			viewOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ROLE which owns the view",
+			},
+			viewCheckOptionAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Adds a check option to the view. One of: LOCAL, CASCADED",
+				ValidateFunc: validation.StringInSlice([]string{"", "LOCAL", "CASCADED"}, false),
+			},
+			viewSecurityBarrierAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the view is a security barrier view",
+			},
+			viewSecurityInvokerAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the privileges on the underlying relations are checked against the user of the view instead of its owner",
+			},
+			viewDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the view",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLViewCreate(db *DBConnection, d *schema.ResourceData) error {
+	if err := validateViewFeatures(db, d); err != nil {
+		return err
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getViewSchema(d)
+	viewName := d.Get(viewNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	owner := d.Get(viewOwnerAttr).(string)
+	var rolesToGrant []string
+	if owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		if _, err := txn.Exec(createViewQuery(d, pgSchema, viewName, false)); err != nil {
+			return fmt.Errorf("could not create view %s: %w", viewName, err)
+		}
+
+		return setViewOwner(txn, "VIEW", pgSchema, viewName, owner)
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateViewID(database, pgSchema, viewName))
+
+	return resourcePostgreSQLViewReadImpl(db, d, true)
+}
+
+func resourcePostgreSQLViewExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	return viewExists(db, d, "v")
+}
+
+func resourcePostgreSQLViewRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLViewReadImpl(db, d, false)
+}
+
+// resourcePostgreSQLViewReadImpl reads the view. When refreshDefinition is true (after a create
+// or an update), the query of the state is kept and only the definition returned by PostgreSQL
+// is stored. Otherwise, if the definition has changed since the last apply, the query of the state
+// is replaced by the current definition so the drift is visible in the plan.
+func resourcePostgreSQLViewReadImpl(db *DBConnection, d *schema.ResourceData, refreshDefinition bool) error {
+	database, pgSchema, viewName, err := getDBViewName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var viewOwner, viewDefinition string
+	var viewOptions []string
+	err = txn.QueryRow(`
+SELECT pg_catalog.pg_get_userbyid(c.relowner), pg_catalog.pg_get_viewdef(c.oid), COALESCE(c.reloptions, '{}')
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind = 'v'
+`, pgSchema, viewName).Scan(&viewOwner, &viewDefinition, pq.Array(&viewOptions))
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL view (%s) not found in database %s", viewName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading view: %w", err)
+	}
+
+	options := parseRelOptions(viewOptions)
+
+	setViewQuery(d, viewDefinition, refreshDefinition)
+	d.Set(viewNameAttr, viewName)
+	d.Set(viewSchemaAttr, pgSchema)
+	d.Set(viewDatabaseAttr, database)
+	d.Set(viewOwnerAttr, viewOwner)
+	d.Set(viewCheckOptionAttr, strings.ToUpper(options["check_option"]))
+	d.Set(viewSecurityBarrierAttr, relOptionBool(options["security_barrier"]))
+	d.Set(viewSecurityInvokerAttr, relOptionBool(options["security_invoker"]))
+	d.SetId(generateViewID(database, pgSchema, viewName))
+
+	return nil
+}
+
+func resourcePostgreSQLViewUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if err := validateViewFeatures(db, d); err != nil {
+		return err
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	pgSchema := getViewSchema(d)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	owner := d.Get(viewOwnerAttr).(string)
+	if d.HasChange(viewOwnerAttr) {
+		oldOwner, _ := d.GetChange(viewOwnerAttr)
+		owner = oldOwner.(string)
+	}
+	var rolesToGrant []string
+	if owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		if err := setViewName(txn, d, "VIEW", pgSchema); err != nil {
+			return err
+		}
+
+		viewName := d.Get(viewNameAttr).(string)
+
+		if d.HasChanges(viewQueryAttr, viewCheckOptionAttr, viewSecurityBarrierAttr, viewSecurityInvokerAttr) {
+			if err := replaceView(txn, d, pgSchema, viewName); err != nil {
+				return err
+			}
+		}
+
+		if d.HasChange(viewOwnerAttr) {
+			return setViewOwner(txn, "VIEW", pgSchema, viewName, d.Get(viewOwnerAttr).(string))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourcePostgreSQLViewReadImpl(db, d, true)
+}
+
+// replaceView tries to replace the view in place with CREATE OR REPLACE VIEW so the views which
+// depend on it are kept. If PostgreSQL refuses it (e.g.: a column is removed or its type changes),
+// the view is dropped and created again with all its dependent views.
+func replaceView(txn *sql.Tx, d *schema.ResourceData, pgSchema, viewName string) error {
+	if _, err := txn.Exec("SAVEPOINT replace_view"); err != nil {
+		return err
+	}
+
+	_, err := txn.Exec(createViewQuery(d, pgSchema, viewName, true))
+	if err == nil {
+		_, err = txn.Exec("RELEASE SAVEPOINT replace_view")
+		return err
+	}
+
+	var pqErr *pq.Error
+	// 42P16: invalid_table_definition (e.g.: cannot drop columns from view)
+	// 42804: datatype_mismatch (e.g.: cannot change data type of view column)
+	if !errors.As(err, &pqErr) || (pqErr.Code != "42P16" && pqErr.Code != "42804") {
+		return fmt.Errorf("could not replace view %s: %w", viewName, err)
+	}
+
+	log.Printf("[DEBUG] view %s cannot be replaced in place, recreating it: %v", viewName, err)
+	if _, err := txn.Exec("ROLLBACK TO SAVEPOINT replace_view"); err != nil {
+		return err
+	}
+
+	return recreateWithDependentViews(txn, "v", pgSchema, viewName, func() error {
+		if _, err := txn.Exec(fmt.Sprintf("DROP VIEW %s CASCADE", tableIdentifier(pgSchema, viewName))); err != nil {
+			return fmt.Errorf("could not drop view %s: %w", viewName, err)
+		}
+		if _, err := txn.Exec(createViewQuery(d, pgSchema, viewName, false)); err != nil {
+			return fmt.Errorf("could not create view %s: %w", viewName, err)
+		}
+		return setViewOwner(txn, "VIEW", pgSchema, viewName, d.Get(viewOwnerAttr).(string))
+	})
+}
+
+func resourcePostgreSQLViewDelete(db *DBConnection, d *schema.ResourceData) error {
+	return dropView(db, d, "VIEW")
+}
+
+func createViewQuery(d *schema.ResourceData, pgSchema, viewName string, replace bool) string {
+	b := bytes.NewBufferString("CREATE ")
+	if replace {
+		b.WriteString("OR REPLACE ")
+	}
+	fmt.Fprint(b, "VIEW ", tableIdentifier(pgSchema, viewName))
+
+	var options []string
+	if d.Get(viewSecurityBarrierAttr).(bool) {
+		options = append(options, "security_barrier = true")
+	}
+	if d.Get(viewSecurityInvokerAttr).(bool) {
+		options = append(options, "security_invoker = true")
+	}
+	if len(options) > 0 {
+		fmt.Fprintf(b, " WITH (%s)", strings.Join(options, ", "))
+	}
+
+	fmt.Fprint(b, " AS ", normalizeViewQuery(d.Get(viewQueryAttr).(string)))
+
+	if checkOption := d.Get(viewCheckOptionAttr).(string); checkOption != "" {
+		fmt.Fprintf(b, " WITH %s CHECK OPTION", checkOption)
+	}
+
+	return b.String()
+}
+
+func validateViewFeatures(db *DBConnection, d *schema.ResourceData) error {
+	if d.Get(viewSecurityInvokerAttr).(bool) && !db.featureSupported(featureViewSecurityInvoker) {
+		return fmt.Errorf(
+			"security_invoker views are not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+	return nil
+}
+
+// viewExists checks if a view of the specified kind (v for views, m for materialized views) exists.
+func viewExists(db *DBConnection, d *schema.ResourceData, kind string) (bool, error) {
+	database, pgSchema, viewName, err := getDBViewName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var oid uint32
+	err = txn.QueryRow(`
+SELECT c.oid
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind = $3
+`, pgSchema, viewName, kind).Scan(&oid)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading view: %w", err)
+	}
+
+	return true, nil
+}
+
+// dropView drops a view or a materialized view (according to objectType).
+func dropView(db *DBConnection, d *schema.ResourceData, objectType string) error {
+	database, pgSchema, viewName, err := getDBViewName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var rolesToGrant []string
+	if owner := d.Get(viewOwnerAttr).(string); owner != "" {
+		rolesToGrant = append(rolesToGrant, owner)
+	}
+
+	if err := withRolesGranted(txn, rolesToGrant, func() error {
+		dropMode := "RESTRICT"
+		if d.Get(viewDropCascadeAttr).(bool) {
+			dropMode = "CASCADE"
+		}
+
+		query := fmt.Sprintf("DROP %s IF EXISTS %s %s", objectType, tableIdentifier(pgSchema, viewName), dropMode)
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not drop %s %s: %w", strings.ToLower(objectType), viewName, err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setViewName(txn *sql.Tx, d *schema.ResourceData, objectType, pgSchema string) error {
+	if !d.HasChange(viewNameAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(viewNameAttr)
+	o := oraw.(string)
+	n := nraw.(string)
+	if n == "" {
+		return fmt.Errorf("Error setting %s name to an empty string", strings.ToLower(objectType))
+	}
+
+	query := fmt.Sprintf("ALTER %s %s RENAME TO %s", objectType, tableIdentifier(pgSchema, o), pq.QuoteIdentifier(n))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating %s name: %w", strings.ToLower(objectType), err)
+	}
+
+	d.SetId(generateViewID(getDatabase(d, ""), pgSchema, n))
+
+	return nil
+}
+
+func setViewOwner(txn *sql.Tx, objectType, pgSchema, viewName, owner string) error {
+	if owner == "" {
+		return nil
+	}
+
+	query := fmt.Sprintf("ALTER %s %s OWNER TO %s", objectType, tableIdentifier(pgSchema, viewName), pq.QuoteIdentifier(owner))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating %s owner: %w", strings.ToLower(objectType), err)
+	}
+
+	return nil
+}
+
+// setViewQuery stores the definition returned by pg_get_viewdef in the state.
+// As it is rewritten by PostgreSQL, it cannot be compared with the configured query, so
+// it is compared with the definition stored during the last apply instead.
+func setViewQuery(d *schema.ResourceData, definition string, refreshDefinition bool) {
+	definition = normalizeViewQuery(definition)
+
+	stored := d.Get(viewDefinitionAttr).(string)
+	if d.Get(viewQueryAttr).(string) == "" || (!refreshDefinition && stored != "" && stored != definition) {
+		d.Set(viewQueryAttr, definition)
+	}
+	d.Set(viewDefinitionAttr, definition)
+}
+
+// readDependentViews returns all the views and materialized views which depend (directly or not)
+// on the specified relation, ordered so that a view comes after the views it depends on.
+func readDependentViews(txn *sql.Tx, pgSchema, name string) ([]pgDependentView, error) {
+	rows, err := txn.Query(`
+WITH RECURSIVE dependents(oid, depth) AS (
+    SELECT DISTINCT r.ev_class, 1
+      FROM pg_catalog.pg_depend d
+      JOIN pg_catalog.pg_rewrite r ON r.oid = d.objid
+     WHERE d.classid = 'pg_catalog.pg_rewrite'::regclass
+       AND d.refobjid = (
+           SELECT c.oid FROM pg_catalog.pg_class c
+             JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+            WHERE n.nspname = $1 AND c.relname = $2
+       )
+       AND r.ev_class <> d.refobjid
+    UNION
+    SELECT DISTINCT r.ev_class, dependents.depth + 1
+      FROM dependents
+      JOIN pg_catalog.pg_depend d ON d.refobjid = dependents.oid
+      JOIN pg_catalog.pg_rewrite r ON r.oid = d.objid
+     WHERE d.classid = 'pg_catalog.pg_rewrite'::regclass
+       AND r.ev_class <> dependents.oid
+)
+SELECT n.nspname, c.relname, c.relkind::TEXT, pg_catalog.pg_get_viewdef(c.oid), COALESCE(c.reloptions, '{}'),
+       pg_catalog.pg_get_userbyid(c.relowner), COALESCE(t.spcname, ''), c.relispopulated,`+viewPropertiesColumns+`
+  FROM (SELECT oid, max(depth) AS depth FROM dependents GROUP BY oid) dep
+  JOIN pg_catalog.pg_class c ON c.oid = dep.oid
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+  LEFT JOIN pg_catalog.pg_tablespace t ON t.oid = c.reltablespace
+ ORDER BY dep.depth
+`, pgSchema, name)
+	if err != nil {
+		return nil, fmt.Errorf("could not read dependent views of %s: %w", name, err)
+	}
+	defer rows.Close()
+
+	views := []pgDependentView{}
+	for rows.Next() {
+		var v pgDependentView
+		dest := append([]interface{}{
+			&v.Schema, &v.Name, &v.Kind, &v.Definition, pq.Array(&v.Options),
+			&v.Owner, &v.Tablespace, &v.Populated,
+		}, v.pgViewProperties.scanDest()...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("could not scan dependent view: %w", err)
+		}
+		views = append(views, v)
+	}
+
+	return views, rows.Err()
+}
+
+// readViewProperties returns the properties of a view or materialized view which are lost when it's dropped.
+func readViewProperties(txn *sql.Tx, pgSchema, name string) (pgViewProperties, error) {
+	var p pgViewProperties
+	err := txn.QueryRow(`
+SELECT`+viewPropertiesColumns+`
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relname = $2
+`, pgSchema, name).Scan(p.scanDest()...)
+	if err != nil {
+		return p, fmt.Errorf("could not read privileges, comments, indexes and triggers of %s: %w", name, err)
+	}
+	return p, nil
+}
+
+// restoreViewProperties restores the privileges, comments, indexes and triggers of a view
+// or materialized view (according to kind) which has been dropped and created again.
+// The comments of the columns which do not exist anymore are not restored.
+func restoreViewProperties(txn *sql.Tx, kind, pgSchema, name string, p pgViewProperties) error {
+	rows, err := txn.Query(`
+SELECT attname FROM pg_catalog.pg_attribute
+ WHERE attrelid = $1::regclass AND attnum > 0 AND NOT attisdropped
+`, tableIdentifier(pgSchema, name))
+	if err != nil {
+		return fmt.Errorf("could not read columns of %s: %w", name, err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return fmt.Errorf("could not scan column of %s: %w", name, err)
+		}
+		columns = append(columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, query := range restoreViewPropertiesQueries(kind, pgSchema, name, p, columns) {
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not restore privileges, comments, indexes or triggers of %s.%s: %w", pgSchema, name, err)
+		}
+	}
+	return nil
+}
+
+func restoreViewPropertiesQueries(kind, pgSchema, name string, p pgViewProperties, columns []string) []string {
+	identifier := tableIdentifier(pgSchema, name)
+	queries := append([]string{}, p.Grants...)
+
+	if p.Comment != "" {
+		queries = append(queries, fmt.Sprintf("COMMENT ON %s %s IS %s", viewObjectType(kind), identifier, pq.QuoteLiteral(p.Comment)))
+	}
+	for i, column := range p.CommentedColumns {
+		if i < len(p.ColumnComments) && sliceContainsStr(columns, column) {
+			queries = append(queries, fmt.Sprintf(
+				"COMMENT ON COLUMN %s.%s IS %s", identifier, pq.QuoteIdentifier(column), pq.QuoteLiteral(p.ColumnComments[i]),
+			))
+		}
+	}
+
+	queries = append(queries, p.Indexes...)
+	return append(queries, p.Triggers...)
+}
+
+// recreateWithDependentViews saves the definition of the views depending on the specified
+// relation, calls dropAndCreate which is expected to drop it with CASCADE and create it again,
+// then recreates the dependent views with their options and owner.
+// The privileges, comments, indexes and triggers of the relation and of its dependent views are restored.
+func recreateWithDependentViews(txn *sql.Tx, kind, pgSchema, name string, dropAndCreate func() error) error {
+	properties, err := readViewProperties(txn, pgSchema, name)
+	if err != nil {
+		return err
+	}
+
+	dependents, err := readDependentViews(txn, pgSchema, name)
+	if err != nil {
+		return err
+	}
+
+	if err := dropAndCreate(); err != nil {
+		return err
+	}
+
+	if err := restoreViewProperties(txn, kind, pgSchema, name, properties); err != nil {
+		return err
+	}
+
+	for _, v := range dependents {
+		if _, err := txn.Exec(createDependentViewQuery(v)); err != nil {
+			return fmt.Errorf("could not recreate dependent view %s.%s: %w", v.Schema, v.Name, err)
+		}
+
+		if err := setViewOwner(txn, viewObjectType(v.Kind), v.Schema, v.Name, v.Owner); err != nil {
+			return err
+		}
+
+		if err := restoreViewProperties(txn, v.Kind, v.Schema, v.Name, v.pgViewProperties); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// viewObjectType returns the object type of a view according to its relkind.
+func viewObjectType(kind string) string {
+	if kind == "m" {
+		return "MATERIALIZED VIEW"
+	}
+	return "VIEW"
+}
+
+func createDependentViewQuery(v pgDependentView) string {
+	b := bytes.NewBufferString("CREATE ")
+	if v.Kind == "m" {
+		b.WriteString("MATERIALIZED ")
+	}
+	fmt.Fprint(b, "VIEW ", tableIdentifier(v.Schema, v.Name))
+
+	if len(v.Options) > 0 {
+		fmt.Fprintf(b, " WITH (%s)", strings.Join(v.Options, ", "))
+	}
+	if v.Kind == "m" && v.Tablespace != "" {
+		fmt.Fprint(b, " TABLESPACE ", pq.QuoteIdentifier(v.Tablespace))
+	}
+
+	fmt.Fprint(b, " AS ", normalizeViewQuery(v.Definition))
+
+	if v.Kind == "m" && !v.Populated {
+		b.WriteString(" WITH NO DATA")
+	}
+
+	return b.String()
+}
+
+// parseRelOptions parses pg_class.reloptions (e.g.: {security_barrier=true,check_option=local}).
+func parseRelOptions(options []string) map[string]string {
+	parsed := make(map[string]string, len(options))
+	for _, option := range options {
+		parts := strings.SplitN(option, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		parsed[parts[0]] = parts[1]
+	}
+	return parsed
+}
+
+// relOptionBool parses a boolean reloption which can be set with any boolean syntax
+// accepted by PostgreSQL (e.g.: on, true, 1).
+func relOptionBool(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "on", "yes", "1", "t", "y":
+		return true
+	}
+	return false
+}
+
+// normalizeViewQuery removes the leading and trailing whitespaces and semicolons of a query,
+// e.g.: pg_get_viewdef returns " SELECT 1;"
+func normalizeViewQuery(query string) string {
+	return strings.TrimRight(strings.TrimSpace(query), "; \t\n")
+}
+
+func getViewSchema(d *schema.ResourceData) string {
+	if v, ok := d.GetOk(viewSchemaAttr); ok {
+		return v.(string)
+	}
+	return "public"
+}
+
+func generateViewID(database, pgSchema, viewName string) string {
+	return strings.Join([]string{database, pgSchema, viewName}, ".")
+}
+
+// getDBViewName returns database, schema and view name. If we are importing this resource,
+// they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBViewName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	pgSchema := getViewSchema(d)
+	viewName := d.Get(viewNameAttr).(string)
+
+	// When importing, we have to parse the ID to find database, schema and view names.
+	if viewName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("view ID %s has not the expected format 'database.schema.view': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		pgSchema = parsed[1]
+		viewName = parsed[2]
+	}
+	return database, pgSchema, viewName, nil
+}
@@ -0,0 +1,280 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateViewQuery(t *testing.T) {
+	cases := []struct {
+		resource map[string]interface{}
+		replace  bool
+		expected string
+	}{
+		{
+			resource: map[string]interface{}{
+				"name":  "v",
+				"query": "SELECT 1;\n",
+			},
+			expected: `CREATE VIEW "public"."v" AS SELECT 1`,
+		},
+		{
+			resource: map[string]interface{}{
+				"name":             "v",
+				"query":            "SELECT * FROM t WHERE a > 0",
+				"security_barrier": true,
+				"security_invoker": true,
+				"check_option":     "LOCAL",
+			},
+			replace:  true,
+			expected: `CREATE OR REPLACE VIEW "public"."v" WITH (security_barrier = true, security_invoker = true) AS SELECT * FROM t WHERE a > 0 WITH LOCAL CHECK OPTION`,
+		},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourcePostgreSQLView().Schema, c.resource)
+		assert.Equal(t, c.expected, createViewQuery(d, "public", "v", c.replace))
+	}
+}
+
+func TestCreateDependentViewQuery(t *testing.T) {
+	assert.Equal(t,
+		`CREATE VIEW "s"."v" WITH (check_option=local) AS SELECT t.a FROM t`,
+		createDependentViewQuery(pgDependentView{
+			Schema: "s", Name: "v", Kind: "v", Definition: " SELECT t.a FROM t;", Options: []string{"check_option=local"},
+		}),
+	)
+	assert.Equal(t,
+		`CREATE MATERIALIZED VIEW "s"."m" TABLESPACE "fast" AS SELECT t.a FROM t WITH NO DATA`,
+		createDependentViewQuery(pgDependentView{
+			Schema: "s", Name: "m", Kind: "m", Definition: " SELECT t.a FROM t;", Tablespace: "fast",
+		}),
+	)
+}
+
+func TestRestoreViewPropertiesQueries(t *testing.T) {
+	properties := pgViewProperties{
+		Grants:           []string{`GRANT SELECT ON s.m TO "reader"`},
+		Comment:          "Orders by day",
+		CommentedColumns: []string{"day", "removed"},
+		ColumnComments:   []string{"The day", "Not there anymore"},
+		Indexes:          []string{"CREATE UNIQUE INDEX m_day_idx ON s.m USING btree (day)"},
+	}
+
+	assert.Equal(t,
+		[]string{
+			`GRANT SELECT ON s.m TO "reader"`,
+			`COMMENT ON MATERIALIZED VIEW "s"."m" IS 'Orders by day'`,
+			`COMMENT ON COLUMN "s"."m"."day" IS 'The day'`,
+			"CREATE UNIQUE INDEX m_day_idx ON s.m USING btree (day)",
+		},
+		restoreViewPropertiesQueries("m", "s", "m", properties, []string{"day", "total"}),
+	)
+
+	properties = pgViewProperties{
+		Triggers: []string{"CREATE TRIGGER v_insert INSTEAD OF INSERT ON s.v FOR EACH ROW EXECUTE FUNCTION s.f()"},
+	}
+	assert.Equal(t,
+		[]string{"CREATE TRIGGER v_insert INSTEAD OF INSERT ON s.v FOR EACH ROW EXECUTE FUNCTION s.f()"},
+		restoreViewPropertiesQueries("v", "s", "v", properties, nil),
+	)
+}
+
+func TestParseRelOptions(t *testing.T) {
+	options := parseRelOptions([]string{"security_barrier=on", "check_option=cascaded"})
+
+	assert.Equal(t, map[string]string{"security_barrier": "on", "check_option": "cascaded"}, options)
+	assert.True(t, relOptionBool(options["security_barrier"]))
+	assert.False(t, relOptionBool(options["security_invoker"]))
+}
+
+func TestNormalizeViewQuery(t *testing.T) {
+	assert.Equal(t, "SELECT 1", normalizeViewQuery(" SELECT 1;"))
+	assert.Equal(t, "SELECT 1", normalizeViewQuery("\n  SELECT 1 ;\n"))
+}
+
+func TestAccPostgresqlView_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	config := fmt.Sprintf(`
+resource "postgresql_view" "test" {
+  database         = "%s"
+  name             = "test_view"
+  query            = "SELECT 1 AS a, 'foo'::text AS b"
+  security_barrier = true
+}
+`, dbName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlViewExists("postgresql_view.test"),
+					resource.TestCheckResourceAttr("postgresql_view.test", "name", "test_view"),
+					resource.TestCheckResourceAttr("postgresql_view.test", "schema", "public"),
+					resource.TestCheckResourceAttr("postgresql_view.test", "security_barrier", "true"),
+					resource.TestCheckResourceAttrSet("postgresql_view.test", "definition"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_view.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"query", "drop_cascade"},
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlView_ReplaceWithDependents(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	config := `
+resource "postgresql_view" "base" {
+  database = "%s"
+  name     = "base_view"
+  query    = "%s"
+}
+
+resource "postgresql_view" "dependent" {
+  database = "%s"
+  name     = "dependent_view"
+  query    = "SELECT a FROM ${postgresql_view.base.name}"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, dbName, "SELECT 1 AS a, 2 AS b", dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlViewExists("postgresql_view.base"),
+					testAccCheckPostgresqlViewExists("postgresql_view.dependent"),
+				),
+			},
+			{
+				// Column b is removed so the view cannot be replaced in place.
+				Config: fmt.Sprintf(config, dbName, "SELECT 3 AS a", dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlViewExists("postgresql_view.base"),
+					testAccCheckPostgresqlViewExists("postgresql_view.dependent"),
+					resource.TestCheckResourceAttr("postgresql_view.base", "query", "SELECT 3 AS a"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlViewExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		kind := "v"
+		if rs.Type == "postgresql_materialized_view" {
+			kind = "m"
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkViewExists(txn, kind, rs.Primary.Attributes["schema"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking view %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("View not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPostgresqlViewDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		kind := ""
+		switch rs.Type {
+		case "postgresql_view":
+			kind = "v"
+		case "postgresql_materialized_view":
+			kind = "m"
+		default:
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes["database"])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkViewExists(txn, kind, rs.Primary.Attributes["schema"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking view %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("View still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func checkViewExists(txn *sql.Tx, kind, pgSchema, viewName string) (bool, error) {
+	var _rez int
+	err := txn.QueryRow(`
+SELECT 1
+  FROM pg_catalog.pg_class c
+  JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind = $3
+`, pgSchema, viewName, kind).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about view: %s", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,119 @@
+package postgresql
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	scramSHA256Prefix = "SCRAM-SHA-256"
+
+	// scramDefaultIterations and scramSaltLength are the values used by PostgreSQL
+	// when it hashes a password itself.
+	scramDefaultIterations = 4096
+	scramSaltLength        = 16
+)
+
+// scramVerifier is a SCRAM-SHA-256 password verifier as stored by PostgreSQL in pg_authid.rolpassword:
+// SCRAM-SHA-256$<iterations>:<salt>$<StoredKey>:<ServerKey>
+type scramVerifier struct {
+	Iterations int
+	Salt       []byte
+	StoredKey  []byte
+	ServerKey  []byte
+}
+
+func parseSCRAMVerifier(verifier string) (*scramVerifier, error) {
+	parts := strings.Split(verifier, "$")
+	if len(parts) != 3 || parts[0] != scramSHA256Prefix {
+		return nil, fmt.Errorf("invalid SCRAM-SHA-256 verifier format")
+	}
+
+	iterationsAndSalt := strings.Split(parts[1], ":")
+	keys := strings.Split(parts[2], ":")
+	if len(iterationsAndSalt) != 2 || len(keys) != 2 {
+		return nil, fmt.Errorf("invalid SCRAM-SHA-256 verifier format")
+	}
+
+	iterations, err := strconv.Atoi(iterationsAndSalt[0])
+	if err != nil || iterations <= 0 {
+		return nil, fmt.Errorf("invalid iteration count in SCRAM-SHA-256 verifier")
+	}
+
+	v := &scramVerifier{Iterations: iterations}
+	for _, field := range []struct {
+		value string
+		dest  *[]byte
+	}{
+		{iterationsAndSalt[1], &v.Salt},
+		{keys[0], &v.StoredKey},
+		{keys[1], &v.ServerKey},
+	} {
+		decoded, err := base64.StdEncoding.DecodeString(field.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 value in SCRAM-SHA-256 verifier: %w", err)
+		}
+		*field.dest = decoded
+	}
+
+	return v, nil
+}
+
+// String returns the verifier in the format stored by PostgreSQL.
+func (v *scramVerifier) String() string {
+	return fmt.Sprintf(
+		"%s$%d:%s$%s:%s",
+		scramSHA256Prefix,
+		v.Iterations,
+		base64.StdEncoding.EncodeToString(v.Salt),
+		base64.StdEncoding.EncodeToString(v.StoredKey),
+		base64.StdEncoding.EncodeToString(v.ServerKey),
+	)
+}
+
+// newSCRAMVerifier computes the SCRAM-SHA-256 verifier of a password as defined by RFC 5802 and RFC 7677.
+// The password is not normalized with SASLprep, which is a no-op for ASCII passwords.
+// PostgreSQL also uses the raw password when it's not valid UTF-8 or SASLprep fails.
+func newSCRAMVerifier(password string, salt []byte, iterations int) *scramVerifier {
+	saltedPassword := pbkdf2.Key([]byte(password), salt, iterations, sha256.Size, sha256.New)
+
+	clientKey := scramHMAC(saltedPassword, "Client Key")
+	storedKey := sha256.Sum256(clientKey)
+
+	return &scramVerifier{
+		Iterations: iterations,
+		Salt:       salt,
+		StoredKey:  storedKey[:],
+		ServerKey:  scramHMAC(saltedPassword, "Server Key"),
+	}
+}
+
+// newRandomSCRAMVerifier computes the SCRAM-SHA-256 verifier of a password with a random salt.
+func newRandomSCRAMVerifier(password string, iterations int) (*scramVerifier, error) {
+	salt := make([]byte, scramSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("could not generate SCRAM-SHA-256 salt: %w", err)
+	}
+	return newSCRAMVerifier(password, salt, iterations), nil
+}
+
+// matches returns true if the verifier has been computed from the given password.
+func (v *scramVerifier) matches(password string) bool {
+	computed := newSCRAMVerifier(password, v.Salt, v.Iterations)
+	return subtle.ConstantTimeCompare(computed.StoredKey, v.StoredKey) == 1 &&
+		subtle.ConstantTimeCompare(computed.ServerKey, v.ServerKey) == 1
+}
+
+func scramHMAC(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
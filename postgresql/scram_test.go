@@ -0,0 +1,44 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Verifier of the password "pencil" with the salt and iteration count of RFC 7677.
+const testSCRAMVerifier = "SCRAM-SHA-256$4096:W22ZaJ0SNY7soEsUEjb6gQ==$WG5d8oPm3OtcPnkdi4Uo7BkeZkBFzpcXkuLmtbsT4qY=:wfPLwcE6nTWhTAmQ7tl2KeoiWGPlZqQxSrmfPwDl2dU="
+
+func TestParseSCRAMVerifier(t *testing.T) {
+	verifier, err := parseSCRAMVerifier(testSCRAMVerifier)
+	assert.NoError(t, err)
+	assert.Equal(t, 4096, verifier.Iterations)
+	assert.Len(t, verifier.Salt, 16)
+	assert.Equal(t, testSCRAMVerifier, verifier.String())
+
+	for _, invalid := range []string{
+		"md5c5f2d8e6a0cf2fc3e8a6bf2ff8ab1e3b",
+		"SCRAM-SHA-256$4096:W22ZaJ0SNY7soEsUEjb6gQ==",
+		"SCRAM-SHA-256$abc:W22ZaJ0SNY7soEsUEjb6gQ==$a:b",
+		"SCRAM-SHA-256$4096:not base64!$WG5d8oPm3OtcPnkdi4Uo7BkeZkBFzpcXkuLmtbsT4qY=:wfPLwcE6nTWhTAmQ7tl2KeoiWGPlZqQxSrmfPwDl2dU=",
+	} {
+		_, err := parseSCRAMVerifier(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestSCRAMVerifierMatches(t *testing.T) {
+	verifier, err := parseSCRAMVerifier(testSCRAMVerifier)
+	assert.NoError(t, err)
+
+	assert.True(t, verifier.matches("pencil"))
+	assert.False(t, verifier.matches("pen"))
+	assert.False(t, verifier.matches(""))
+}
+
+func TestNewSCRAMVerifier(t *testing.T) {
+	verifier, err := parseSCRAMVerifier(testSCRAMVerifier)
+	assert.NoError(t, err)
+
+	assert.Equal(t, testSCRAMVerifier, newSCRAMVerifier("pencil", verifier.Salt, 4096).String())
+}
@@ -0,0 +1,224 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+// Attributes shared by the user-defined types resources
+// (postgresql_enum_type, postgresql_composite_type, postgresql_domain and postgresql_range_type).
+const (
+	typeNameAttr        = "name"
+	typeSchemaAttr      = "schema"
+	typeDatabaseAttr    = "database"
+	typeOwnerAttr       = "owner"
+	typeDropCascadeAttr = "drop_cascade"
+)
+
+// pg_type.typtype values
+const (
+	typTypeEnum      = "e"
+	typTypeComposite = "c"
+	typTypeDomain    = "d"
+	typTypeRange     = "r"
+)
+
+// typeCommonSchema returns the attributes shared by all the user-defined types resources.
+func typeCommonSchema(kind string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		typeNameAttr: {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  fmt.Sprintf("The name of the %s", kind),
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		typeSchemaAttr: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: fmt.Sprintf("The schema where the %s is located. If not specified, the public schema is used", kind),
+		},
+		typeDatabaseAttr: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: fmt.Sprintf("The database where the %s is located. If not specified, the provider default database is used", kind),
+		},
+		typeOwnerAttr: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("The ROLE which owns the %s", kind),
+		},
+		typeDropCascadeAttr: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: fmt.Sprintf("When true, will also drop all the objects that depend on the %s (such as columns or functions)", kind),
+		},
+	}
+}
+
+const typeQuery = `
+SELECT t.oid, pg_catalog.pg_get_userbyid(t.typowner)
+  FROM pg_catalog.pg_type t
+  JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+ WHERE n.nspname = $1 AND t.typname = $2 AND t.typtype = $3
+`
+
+// typeExists checks if a type of the specified kind (pg_type.typtype) exists.
+func typeExists(db *DBConnection, d *schema.ResourceData, typType string) (bool, error) {
+	database, pgSchema, typeName, err := getDBTypeName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var oid uint32
+	var owner string
+	err = txn.QueryRow(typeQuery, pgSchema, typeName, typType).Scan(&oid, &owner)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading type: %w", err)
+	}
+
+	return true, nil
+}
+
+// typeOwnerRoles returns the roles to grant to manage the type, i.e.: its current owner.
+func typeOwnerRoles(d *schema.ResourceData) []string {
+	owner := d.Get(typeOwnerAttr).(string)
+	if d.HasChange(typeOwnerAttr) {
+		oldOwner, _ := d.GetChange(typeOwnerAttr)
+		owner = oldOwner.(string)
+	}
+	if owner == "" {
+		return nil
+	}
+	return []string{owner}
+}
+
+// dropType drops a type or a domain (according to objectType).
+func dropType(db *DBConnection, d *schema.ResourceData, objectType string) error {
+	database, pgSchema, typeName, err := getDBTypeName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := withRolesGranted(txn, typeOwnerRoles(d), func() error {
+		dropMode := "RESTRICT"
+		if d.Get(typeDropCascadeAttr).(bool) {
+			dropMode = "CASCADE"
+		}
+
+		query := fmt.Sprintf("DROP %s IF EXISTS %s %s", objectType, tableIdentifier(pgSchema, typeName), dropMode)
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("could not drop %s %s: %w", strings.ToLower(objectType), typeName, err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setTypeName(txn *sql.Tx, d *schema.ResourceData, objectType, pgSchema string) error {
+	if !d.HasChange(typeNameAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(typeNameAttr)
+	o := oraw.(string)
+	n := nraw.(string)
+	if n == "" {
+		return errors.New("Error setting type name to an empty string")
+	}
+
+	query := fmt.Sprintf("ALTER %s %s RENAME TO %s", objectType, tableIdentifier(pgSchema, o), pq.QuoteIdentifier(n))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating %s name: %w", strings.ToLower(objectType), err)
+	}
+
+	d.SetId(generateTypeID(getDatabase(d, ""), pgSchema, n))
+
+	return nil
+}
+
+func setTypeOwner(txn *sql.Tx, objectType, pgSchema, typeName, owner string) error {
+	if owner == "" {
+		return nil
+	}
+
+	query := fmt.Sprintf("ALTER %s %s OWNER TO %s", objectType, tableIdentifier(pgSchema, typeName), pq.QuoteIdentifier(owner))
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("Error updating %s owner: %w", strings.ToLower(objectType), err)
+	}
+
+	return nil
+}
+
+func getTypeSchema(d *schema.ResourceData) string {
+	if v, ok := d.GetOk(typeSchemaAttr); ok {
+		return v.(string)
+	}
+	return "public"
+}
+
+func generateTypeID(database, pgSchema, typeName string) string {
+	return strings.Join([]string{database, pgSchema, typeName}, ".")
+}
+
+// getDBTypeName returns database, schema and type name. If we are importing this resource,
+// they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBTypeName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	pgSchema := getTypeSchema(d)
+	typeName := d.Get(typeNameAttr).(string)
+
+	// When importing, we have to parse the ID to find database, schema and type names.
+	if typeName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("type ID %s has not the expected format 'database.schema.type': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		pgSchema = parsed[1]
+		typeName = parsed[2]
+	}
+	return database, pgSchema, typeName, nil
+}